@@ -5,6 +5,7 @@ require (
 	github.com/dnaeon/go-vcr v1.1.0
 	github.com/dustin/go-humanize v1.0.0
 	github.com/google/go-cmp v0.5.5
+	github.com/hashicorp/go-cty v1.4.1-0.20200414143053-d3edf31b6320
 	github.com/hashicorp/go-retryablehttp v0.6.8
 	github.com/hashicorp/terraform-plugin-sdk/v2 v2.5.0
 	github.com/scaleway/scaleway-sdk-go v1.0.0-beta.7.0.20210414083420-b36f019ca892
@@ -0,0 +1,690 @@
+package scaleway
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/url"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/dnaeon/go-vcr/cassette"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// scrubKind is the kind of a sensitive value. It defines the format of the placeholders replacing it.
+type scrubKind string
+
+const (
+	scrubKindProject      = scrubKind("project")
+	scrubKindOrganization = scrubKind("organization")
+	scrubKindIP           = scrubKind("ip")
+	scrubKindAccessKey    = scrubKind("access_key")
+	scrubKindSecretKey    = scrubKind("secret_key")
+	// scrubKindSecret values are always replaced by the same placeholder as they are never reused in requests.
+	scrubKindSecret = scrubKind("secret")
+)
+
+// scrubbedSecret is the placeholder of scrubKindSecret values.
+const scrubbedSecret = "REDACTED"
+
+// scrubbedCertificate is a placeholder for the certificates recorded in cassettes.
+const scrubbedCertificate = "-----BEGIN CERTIFICATE-----\nREDACTED\n-----END CERTIFICATE-----\n"
+
+// placeholderRegex matches the placeholders returned by formatPlaceholder, IP placeholders excluded.
+var placeholderRegex = regexp.MustCompile(`^((11111111-1111-4111-8111|22222222-2222-4222-8222|33333333-3333-4333-8333)-\d{12}|SCW\d{17})$`)
+
+// generatedNameRegex matches the names generated by newRandomName, which change on every run.
+var generatedNameRegex = regexp.MustCompile(`^tf-[a-z0-9-]+-[a-z]+-[a-z]+$`)
+
+var (
+	// privateIPNetworks are the networks of the addresses which are not scrubbed.
+	privateIPNetworks = parseCIDRs("0.0.0.0/8", "10.0.0.0/8", "100.64.0.0/10", "127.0.0.0/8", "169.254.0.0/16", "172.16.0.0/12", "192.168.0.0/16", "::/128", "::1/128", "fc00::/7", "fe80::/10")
+	// placeholderIPNetworks are the documentation networks used by the IP placeholders.
+	placeholderIPNetworks = parseCIDRs("198.51.100.0/24", "2001:db8::/32")
+)
+
+func parseCIDRs(cidrs ...string) []*net.IPNet {
+	networks := []*net.IPNet(nil)
+	for _, cidr := range cidrs {
+		_, network, err := net.ParseCIDR(cidr)
+		if err != nil {
+			panic(err)
+		}
+		networks = append(networks, network)
+	}
+	return networks
+}
+
+func ipInNetworks(ip net.IP, networks []*net.IPNet) bool {
+	for _, network := range networks {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// isPublicIP returns true if the value is a public IP address, placeholders excluded.
+func isPublicIP(value string) bool {
+	ip := net.ParseIP(value)
+	return ip != nil && !ipInNetworks(ip, privateIPNetworks) && !ipInNetworks(ip, placeholderIPNetworks)
+}
+
+// cassetteScrubberConfig defines the sensitive values removed from the cassettes.
+type cassetteScrubberConfig struct {
+	// jsonKeys are the JSON keys whose string values are replaced by a placeholder of the given kind.
+	jsonKeys map[string]scrubKind
+	// queryParameters are the URL query parameters whose values are replaced by a placeholder of the given kind.
+	queryParameters map[string]scrubKind
+	// headers are removed from the recorded requests and responses.
+	headers []string
+	// files are the API files rewritten when the request path ends with the given suffix.
+	// The function receives the decoded content of the file and returns the content to record.
+	files map[string]func(content []byte) []byte
+}
+
+// defaultCassetteScrubberConfig returns the scrubber configuration used by the acceptance tests.
+func defaultCassetteScrubberConfig() *cassetteScrubberConfig {
+	return &cassetteScrubberConfig{
+		jsonKeys: map[string]scrubKind{
+			"organization":    scrubKindOrganization,
+			"organization_id": scrubKindOrganization,
+			"project":         scrubKindProject,
+			"project_id":      scrubKindProject,
+			"address":         scrubKindIP,
+			"ip_address":      scrubKindIP,
+			"ip":              scrubKindIP,
+			"gateway":         scrubKindIP,
+		},
+		queryParameters: map[string]scrubKind{
+			"organization":         scrubKindOrganization,
+			"organization_id":      scrubKindOrganization,
+			"project":              scrubKindProject,
+			"project_id":           scrubKindProject,
+			"X-Amz-Credential":     scrubKindSecret,
+			"X-Amz-Signature":      scrubKindSecret,
+			"X-Amz-Security-Token": scrubKindSecret,
+		},
+		headers: []string{
+			"X-Auth-Token",
+			"Authorization",
+			"X-Amz-Security-Token",
+		},
+		files: map[string]func(content []byte) []byte{
+			"/kubeconfig":  scrubKubeconfig,
+			"/certificate": func(_ []byte) []byte { return []byte(scrubbedCertificate) },
+		},
+	}
+}
+
+var (
+	kubeconfigTokenRegex = regexp.MustCompile(`(?m)^(\s*token:\s*).+$`)
+	kubeconfigCARegex    = regexp.MustCompile(`(?m)^(\s*certificate-authority-data:\s*).+$`)
+)
+
+// scrubKubeconfig replaces the token and the certificate authority of a kubeconfig. The kubeconfig must
+// remain valid as it is parsed by the k8s cluster resource.
+func scrubKubeconfig(content []byte) []byte {
+	content = kubeconfigTokenRegex.ReplaceAll(content, []byte("${1}"+scrubbedSecret))
+	return kubeconfigCARegex.ReplaceAll(content, []byte("${1}"+base64.StdEncoding.EncodeToString([]byte(scrubbedCertificate))))
+}
+
+// cassetteScrubber rewrites the sensitive values of the recorded interactions to stable placeholders.
+//
+// A value is always replaced by the same placeholder within a cassette so the recorded interactions stay consistent.
+// The values of the test configuration (project, organization and credentials) are replaced by the first placeholder
+// of their kind and restored with the values of the current configuration at replay.
+type cassetteScrubber struct {
+	config *cassetteScrubberConfig
+
+	mu sync.Mutex
+	// placeholders are the placeholders indexed by the value they replace.
+	placeholders map[string]string
+	counters     map[scrubKind]int
+	// knownValues are the values of the test configuration indexed by their placeholder.
+	knownValues map[string]string
+}
+
+// newCassetteScrubber creates a scrubber that knows the values of the given profile.
+func newCassetteScrubber(config *cassetteScrubberConfig, profile *scw.Profile) *cassetteScrubber {
+	s := &cassetteScrubber{
+		config:       config,
+		placeholders: map[string]string{},
+		counters:     map[scrubKind]int{},
+		knownValues:  map[string]string{},
+	}
+
+	known := []struct {
+		kind  scrubKind
+		value *string
+	}{
+		{scrubKindProject, profile.DefaultProjectID},
+		{scrubKindOrganization, profile.DefaultOrganizationID},
+		{scrubKindAccessKey, profile.AccessKey},
+		{scrubKindSecretKey, profile.SecretKey},
+	}
+	for _, k := range known {
+		// The placeholder is reserved even if the value is not known so known values always get the first placeholder.
+		s.counters[k.kind]++
+		placeholder := formatPlaceholder(k.kind, s.counters[k.kind])
+		if k.value != nil && *k.value != "" {
+			s.placeholders[*k.value] = placeholder
+			s.knownValues[placeholder] = *k.value
+		}
+	}
+
+	return s
+}
+
+// formatPlaceholder returns the n-th placeholder of a kind. Placeholders are valid values of their kind.
+func formatPlaceholder(kind scrubKind, n int) string {
+	switch kind {
+	case scrubKindProject:
+		return fmt.Sprintf("11111111-1111-4111-8111-%012d", n)
+	case scrubKindOrganization:
+		return fmt.Sprintf("22222222-2222-4222-8222-%012d", n)
+	case scrubKindSecretKey:
+		return fmt.Sprintf("33333333-3333-4333-8333-%012d", n)
+	case scrubKindAccessKey:
+		return fmt.Sprintf("SCW%017d", n)
+	case scrubKindIP:
+		// 198.51.100.0/24 is reserved for documentation, cassettes are not expected to contain more than 254 public IPs.
+		return fmt.Sprintf("198.51.100.%d", (n-1)%254+1)
+	}
+	return scrubbedSecret
+}
+
+// placeholder returns the placeholder of a value, allocating a new one if needed.
+// It must be called with the lock held.
+func (s *cassetteScrubber) placeholder(kind scrubKind, value string) string {
+	if value == "" || value == scrubbedSecret {
+		return value
+	}
+	if kind == scrubKindSecret {
+		return scrubbedSecret
+	}
+	if kind == scrubKindIP && !isPublicIP(value) {
+		return value
+	}
+	if placeholder, exist := s.placeholders[value]; exist {
+		return placeholder
+	}
+	// Scrubbing an already scrubbed cassette must not change it.
+	if placeholderRegex.MatchString(value) {
+		return value
+	}
+
+	s.counters[kind]++
+	placeholder := formatPlaceholder(kind, s.counters[kind])
+	if kind == scrubKindIP && net.ParseIP(value).To4() == nil {
+		placeholder = fmt.Sprintf("2001:db8::%x", s.counters[kind])
+	}
+	s.placeholders[value] = placeholder
+	return placeholder
+}
+
+// replacePlaceholders replaces the values having a placeholder in a text, longest values first.
+// It must be called with the lock held.
+func (s *cassetteScrubber) replacePlaceholders(text string) string {
+	values := make([]string, 0, len(s.placeholders))
+	for value := range s.placeholders {
+		values = append(values, value)
+	}
+	sort.Slice(values, func(i, j int) bool {
+		if len(values[i]) != len(values[j]) {
+			return len(values[i]) > len(values[j])
+		}
+		return values[i] < values[j]
+	})
+	for _, value := range values {
+		text = strings.ReplaceAll(text, value, s.placeholders[value])
+	}
+	return text
+}
+
+// scrubInteraction is a cassette filter replacing the sensitive values of an interaction.
+func (s *cassetteScrubber) scrubInteraction(i *cassette.Interaction) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	path := ""
+	if u, err := url.Parse(i.Request.URL); err == nil {
+		path = u.Path
+	}
+
+	i.Request.Headers = s.scrubHeaders(i.Request.Headers)
+	i.Request.Body = s.scrubBody(i.Request.Body, "")
+	i.Request.Form = nil
+	i.Response.Headers = s.scrubHeaders(i.Response.Headers)
+	i.Response.Body = s.scrubBody(i.Response.Body, path)
+	// The URL is scrubbed last so the values found in the bodies are replaced in it.
+	i.Request.URL = s.scrubURL(i.Request.URL, func(kind scrubKind, value string) string {
+		return s.placeholder(kind, value)
+	})
+
+	return nil
+}
+
+func (s *cassetteScrubber) scrubHeaders(headers http.Header) http.Header {
+	headers = headers.Clone()
+	for _, header := range s.config.headers {
+		headers.Del(header)
+	}
+	for key, values := range headers {
+		for i, value := range values {
+			headers[key][i] = s.replacePlaceholders(value)
+		}
+	}
+	return headers
+}
+
+// scrubURL replaces the sensitive query parameters of a URL using replace and the known values in its path.
+func (s *cassetteScrubber) scrubURL(rawURL string, replace func(kind scrubKind, value string) string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return s.replacePlaceholders(rawURL)
+	}
+
+	query := u.Query()
+	for parameter, kind := range s.config.queryParameters {
+		values, exist := query[parameter]
+		if !exist {
+			continue
+		}
+		for i, value := range values {
+			values[i] = replace(kind, value)
+		}
+	}
+	u.RawQuery = query.Encode()
+	u.Path = s.replacePlaceholders(u.Path)
+	u.RawPath = ""
+	u.Host = s.replacePlaceholders(u.Host)
+
+	return u.String()
+}
+
+// scrubBody replaces the sensitive values of a body. path is the request path used to detect files.
+func (s *cassetteScrubber) scrubBody(body string, path string) string {
+	var value interface{}
+	if err := unmarshalJSONNumber(body, &value); err != nil {
+		return s.replacePlaceholders(body)
+	}
+
+	for suffix, rewrite := range s.config.files {
+		if !strings.HasSuffix(path, suffix) {
+			continue
+		}
+		if file, isObject := value.(map[string]interface{}); isObject {
+			if content, isString := file["content"].(string); isString {
+				if decoded, err := base64.StdEncoding.DecodeString(content); err == nil {
+					file["content"] = base64.StdEncoding.EncodeToString(rewrite(decoded))
+				}
+			}
+		}
+	}
+
+	value = s.scrubJSON(value, func(kind scrubKind, value string) string {
+		return s.placeholder(kind, value)
+	})
+
+	scrubbed, err := marshalJSON(value)
+	if err != nil {
+		return s.replacePlaceholders(body)
+	}
+	return s.replacePlaceholders(scrubbed)
+}
+
+// scrubJSON replaces the values of the configured keys using replace.
+func (s *cassetteScrubber) scrubJSON(value interface{}, replace func(kind scrubKind, value string) string) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, child := range v {
+			if kind, exist := s.config.jsonKeys[key]; exist {
+				if str, isString := child.(string); isString {
+					v[key] = replace(kind, str)
+					continue
+				}
+			}
+			v[key] = s.scrubJSON(child, replace)
+		}
+	case []interface{}:
+		for i, child := range v {
+			v[i] = s.scrubJSON(child, replace)
+		}
+	}
+	return value
+}
+
+// restore replaces the placeholders of the test configuration values with the values of the current configuration.
+func (s *cassetteScrubber) restore(text string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for placeholder, value := range s.knownValues {
+		text = strings.ReplaceAll(text, placeholder, value)
+	}
+	return text
+}
+
+// matcher matches a request with a recorded one on its method, URL and JSON body.
+//
+// Both requests are normalized first: the known values are replaced by their placeholders and the scrubbed
+// values and generated names are masked, so cassettes recorded with other credentials can be replayed.
+func (s *cassetteScrubber) matcher(r *http.Request, i cassette.Request) bool {
+	if r.Method != i.Method {
+		return false
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.normalizeURL(r.URL.String()) != s.normalizeURL(i.URL) {
+		return false
+	}
+
+	body := ""
+	if r.Body != nil && r.Body != http.NoBody {
+		rawBody, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			return false
+		}
+		r.Body = ioutil.NopCloser(bytes.NewReader(rawBody))
+		body = string(rawBody)
+	}
+
+	return s.normalizeBody(body) == s.normalizeBody(i.Body)
+}
+
+// maskScrubbedValue replaces a scrubbed value, whether it is a placeholder or not, by the name of its kind.
+func maskScrubbedValue(kind scrubKind, value string) string {
+	if value == "" {
+		return value
+	}
+	if kind == scrubKindIP {
+		ip := net.ParseIP(value)
+		if ip == nil || ipInNetworks(ip, privateIPNetworks) {
+			return value
+		}
+	}
+	return "<" + string(kind) + ">"
+}
+
+// normalizeURL masks the scrubbed query parameters of a URL. It must be called with the lock held.
+func (s *cassetteScrubber) normalizeURL(rawURL string) string {
+	return s.scrubURL(rawURL, maskScrubbedValue)
+}
+
+// normalizeBody returns a canonical form of a JSON body. Other bodies are only scrubbed from known values.
+// It must be called with the lock held.
+func (s *cassetteScrubber) normalizeBody(body string) string {
+	var value interface{}
+	if err := unmarshalJSONNumber(body, &value); err != nil {
+		return s.replacePlaceholders(body)
+	}
+
+	value = s.scrubJSON(value, maskScrubbedValue)
+	value = normalizeJSON(value)
+
+	normalized, err := marshalJSON(value)
+	if err != nil {
+		return s.replacePlaceholders(body)
+	}
+	return s.replacePlaceholders(normalized)
+}
+
+// normalizeJSON removes the null values and masks the generated names of a JSON value.
+func normalizeJSON(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, child := range v {
+			if child == nil {
+				delete(v, key)
+				continue
+			}
+			v[key] = normalizeJSON(child)
+		}
+	case []interface{}:
+		for i, child := range v {
+			v[i] = normalizeJSON(child)
+		}
+	case string:
+		if generatedNameRegex.MatchString(v) {
+			return "<generated_name>"
+		}
+	}
+	return value
+}
+
+func unmarshalJSONNumber(body string, value interface{}) error {
+	decoder := json.NewDecoder(strings.NewReader(body))
+	decoder.UseNumber()
+	return decoder.Decode(value)
+}
+
+// marshalJSON encodes a JSON value with sorted keys and without escaping HTML characters.
+func marshalJSON(value interface{}) (string, error) {
+	buffer := &bytes.Buffer{}
+	encoder := json.NewEncoder(buffer)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(value); err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(buffer.String(), "\n"), nil
+}
+
+// cassetteRestoringTransport restores the values of the test configuration in the replayed responses.
+type cassetteRestoringTransport struct {
+	transport http.RoundTripper
+	scrubber  *cassetteScrubber
+}
+
+func (t *cassetteRestoringTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	resp, err := t.transport.RoundTrip(r)
+	if err != nil || len(t.scrubber.knownValues) == 0 {
+		return resp, err
+	}
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	_ = resp.Body.Close()
+
+	restored := t.scrubber.restore(string(body))
+	resp.Body = ioutil.NopCloser(strings.NewReader(restored))
+	resp.ContentLength = int64(len(restored))
+	return resp, nil
+}
+
+func testCassetteScrubber() *cassetteScrubber {
+	return newCassetteScrubber(defaultCassetteScrubberConfig(), &scw.Profile{
+		AccessKey:        scw.StringPtr("SCWABCDEFGHIJKLMNOPQ"),
+		SecretKey:        scw.StringPtr("7363616c-6577-6573-6862-6f7579616161"),
+		DefaultProjectID: scw.StringPtr("951df375-e094-4d26-97c1-ba548eeb9c42"),
+	})
+}
+
+func TestCassetteScrubberScrubInteraction(t *testing.T) {
+	s := testCassetteScrubber()
+	kubeconfig := "apiVersion: v1\nclusters:\n- cluster:\n    certificate-authority-data: c2VjcmV0\n    server: https://cluster.api.k8s.fr-par.scw.cloud:6443\nusers:\n- user:\n    token: my-secret-token\n"
+
+	interactions := []*cassette.Interaction{
+		{
+			Request: cassette.Request{
+				URL:     "https://api.scaleway.com/instance/v1/zones/fr-par-1/ips",
+				Method:  http.MethodPost,
+				Headers: http.Header{"X-Auth-Token": []string{"7363616c-6577-6573-6862-6f7579616161"}},
+				Body:    `{"project":"951df375-e094-4d26-97c1-ba548eeb9c42"}`,
+			},
+			Response: cassette.Response{
+				Body: `{"ip":{"id":"ip-id","address":"51.15.212.1","organization":"2b9f3a10-7a51-4a57-9b3c-f21b1e7e0d8a","project":"951df375-e094-4d26-97c1-ba548eeb9c42"}}`,
+			},
+		},
+		{
+			Request: cassette.Request{
+				URL:    "https://api.scaleway.com/instance/v1/zones/fr-par-1/servers?organization=2b9f3a10-7a51-4a57-9b3c-f21b1e7e0d8a",
+				Method: http.MethodGet,
+			},
+			Response: cassette.Response{
+				Body: `{"servers":[{"public_ip":{"address":"51.15.212.1"},"private_ip":"10.64.0.1"}]}`,
+			},
+		},
+		{
+			Request: cassette.Request{
+				URL:    "https://api.scaleway.com/k8s/v1/regions/fr-par/clusters/cluster-id/kubeconfig",
+				Method: http.MethodGet,
+			},
+			Response: cassette.Response{
+				Body: `{"name":"kubeconfig","content":"` + base64.StdEncoding.EncodeToString([]byte(kubeconfig)) + `"}`,
+			},
+		},
+	}
+	for _, i := range interactions {
+		require.NoError(t, s.scrubInteraction(i))
+	}
+
+	assert.Empty(t, interactions[0].Request.Headers)
+	assert.Equal(t, `{"project":"11111111-1111-4111-8111-000000000001"}`, interactions[0].Request.Body)
+	assert.Equal(t, `{"ip":{"address":"198.51.100.1","id":"ip-id","organization":"22222222-2222-4222-8222-000000000002","project":"11111111-1111-4111-8111-000000000001"}}`, interactions[0].Response.Body)
+	assert.Equal(t, "https://api.scaleway.com/instance/v1/zones/fr-par-1/servers?organization=22222222-2222-4222-8222-000000000002", interactions[1].Request.URL)
+	assert.Equal(t, `{"servers":[{"private_ip":"10.64.0.1","public_ip":{"address":"198.51.100.1"}}]}`, interactions[1].Response.Body)
+
+	file := struct {
+		Content []byte `json:"content"`
+	}{}
+	require.NoError(t, json.Unmarshal([]byte(interactions[2].Response.Body), &file))
+	assert.NotContains(t, string(file.Content), "my-secret-token")
+	assert.NotContains(t, string(file.Content), "c2VjcmV0")
+	assert.Contains(t, string(file.Content), "server: https://cluster.api.k8s.fr-par.scw.cloud:6443")
+}
+
+func TestCassetteScrubberMatcher(t *testing.T) {
+	recorded := cassette.Request{
+		URL:    "https://api.scaleway.com/vpc/v1/zones/fr-par-1/private-networks?project_id=11111111-1111-4111-8111-000000000001",
+		Method: http.MethodPost,
+		Body:   `{"name":"tf-pn-sharp-bhabha","project_id":"11111111-1111-4111-8111-000000000001","tags":null}`,
+	}
+
+	newRequest := func(rawURL string, body string) *http.Request {
+		r, err := http.NewRequest(http.MethodPost, rawURL, strings.NewReader(body))
+		require.NoError(t, err)
+		return r
+	}
+
+	testCases := []struct {
+		name    string
+		url     string
+		body    string
+		matches bool
+	}{
+		{
+			name:    "same request",
+			url:     recorded.URL,
+			body:    recorded.Body,
+			matches: true,
+		},
+		{
+			name:    "known values, generated name, key order and null values",
+			url:     "https://api.scaleway.com/vpc/v1/zones/fr-par-1/private-networks?project_id=951df375-e094-4d26-97c1-ba548eeb9c42",
+			body:    `{"project_id":"951df375-e094-4d26-97c1-ba548eeb9c42","name":"tf-pn-quirky-turing"}`,
+			matches: true,
+		},
+		{
+			name:    "different payload",
+			url:     recorded.URL,
+			body:    `{"name":"tf-pn-sharp-bhabha","project_id":"11111111-1111-4111-8111-000000000001","tags":["foo"]}`,
+			matches: false,
+		},
+		{
+			name:    "different path",
+			url:     "https://api.scaleway.com/vpc/v1/zones/nl-ams-1/private-networks?project_id=11111111-1111-4111-8111-000000000001",
+			body:    recorded.Body,
+			matches: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := newRequest(tc.url, tc.body)
+			assert.Equal(t, tc.matches, testCassetteScrubber().matcher(r, recorded))
+
+			// The body must still be readable once matched.
+			body, err := ioutil.ReadAll(r.Body)
+			require.NoError(t, err)
+			assert.Equal(t, tc.body, string(body))
+		})
+	}
+}
+
+func TestCassetteScrubberRestore(t *testing.T) {
+	s := testCassetteScrubber()
+	assert.Equal(t, `{"project_id":"951df375-e094-4d26-97c1-ba548eeb9c42","organization_id":"22222222-2222-4222-8222-000000000002"}`,
+		s.restore(`{"project_id":"11111111-1111-4111-8111-000000000001","organization_id":"22222222-2222-4222-8222-000000000002"}`))
+
+	// Placeholders are kept when the test configuration does not define the value.
+	s = newCassetteScrubber(defaultCassetteScrubberConfig(), &scw.Profile{})
+	assert.Equal(t, `{"project_id":"11111111-1111-4111-8111-000000000001"}`, s.restore(`{"project_id":"11111111-1111-4111-8111-000000000001"}`))
+}
+
+// TestCassetteScrubberFixture scrubs a cassette recorded with real values and compares it with the expected one.
+// The requests sent with the real values must match the scrubbed interactions.
+func TestCassetteScrubberFixture(t *testing.T) {
+	input, err := cassette.Load(filepath.Join("testdata", "cassette-scrubber", "input.cassette"))
+	require.NoError(t, err)
+	expected, err := cassette.Load(filepath.Join("testdata", "cassette-scrubber", "expected.cassette"))
+	require.NoError(t, err)
+	require.Len(t, input.Interactions, len(expected.Interactions))
+
+	s := testCassetteScrubber()
+	for n, i := range input.Interactions {
+		r, err := http.NewRequest(i.Request.Method, i.Request.URL, strings.NewReader(i.Request.Body))
+		require.NoError(t, err)
+
+		require.NoError(t, s.scrubInteraction(i))
+		assert.Equal(t, expected.Interactions[n].Request.URL, i.Request.URL, "interaction %d", n)
+		assert.Equal(t, expected.Interactions[n].Request.Body, i.Request.Body, "interaction %d", n)
+		assert.Equal(t, expected.Interactions[n].Response.Body, i.Response.Body, "interaction %d", n)
+		assert.Empty(t, i.Request.Headers.Get("X-Auth-Token"), "interaction %d", n)
+
+		assert.True(t, s.matcher(r, expected.Interactions[n].Request), "interaction %d: %s %s is not matched", n, r.Method, r.URL)
+	}
+}
+
+// TestRecordedCassettesAreScrubbed makes sure the recorded cassettes do not contain sensitive values: scrubbing them
+// again must not change them. Run it with -scrub-cassettes to scrub the cassettes after a change of the scrubber.
+func TestRecordedCassettesAreScrubbed(t *testing.T) {
+	cassettes, err := filepath.Glob(filepath.Join("testdata", "*.cassette.yaml"))
+	require.NoError(t, err)
+
+	for _, file := range cassettes {
+		c, err := cassette.Load(strings.TrimSuffix(file, ".yaml"))
+		require.NoError(t, err)
+
+		s := testCassetteScrubber()
+		for n, i := range c.Interactions {
+			recorded := *i
+			require.NoError(t, s.scrubInteraction(i))
+			if *ScrubCassettes {
+				continue
+			}
+			assert.Equal(t, recorded.Request.URL, i.Request.URL, "%s: interaction %d", file, n)
+			assert.Equal(t, recorded.Request.Body, i.Request.Body, "%s: interaction %d", file, n)
+			assert.Equal(t, recorded.Response.Body, i.Response.Body, "%s: interaction %d", file, n)
+		}
+
+		if *ScrubCassettes {
+			require.NoError(t, c.Save())
+		}
+	}
+}
@@ -0,0 +1,87 @@
+package scaleway
+
+import (
+	"sync"
+	"time"
+)
+
+const (
+	defaultCatalogCacheTTL = 5 * time.Minute
+)
+
+// catalogCache caches catalog-style API responses such as server types, marketplace images or k8s versions.
+// Those responses are the same for every resource of a plan so they are fetched once per key and TTL.
+//
+// It is safe for concurrent use: concurrent lookups of a key that is being fetched wait for that fetch
+// instead of issuing their own call. Errors are never cached.
+// Cached values are shared between callers and must not be modified.
+type catalogCache struct {
+	ttl time.Duration
+	now func() time.Time
+
+	mu      sync.Mutex
+	entries map[string]*catalogCacheEntry
+	hits    uint64
+	misses  uint64
+}
+
+type catalogCacheEntry struct {
+	// done is closed once value and err are set.
+	done      chan struct{}
+	value     interface{}
+	err       error
+	expiresAt time.Time
+}
+
+func newCatalogCache(ttl time.Duration) *catalogCache {
+	return &catalogCache{
+		ttl:     ttl,
+		now:     time.Now,
+		entries: map[string]*catalogCacheEntry{},
+	}
+}
+
+// get returns the value cached for key, calling fetch when it is missing or expired.
+// A nil cache always calls fetch.
+func (c *catalogCache) get(key string, fetch func() (interface{}, error)) (interface{}, error) {
+	if c == nil {
+		return fetch()
+	}
+
+	c.mu.Lock()
+	entry, exists := c.entries[key]
+	if exists && !c.isStale(entry) {
+		c.hits++
+		l.Debugf("catalog cache hit for %s (hits: %d, misses: %d)", key, c.hits, c.misses)
+		c.mu.Unlock()
+
+		<-entry.done
+		return entry.value, entry.err
+	}
+
+	entry = &catalogCacheEntry{done: make(chan struct{})}
+	c.entries[key] = entry
+	c.misses++
+	l.Debugf("catalog cache miss for %s (hits: %d, misses: %d)", key, c.hits, c.misses)
+	c.mu.Unlock()
+
+	value, err := fetch()
+
+	c.mu.Lock()
+	entry.value, entry.err, entry.expiresAt = value, err, c.now().Add(c.ttl)
+	close(entry.done)
+	c.mu.Unlock()
+
+	return value, err
+}
+
+// isStale returns whether a fetched entry failed or expired. An entry being fetched is never stale.
+// It must be called with c.mu held.
+func (c *catalogCache) isStale(entry *catalogCacheEntry) bool {
+	select {
+	case <-entry.done:
+		return entry.err != nil || !c.now().Before(entry.expiresAt)
+	default:
+		return false
+	}
+}
@@ -0,0 +1,110 @@
+package scaleway
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCatalogCacheGet(t *testing.T) {
+	now := time.Date(2021, 4, 1, 0, 0, 0, 0, time.UTC)
+	cache := newCatalogCache(time.Minute)
+	cache.now = func() time.Time { return now }
+
+	calls := 0
+	fetch := func() (interface{}, error) {
+		calls++
+		return fmt.Sprintf("value-%d", calls), nil
+	}
+
+	value, err := cache.get("key", fetch)
+	require.NoError(t, err)
+	assert.Equal(t, "value-1", value)
+
+	value, err = cache.get("key", fetch)
+	require.NoError(t, err)
+	assert.Equal(t, "value-1", value)
+
+	value, err = cache.get("other-key", fetch)
+	require.NoError(t, err)
+	assert.Equal(t, "value-2", value)
+	assert.Equal(t, uint64(1), cache.hits)
+	assert.Equal(t, uint64(2), cache.misses)
+
+	// Entries are fetched again once expired.
+	now = now.Add(time.Minute)
+	value, err = cache.get("key", fetch)
+	require.NoError(t, err)
+	assert.Equal(t, "value-3", value)
+}
+
+func TestCatalogCacheGetError(t *testing.T) {
+	cache := newCatalogCache(time.Minute)
+
+	_, err := cache.get("key", func() (interface{}, error) {
+		return nil, fmt.Errorf("unavailable")
+	})
+	require.EqualError(t, err, "unavailable")
+
+	// Errors are not cached.
+	value, err := cache.get("key", func() (interface{}, error) {
+		return "value", nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "value", value)
+}
+
+func TestCatalogCacheGetConcurrent(t *testing.T) {
+	cache := newCatalogCache(time.Minute)
+
+	calls := int32(0)
+	release := make(chan struct{})
+	fetch := func() (interface{}, error) {
+		atomic.AddInt32(&calls, 1)
+		<-release
+		return "value", nil
+	}
+
+	wg := sync.WaitGroup{}
+	values := make([]interface{}, 50)
+	for i := range values {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			values[i], _ = cache.get("key", fetch)
+		}(i)
+	}
+
+	// Let every goroutine reach the cache before the first fetch returns.
+	for {
+		cache.mu.Lock()
+		lookups := cache.hits + cache.misses
+		cache.mu.Unlock()
+		if lookups == uint64(len(values)) {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+	close(release)
+	wg.Wait()
+
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+	for _, value := range values {
+		assert.Equal(t, "value", value)
+	}
+}
+
+func TestCatalogCacheGetNil(t *testing.T) {
+	cache := (*catalogCache)(nil)
+
+	value, err := cache.get("key", func() (interface{}, error) {
+		return "value", nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "value", value)
+}
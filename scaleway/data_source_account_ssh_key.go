@@ -36,7 +36,7 @@ func dataSourceScalewayAccountSSHKeyRead(ctx context.Context, d *schema.Resource
 	if ok {
 		res, err := accountAPI.GetSSHKey(&account.GetSSHKeyRequest{SSHKeyID: expandID(sshKeyID)}, scw.WithContext(ctx))
 		if err != nil {
-			return diag.FromErr(err)
+			return diagFromErr(err)
 		}
 		sshKey = res
 	} else {
@@ -45,13 +45,13 @@ func dataSourceScalewayAccountSSHKeyRead(ctx context.Context, d *schema.Resource
 			ProjectID: expandStringPtr(d.Get("project_id")),
 		}, scw.WithContext(ctx))
 		if err != nil {
-			return diag.FromErr(err)
+			return diagFromErr(err)
 		}
 		if len(res.SSHKeys) == 0 {
-			return diag.FromErr(fmt.Errorf("no SSH Key found with the name %s", d.Get("name")))
+			return diagFromErr(fmt.Errorf("no SSH Key found with the name %s", d.Get("name")))
 		}
 		if len(res.SSHKeys) > 1 {
-			return diag.FromErr(fmt.Errorf("%d SSH Keys found with the same name %s", len(res.SSHKeys), d.Get("name")))
+			return diagFromErr(fmt.Errorf("%d SSH Keys found with the same name %s", len(res.SSHKeys), d.Get("name")))
 		}
 		sshKey = res.SSHKeys[0]
 	}
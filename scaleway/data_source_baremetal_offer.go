@@ -7,7 +7,6 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/scaleway/scaleway-sdk-go/api/baremetal/v1"
-	"github.com/scaleway/scaleway-sdk-go/scw"
 )
 
 func dataSourceScalewayBaremetalOffer() *schema.Resource {
@@ -132,33 +131,31 @@ func dataSourceScalewayBaremetalOffer() *schema.Resource {
 }
 
 func dataSourceScalewayBaremetalOfferRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	baremetalAPI, fallBackZone, err := baremetalAPIWithZone(d, meta)
+	fallBackZone, err := extractZone(d, meta.(*Meta))
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	zone, offerID, _ := parseZonedID(datasourceNewZonedID(d.Get("offer_id"), fallBackZone))
-	res, err := baremetalAPI.ListOffers(&baremetal.ListOffersRequest{
-		Zone: zone,
-	}, scw.WithAllPages(), scw.WithContext(ctx))
+	offers, err := baremetalOffers(ctx, meta, zone)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	matches := []*baremetal.Offer(nil)
-	for _, offer := range res.Offers {
+	for _, offer := range offers {
 		if offer.Name == d.Get("name") || offer.ID == offerID {
 			if !offer.Enable && !d.Get("include_disabled").(bool) {
-				return diag.FromErr(fmt.Errorf("offer %s (%s) found in zone %s but is disabled. Add allow_disabled=true in your terraform config to use it", offer.Name, offer.ID, zone))
+				return diagFromErr(fmt.Errorf("offer %s (%s) found in zone %s but is disabled. Add allow_disabled=true in your terraform config to use it", offer.Name, offer.ID, zone))
 			}
 			matches = append(matches, offer)
 		}
 	}
 	if len(matches) == 0 {
-		return diag.FromErr(fmt.Errorf("no offer found with the name %s in zone %s", d.Get("name"), zone))
+		return diagFromErr(fmt.Errorf("no offer found with the name %s in zone %s", d.Get("name"), zone))
 	}
 	if len(matches) > 1 {
-		return diag.FromErr(fmt.Errorf("%d offers found with the same name %s in zone %s", len(matches), d.Get("name"), zone))
+		return diagFromErr(fmt.Errorf("%d offers found with the same name %s in zone %s", len(matches), d.Get("name"), zone))
 	}
 
 	offer := matches[0]
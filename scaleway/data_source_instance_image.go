@@ -96,7 +96,7 @@ func dataSourceScalewayInstanceImage() *schema.Resource {
 func dataSourceScalewayInstanceImageRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	instanceAPI, zone, err := instanceAPIWithZone(d, meta)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	imageID, ok := d.GetOk("image_id")
@@ -108,7 +108,7 @@ func dataSourceScalewayInstanceImageRead(ctx context.Context, d *schema.Resource
 			Project: expandStringPtr(d.Get("project_id")),
 		}, scw.WithAllPages(), scw.WithContext(ctx))
 		if err != nil {
-			return diag.FromErr(err)
+			return diagFromErr(err)
 		}
 		var matchingImages []*instance.Image
 		for _, image := range res.Images {
@@ -118,10 +118,10 @@ func dataSourceScalewayInstanceImageRead(ctx context.Context, d *schema.Resource
 		}
 
 		if len(matchingImages) == 0 {
-			return diag.FromErr(fmt.Errorf("no image found with the name %s and architecture %s in zone %s", d.Get("name"), d.Get("architecture"), zone))
+			return diagFromErr(fmt.Errorf("no image found with the name %s and architecture %s in zone %s", d.Get("name"), d.Get("architecture"), zone))
 		}
 		if len(matchingImages) > 1 && !d.Get("latest").(bool) {
-			return diag.FromErr(fmt.Errorf("%d images found with the same name %s and architecture %s in zone %s", len(matchingImages), d.Get("name"), d.Get("architecture"), zone))
+			return diagFromErr(fmt.Errorf("%d images found with the same name %s and architecture %s in zone %s", len(matchingImages), d.Get("name"), d.Get("architecture"), zone))
 		}
 
 		sort.Slice(matchingImages, func(i, j int) bool {
@@ -148,7 +148,7 @@ func dataSourceScalewayInstanceImageRead(ctx context.Context, d *schema.Resource
 		ImageID: imageID.(string),
 	}, scw.WithContext(ctx))
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	_ = d.Set("organization_id", resp.Image.Organization)
@@ -36,7 +36,7 @@ func dataSourceScalewayInstanceServer() *schema.Resource {
 func dataSourceScalewayInstanceServerRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	instanceAPI, zone, err := instanceAPIWithZone(d, meta)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	serverID, ok := d.GetOk("server_id")
@@ -47,18 +47,18 @@ func dataSourceScalewayInstanceServerRead(ctx context.Context, d *schema.Resourc
 			Project: expandStringPtr(d.Get("project_id")),
 		}, scw.WithContext(ctx))
 		if err != nil {
-			return diag.FromErr(err)
+			return diagFromErr(err)
 		}
 		for _, server := range res.Servers {
 			if server.Name == d.Get("name").(string) {
 				if serverID != "" {
-					return diag.FromErr(fmt.Errorf("more than 1 server found with the same name %s", d.Get("name")))
+					return diagFromErr(fmt.Errorf("more than 1 server found with the same name %s", d.Get("name")))
 				}
 				serverID = server.ID
 			}
 		}
 		if serverID == "" {
-			return diag.FromErr(fmt.Errorf("no server found with the name %s", d.Get("name")))
+			return diagFromErr(fmt.Errorf("no server found with the name %s", d.Get("name")))
 		}
 	}
 
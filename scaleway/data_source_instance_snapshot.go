@@ -0,0 +1,71 @@
+package scaleway
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/scaleway/scaleway-sdk-go/api/instance/v1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+)
+
+func dataSourceScalewayInstanceSnapshot() *schema.Resource {
+	// Generate datasource schema from resource
+	dsSchema := datasourceSchemaFromResourceSchema(resourceScalewayInstanceSnapshot().Schema)
+
+	// Set 'Optional' schema elements
+	addOptionalFieldsToSchema(dsSchema, "name", "zone")
+
+	dsSchema["snapshot_id"] = &schema.Schema{
+		Type:          schema.TypeString,
+		Optional:      true,
+		Description:   "The ID of the snapshot",
+		ConflictsWith: []string{"name"},
+		ValidateFunc:  validationUUIDorUUIDWithLocality(),
+	}
+	dsSchema["name"].ConflictsWith = []string{"snapshot_id"}
+
+	return &schema.Resource{
+		ReadContext: dataSourceScalewayInstanceSnapshotRead,
+		Schema:      dsSchema,
+	}
+}
+
+func dataSourceScalewayInstanceSnapshotRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	instanceAPI, zone, err := instanceAPIWithZone(d, meta)
+	if err != nil {
+		return diagFromErr(err)
+	}
+
+	snapshotID, ok := d.GetOk("snapshot_id")
+	if !ok { // Get snapshots by zone and name.
+		res, err := instanceAPI.ListSnapshots(&instance.ListSnapshotsRequest{
+			Zone:    zone,
+			Name:    expandStringPtr(d.Get("name")),
+			Project: expandStringPtr(d.Get("project_id")),
+		}, scw.WithAllPages(), scw.WithContext(ctx))
+		if err != nil {
+			return diagFromErr(err)
+		}
+		for _, snapshot := range res.Snapshots {
+			if snapshot.Name == d.Get("name").(string) {
+				if snapshotID != "" {
+					return diagFromErr(fmt.Errorf("more than 1 snapshot found with the same name %s", d.Get("name")))
+				}
+				snapshotID = snapshot.ID
+			}
+		}
+		if snapshotID == "" {
+			return diagFromErr(fmt.Errorf("no snapshot found with the name %s", d.Get("name")))
+		}
+	}
+
+	zonedID := datasourceNewZonedID(snapshotID, zone)
+	d.SetId(zonedID)
+	err = d.Set("snapshot_id", zonedID)
+	if err != nil {
+		return diagFromErr(err)
+	}
+	return resourceScalewayInstanceSnapshotRead(ctx, d, meta)
+}
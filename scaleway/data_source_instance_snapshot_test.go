@@ -0,0 +1,41 @@
+package scaleway
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScalewayDataSourceInstanceSnapshot_FakeAPI(t *testing.T) {
+	f := newFakeAPI(t)
+	defer f.close()
+	meta := f.meta()
+	ctx := context.Background()
+
+	snapshot := f.seed("instance", "fr-par-1", "snapshots", map[string]interface{}{
+		"name":        "pre-upgrade",
+		"volume_type": "b_ssd",
+		"size":        20000000000,
+	})
+	f.seed("instance", "fr-par-1", "snapshots", map[string]interface{}{"name": "pre-upgrade-2"})
+	f.seed("instance", "fr-par-1", "snapshots", map[string]interface{}{"name": "duplicated"})
+	f.seed("instance", "fr-par-1", "snapshots", map[string]interface{}{"name": "duplicated"})
+	zonedID := newZonedIDString("fr-par-1", snapshot["id"].(string))
+
+	d := f.resourceData(dataSourceScalewayInstanceSnapshot(), "", map[string]interface{}{"name": "pre-upgrade"})
+	require.False(t, dataSourceScalewayInstanceSnapshotRead(ctx, d, meta).HasError())
+	assert.Equal(t, zonedID, d.Id())
+	assert.Equal(t, zonedID, d.Get("snapshot_id"))
+	assert.Equal(t, 20, d.Get("size_in_gb"))
+
+	d = f.resourceData(dataSourceScalewayInstanceSnapshot(), "", map[string]interface{}{"snapshot_id": snapshot["id"]})
+	require.False(t, dataSourceScalewayInstanceSnapshotRead(ctx, d, meta).HasError())
+	assert.Equal(t, "pre-upgrade", d.Get("name"))
+
+	d = f.resourceData(dataSourceScalewayInstanceSnapshot(), "", map[string]interface{}{"name": "duplicated"})
+	diags := dataSourceScalewayInstanceSnapshotRead(ctx, d, meta)
+	require.True(t, diags.HasError())
+	assert.Contains(t, diags[0].Summary, "more than 1 snapshot found with the same name duplicated")
+}
@@ -35,7 +35,7 @@ func dataSourceScalewayInstanceVolume() *schema.Resource {
 func dataSourceScalewayInstanceVolumeRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	instanceAPI, zone, err := instanceAPIWithZone(d, meta)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	volumeID, ok := d.GetOk("volume_id")
@@ -46,18 +46,18 @@ func dataSourceScalewayInstanceVolumeRead(ctx context.Context, d *schema.Resourc
 			Project: expandStringPtr(d.Get("project_id")),
 		}, scw.WithContext(ctx))
 		if err != nil {
-			return diag.FromErr(err)
+			return diagFromErr(err)
 		}
 		for _, volume := range res.Volumes {
 			if volume.Name == d.Get("name").(string) {
 				if volumeID != "" {
-					return diag.FromErr(fmt.Errorf("more than 1 volume found with the same name %s", d.Get("name")))
+					return diagFromErr(fmt.Errorf("more than 1 volume found with the same name %s", d.Get("name")))
 				}
 				volumeID = volume.ID
 			}
 		}
 		if volumeID == "" {
-			return diag.FromErr(fmt.Errorf("no volume found with the name %s", d.Get("name")))
+			return diagFromErr(fmt.Errorf("no volume found with the name %s", d.Get("name")))
 		}
 	}
 
@@ -65,7 +65,7 @@ func dataSourceScalewayInstanceVolumeRead(ctx context.Context, d *schema.Resourc
 	d.SetId(zonedID)
 	err = d.Set("volume_id", zonedID)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 	return resourceScalewayInstanceVolumeRead(ctx, d, meta)
 }
@@ -37,7 +37,7 @@ func dataSourceScalewayK8SCluster() *schema.Resource {
 func dataSourceScalewayK8SClusterRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	k8sAPI, region, err := k8sAPIWithRegion(d, meta)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	clusterID, ok := d.GetOk("cluster_id")
@@ -48,18 +48,18 @@ func dataSourceScalewayK8SClusterRead(ctx context.Context, d *schema.ResourceDat
 			ProjectID: expandStringPtr(d.Get("project_id")),
 		}, scw.WithContext(ctx))
 		if err != nil {
-			return diag.FromErr(err)
+			return diagFromErr(err)
 		}
 		for _, cluster := range res.Clusters {
 			if cluster.Name == d.Get("name").(string) {
 				if clusterID != "" {
-					return diag.FromErr(fmt.Errorf("more than 1 cluster found with the same name %s", d.Get("name")))
+					return diagFromErr(fmt.Errorf("more than 1 cluster found with the same name %s", d.Get("name")))
 				}
 				clusterID = cluster.ID
 			}
 		}
 		if clusterID == "" {
-			return diag.FromErr(fmt.Errorf("no cluster found with the name %s", d.Get("name")))
+			return diagFromErr(fmt.Errorf("no cluster found with the name %s", d.Get("name")))
 		}
 	}
 
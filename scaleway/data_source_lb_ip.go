@@ -37,7 +37,7 @@ func dataSourceScalewayLbIP() *schema.Resource {
 func dataSourceScalewayLbIPRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	api, region, err := lbAPIWithRegion(d, meta)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	ipID, ok := d.GetOk("ip_id")
@@ -48,13 +48,13 @@ func dataSourceScalewayLbIPRead(ctx context.Context, d *schema.ResourceData, met
 			ProjectID: expandStringPtr(d.Get("project_id")),
 		}, scw.WithContext(ctx))
 		if err != nil {
-			return diag.FromErr(err)
+			return diagFromErr(err)
 		}
 		if len(res.IPs) == 0 {
-			return diag.FromErr(fmt.Errorf("no ips found with the address %s", d.Get("ip_address")))
+			return diagFromErr(fmt.Errorf("no ips found with the address %s", d.Get("ip_address")))
 		}
 		if len(res.IPs) > 1 {
-			return diag.FromErr(fmt.Errorf("%d ips found with the same address %s", len(res.IPs), d.Get("ip_address")))
+			return diagFromErr(fmt.Errorf("%d ips found with the same address %s", len(res.IPs), d.Get("ip_address")))
 		}
 		ipID = res.IPs[0].ID
 	}
@@ -63,7 +63,7 @@ func dataSourceScalewayLbIPRead(ctx context.Context, d *schema.ResourceData, met
 	d.SetId(regionalID)
 	err = d.Set("ip_id", regionalID)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 	return resourceScalewayLbIPRead(ctx, d, meta)
 }
@@ -5,8 +5,6 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	"github.com/scaleway/scaleway-sdk-go/api/marketplace/v1"
-	"github.com/scaleway/scaleway-sdk-go/scw"
 )
 
 func dataSourceScalewayMarketplaceImage() *schema.Resource {
@@ -30,18 +28,14 @@ func dataSourceScalewayMarketplaceImage() *schema.Resource {
 }
 
 func dataSourceScalewayMarketplaceImageRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	marketplaceAPI, zone, err := marketplaceAPIWithZone(d, meta)
+	zone, err := extractZone(d, meta.(*Meta))
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
-	imageID, err := marketplaceAPI.GetLocalImageIDByLabel(&marketplace.GetLocalImageIDByLabelRequest{
-		ImageLabel:     d.Get("label").(string),
-		CommercialType: d.Get("instance_type").(string),
-		Zone:           zone,
-	}, scw.WithContext(ctx))
+	imageID, err := marketplaceLocalImageIDByLabel(ctx, meta, zone, d.Get("instance_type").(string), d.Get("label").(string))
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	zonedID := datasourceNewZonedID(imageID, zone)
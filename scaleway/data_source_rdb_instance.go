@@ -34,7 +34,7 @@ func dataSourceScalewayRDBInstance() *schema.Resource {
 func dataSourceScalewayRDBInstanceRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	api, region, err := rdbAPIWithRegion(d, meta)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	instanceID, ok := d.GetOk("instance_id")
@@ -44,13 +44,13 @@ func dataSourceScalewayRDBInstanceRead(ctx context.Context, d *schema.ResourceDa
 			Name:   scw.StringPtr(d.Get("name").(string)),
 		}, scw.WithContext(ctx))
 		if err != nil {
-			return diag.FromErr(err)
+			return diagFromErr(err)
 		}
 		if len(res.Instances) == 0 {
-			return diag.FromErr(fmt.Errorf("no instances found with the name %s", d.Get("name")))
+			return diagFromErr(fmt.Errorf("no instances found with the name %s", d.Get("name")))
 		}
 		if len(res.Instances) > 1 {
-			return diag.FromErr(fmt.Errorf("%d instances found with the same name %s", len(res.Instances), d.Get("name")))
+			return diagFromErr(fmt.Errorf("%d instances found with the same name %s", len(res.Instances), d.Get("name")))
 		}
 		instanceID = res.Instances[0].ID
 	}
@@ -59,7 +59,7 @@ func dataSourceScalewayRDBInstanceRead(ctx context.Context, d *schema.ResourceDa
 	d.SetId(regionalID)
 	err = d.Set("instance_id", regionalID)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 	return resourceScalewayRdbInstanceRead(ctx, d, meta)
 }
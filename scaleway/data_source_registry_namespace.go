@@ -35,7 +35,7 @@ func dataSourceScalewayRegistryNamespace() *schema.Resource {
 func dataSourceScalewayRegistryNamespaceRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	api, region, err := registryAPIWithRegion(d, meta)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	namespaceID, ok := d.GetOk("namespace_id")
@@ -45,13 +45,13 @@ func dataSourceScalewayRegistryNamespaceRead(ctx context.Context, d *schema.Reso
 			Name:   expandStringPtr(d.Get("name")),
 		}, scw.WithContext(ctx))
 		if err != nil {
-			return diag.FromErr(err)
+			return diagFromErr(err)
 		}
 		if len(res.Namespaces) == 0 {
-			return diag.FromErr(fmt.Errorf("no namespaces found with the name %s", d.Get("name")))
+			return diagFromErr(fmt.Errorf("no namespaces found with the name %s", d.Get("name")))
 		}
 		if len(res.Namespaces) > 1 {
-			return diag.FromErr(fmt.Errorf("%d namespaces found with the same name %s", len(res.Namespaces), d.Get("name")))
+			return diagFromErr(fmt.Errorf("%d namespaces found with the same name %s", len(res.Namespaces), d.Get("name")))
 		}
 		namespaceID = res.Namespaces[0].ID
 	}
@@ -35,7 +35,7 @@ func dataSourceScalewayVPCPrivateNetwork() *schema.Resource {
 func dataSourceScalewayVPCPrivateNetworkRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	vpcAPI, zone, err := vpcAPIWithZone(d, meta)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	privateNetworkID, ok := d.GetOk("private_network_id")
@@ -46,10 +46,10 @@ func dataSourceScalewayVPCPrivateNetworkRead(ctx context.Context, d *schema.Reso
 				Zone: zone,
 			}, scw.WithContext(ctx))
 		if err != nil {
-			return diag.FromErr(err)
+			return diagFromErr(err)
 		}
 		if res.TotalCount == 0 {
-			return diag.FromErr(
+			return diagFromErr(
 				fmt.Errorf(
 					"no private network found with the name %s",
 					d.Get("name"),
@@ -57,7 +57,7 @@ func dataSourceScalewayVPCPrivateNetworkRead(ctx context.Context, d *schema.Reso
 			)
 		}
 		if res.TotalCount > 1 {
-			return diag.FromErr(
+			return diagFromErr(
 				fmt.Errorf(
 					"%d private networks found with the name %s",
 					res.TotalCount,
@@ -0,0 +1,193 @@
+package scaleway
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// endpointProducts are the products whose API endpoint can be overridden, s3 aside.
+var endpointProducts = []string{
+	"instance",
+	"iot",
+	"k8s",
+	"lb",
+	"rdb",
+	"registry",
+	"vpc",
+}
+
+// endpointsConfig holds the endpoint overrides of the endpoints block of the provider.
+// A nil endpointsConfig uses the default Scaleway endpoints.
+type endpointsConfig struct {
+	// products maps a product to the URL its API requests are sent to instead of api_url.
+	products map[string]*url.URL
+	// s3 is the object storage endpoint, {region} is replaced by the region of the bucket.
+	s3 string
+	// s3ForcePathStyle sends object storage requests to <endpoint>/<bucket> instead of <bucket>.<endpoint>.
+	s3ForcePathStyle bool
+	// caBundle is the path of a PEM file of certificate authorities trusted in addition to the system ones.
+	caBundle string
+}
+
+// s3Endpoint returns the object storage endpoint of a region.
+func (c *endpointsConfig) s3Endpoint(region string) string {
+	if c == nil || c.s3 == "" {
+		return "https://s3." + region + ".scw.cloud"
+	}
+	return strings.ReplaceAll(c.s3, "{region}", region)
+}
+
+// forcePathStyle returns whether object storage requests use path-style addressing.
+func (c *endpointsConfig) forcePathStyle() bool {
+	return c != nil && c.s3ForcePathStyle
+}
+
+// loadEndpoints returns the endpoint overrides defined in the endpoints block of the provider.
+func loadEndpoints(d *schema.ResourceData) (*endpointsConfig, error) {
+	if d == nil {
+		return nil, nil
+	}
+	if _, exist := d.GetOk("endpoints"); !exist {
+		return nil, nil
+	}
+
+	config := &endpointsConfig{
+		products:         map[string]*url.URL{},
+		s3:               d.Get("endpoints.0.s3").(string),
+		s3ForcePathStyle: d.Get("endpoints.0.s3_force_path_style").(bool),
+		caBundle:         d.Get("endpoints.0.ca_bundle").(string),
+	}
+	for _, product := range endpointProducts {
+		rawURL := d.Get("endpoints.0." + product).(string)
+		if rawURL == "" {
+			continue
+		}
+		endpoint, err := url.Parse(rawURL)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s endpoint %q: %s", product, rawURL, err)
+		}
+		config.products[product] = endpoint
+	}
+
+	return config, nil
+}
+
+// newCABundleTransport returns a copy of the default transport also trusting the certificate authorities
+// of the PEM file at caBundle.
+func newCABundleTransport(caBundle string) (http.RoundTripper, error) {
+	pem, err := ioutil.ReadFile(caBundle)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read ca_bundle: %s", err)
+	}
+
+	pool, err := x509.SystemCertPool()
+	if err != nil || pool == nil {
+		pool = x509.NewCertPool()
+	}
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, fmt.Errorf("ca_bundle %s does not contain any PEM certificate", caBundle)
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = &tls.Config{RootCAs: pool}
+	return transport, nil
+}
+
+// endpointTransport is a http transport sending the API requests of a product to its overridden endpoint.
+type endpointTransport struct {
+	transport http.RoundTripper
+	endpoints map[string]*url.URL
+}
+
+// newEndpointTransport creates a http transport sending the requests of the given products to their endpoint.
+func newEndpointTransport(transport http.RoundTripper, endpoints map[string]*url.URL) http.RoundTripper {
+	return &endpointTransport{transport: transport, endpoints: endpoints}
+}
+
+// RoundTrip rewrites the scheme, host and base path of the request when its product has an endpoint.
+// The product is kept in the request context as it cannot be read from the rewritten URL anymore.
+func (t *endpointTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	product := requestProduct(r)
+	endpoint, exist := t.endpoints[product]
+	if !exist {
+		return t.transport.RoundTrip(r)
+	}
+
+	rewritten := r.Clone(withRequestProduct(r.Context(), product))
+	rewritten.URL.Scheme = endpoint.Scheme
+	rewritten.URL.Host = endpoint.Host
+	rewritten.URL.Path = strings.TrimSuffix(endpoint.Path, "/") + r.URL.Path
+	rewritten.URL.RawPath = ""
+	rewritten.Host = ""
+	return t.transport.RoundTrip(rewritten)
+}
+
+type requestProductKey struct{}
+
+// withRequestProduct returns a context marking the requests made with it as targeting product.
+func withRequestProduct(ctx context.Context, product string) context.Context {
+	return context.WithValue(ctx, requestProductKey{}, product)
+}
+
+// productTransport is a http transport marking every request as targeting a product,
+// for clients whose requests cannot be recognized from their URL such as object storage on a custom endpoint.
+type productTransport struct {
+	transport http.RoundTripper
+	product   string
+}
+
+func (t *productTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	return t.transport.RoundTrip(r.WithContext(withRequestProduct(r.Context(), t.product)))
+}
+
+// validateS3Endpoint validates an object storage endpoint, which may contain a {region} placeholder.
+func validateS3Endpoint() schema.SchemaValidateFunc {
+	return func(i interface{}, key string) ([]string, []error) {
+		endpoint, isString := i.(string)
+		if !isString {
+			return nil, []error{fmt.Errorf("expected type of %s to be string", key)}
+		}
+		return validation.IsURLWithHTTPorHTTPS(strings.ReplaceAll(endpoint, "{region}", "fr-par"), key)
+	}
+}
+
+// endpointsSchema returns the schema of the endpoints block of the provider.
+func endpointsSchema() map[string]*schema.Schema {
+	endpoints := map[string]*schema.Schema{
+		"s3": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			Description:  "The object storage endpoint, {region} is replaced by the region of the bucket. Defaults to https://s3.{region}.scw.cloud.",
+			ValidateFunc: validateS3Endpoint(),
+		},
+		"s3_force_path_style": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+			Description: "Address buckets as <endpoint>/<bucket> instead of <bucket>.<endpoint>.",
+		},
+		"ca_bundle": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "The path of a PEM file of certificate authorities trusted in addition to the system ones.",
+		},
+	}
+	for _, product := range endpointProducts {
+		endpoints[product] = &schema.Schema{
+			Type:         schema.TypeString,
+			Optional:     true,
+			Description:  fmt.Sprintf("The endpoint of the %s API, used instead of api_url.", product),
+			ValidateFunc: validation.IsURLWithHTTPorHTTPS,
+		}
+	}
+	return endpoints
+}
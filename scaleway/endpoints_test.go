@@ -0,0 +1,115 @@
+package scaleway
+
+import (
+	"encoding/pem"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEndpointTransport(t *testing.T) {
+	requests := []string(nil)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+	}))
+	defer server.Close()
+
+	proxy, err := url.Parse(server.URL + "/proxy/")
+	require.NoError(t, err)
+	products := []string(nil)
+	client := &http.Client{Transport: newEndpointTransport(roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+		products = append(products, requestProduct(r))
+		return http.DefaultTransport.RoundTrip(r)
+	}), map[string]*url.URL{"instance": proxy})}
+
+	resp, err := client.Get("https://api.scaleway.invalid/instance/v1/zones/fr-par-1/servers")
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+
+	resp, err = client.Get(server.URL + "/vpc/v1/zones/fr-par-1/private-networks")
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+
+	assert.Equal(t, []string{
+		"GET /proxy/instance/v1/zones/fr-par-1/servers",
+		"GET /vpc/v1/zones/fr-par-1/private-networks",
+	}, requests)
+	assert.Equal(t, []string{"instance", "vpc"}, products)
+}
+
+func TestNewCABundleTransport(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	caBundle, err := ioutil.TempFile("", "ca-bundle-*.pem")
+	require.NoError(t, err)
+	defer os.Remove(caBundle.Name())
+	require.NoError(t, pem.Encode(caBundle, &pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}))
+	require.NoError(t, caBundle.Close())
+
+	_, err = http.DefaultClient.Get(server.URL)
+	require.Error(t, err)
+
+	transport, err := newCABundleTransport(caBundle.Name())
+	require.NoError(t, err)
+	resp, err := (&http.Client{Transport: transport}).Get(server.URL)
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+
+	_, err = newCABundleTransport(caBundle.Name() + ".missing")
+	assert.Error(t, err)
+}
+
+func TestNewS3ClientEndpoint(t *testing.T) {
+	defer unsetAWSCABundle(t)()
+
+	requests := []string(nil)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+	}))
+	defer server.Close()
+
+	products := []string(nil)
+	httpClient := &http.Client{Transport: roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+		products = append(products, requestProduct(r))
+		return http.DefaultTransport.RoundTrip(r)
+	})}
+	endpoints := &endpointsConfig{s3: server.URL, s3ForcePathStyle: true}
+	s3Client, err := newS3Client(httpClient, endpoints, "fr-par", "SCWXXXXXXXXXXXXXXXXX", "11111111-1111-1111-1111-111111111111")
+	require.NoError(t, err)
+
+	_, err = s3Client.HeadBucket(&s3.HeadBucketInput{Bucket: aws.String("my-bucket")})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"HEAD /my-bucket"}, requests)
+	assert.Equal(t, []string{"s3"}, products)
+
+	assert.Equal(t, "https://s3.nl-ams.scw.cloud", (*endpointsConfig)(nil).s3Endpoint("nl-ams"))
+	assert.Equal(t, "https://nl-ams.minio.internal", (&endpointsConfig{s3: "https://{region}.minio.internal"}).s3Endpoint("nl-ams"))
+}
+
+// roundTripperFunc is a http transport calling a function.
+type roundTripperFunc func(r *http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
+
+// unsetAWSCABundle unsets AWS_CA_BUNDLE, that the AWS SDK refuses to load into the custom transports of the provider.
+// It returns a function restoring it.
+func unsetAWSCABundle(t *testing.T) func() {
+	previous, exist := os.LookupEnv("AWS_CA_BUNDLE")
+	require.NoError(t, os.Unsetenv("AWS_CA_BUNDLE"))
+	return func() {
+		if exist {
+			_ = os.Setenv("AWS_CA_BUNDLE", previous)
+		}
+	}
+}
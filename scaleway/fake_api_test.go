@@ -0,0 +1,756 @@
+package scaleway
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	fakeAPIAccessKey      = "SCWXXXXXXXXXXXXXXXXX"
+	fakeAPISecretKey      = "11111111-1111-1111-1111-111111111111"
+	fakeAPIProjectID      = "22222222-2222-2222-2222-222222222222"
+	fakeAPIOrganizationID = "33333333-3333-3333-3333-333333333333"
+)
+
+// fakeResourceKind describes how a collection of the fake API behaves.
+type fakeResourceKind struct {
+	// wrapper is the key wrapping a single resource in responses. Only the instance API wraps its resources.
+	wrapper string
+	// listKey is the key of the resources in list responses.
+	listKey string
+	// parent is the collection in which the resources are created and listed, eg pools are created in clusters.
+	parent string
+	// parentField is the field referencing the parent resource.
+	parentField string
+	// statusField is the field holding the status of the resource.
+	statusField string
+	// createStatus is the status of a newly created resource.
+	createStatus string
+	// transitions are the status changes applied each time the resource is read, to simulate asynchronous work.
+	transitions map[string]string
+	// deleteStatus is the status of a resource being deleted. The resource disappears on the next read.
+	// If empty, resources are deleted immediately.
+	deleteStatus string
+	// defaults are the fields added to a newly created resource.
+	defaults map[string]interface{}
+	// onCreate is called once a resource is created, before it is stored.
+	onCreate func(f *fakeAPI, locality string, resource map[string]interface{})
+	// subResources handles the requests on a resource sub path, eg POST servers/{id}/action.
+	subResources map[string]func(f *fakeAPI, resource map[string]interface{}, body map[string]interface{}) (int, interface{})
+}
+
+// fakeAPIKinds are the collections supported by the fake API indexed by product and collection name.
+// It is initialized in init as the kinds hooks use the fake API which itself reads the kinds.
+var fakeAPIKinds map[string]*fakeResourceKind
+
+func init() {
+	fakeAPIKinds = map[string]*fakeResourceKind{
+		"instance/servers": {
+			wrapper:      "server",
+			listKey:      "servers",
+			statusField:  "state",
+			createStatus: "stopped",
+			transitions: map[string]string{
+				"starting": "running",
+				"stopping": "stopped",
+			},
+			defaults: map[string]interface{}{
+				"boot_type":           "local",
+				"arch":                "x86_64",
+				"bootscript":          map[string]interface{}{"id": ""},
+				"tags":                []interface{}{},
+				"volumes":             map[string]interface{}{},
+				"enable_ipv6":         false,
+				"dynamic_ip_required": false,
+			},
+			onCreate: func(f *fakeAPI, locality string, server map[string]interface{}) {
+				if server["security_group"] == nil {
+					server["security_group"] = map[string]interface{}{"id": f.newID(), "name": "default"}
+				}
+				if image, isString := server["image"].(string); isString {
+					server["image"] = map[string]interface{}{"id": image}
+				}
+			},
+			subResources: map[string]func(f *fakeAPI, server map[string]interface{}, body map[string]interface{}) (int, interface{}){
+				"POST action": func(f *fakeAPI, server map[string]interface{}, body map[string]interface{}) (int, interface{}) {
+					if server["state"] == "locked" {
+						return http.StatusBadRequest, fakeAPIError("invalid_request_error", "server is locked")
+					}
+					switch body["action"] {
+					case "poweron", "reboot":
+						server["state"] = "starting"
+					case "poweroff":
+						server["state"] = "stopping"
+					case "stop_in_place":
+						server["state"] = "stopped in place"
+					default:
+						return http.StatusBadRequest, fakeAPIError("invalid_arguments", fmt.Sprintf("unknown action %v", body["action"]))
+					}
+					return http.StatusAccepted, map[string]interface{}{
+						"task": map[string]interface{}{"id": f.newID(), "description": body["action"], "status": "pending"},
+					}
+				},
+				"GET user_data": func(f *fakeAPI, server map[string]interface{}, body map[string]interface{}) (int, interface{}) {
+					return http.StatusOK, map[string]interface{}{"user_data": []interface{}{}}
+				},
+			},
+		},
+		"instance/volumes": {
+			wrapper:      "volume",
+			listKey:      "volumes",
+			statusField:  "state",
+			createStatus: "available",
+		},
+		"instance/snapshots": {
+			wrapper:      "snapshot",
+			listKey:      "snapshots",
+			statusField:  "state",
+			createStatus: "snapshotting",
+			transitions: map[string]string{
+				"snapshotting": "available",
+			},
+			onCreate: func(f *fakeAPI, zone string, snapshot map[string]interface{}) {
+				if volume := f.get("instance", zone, "volumes", fmt.Sprint(snapshot["volume_id"])); volume != nil {
+					snapshot["volume_type"] = volume["volume_type"]
+					snapshot["size"] = volume["size"]
+					snapshot["base_volume"] = map[string]interface{}{"id": volume["id"], "name": volume["name"]}
+				}
+				delete(snapshot, "volume_id")
+			},
+		},
+		"instance/images": {
+			wrapper:      "image",
+			listKey:      "images",
+			statusField:  "state",
+			createStatus: "creating",
+			transitions: map[string]string{
+				"creating": "available",
+			},
+			onCreate: func(f *fakeAPI, zone string, image map[string]interface{}) {
+				if rootVolume, ok := image["root_volume"].(string); ok {
+					image["root_volume"] = map[string]interface{}{"id": rootVolume}
+				}
+				if extraVolumes, ok := image["extra_volumes"].(map[string]interface{}); ok {
+					for index, volume := range extraVolumes {
+						extraVolumes[index] = map[string]interface{}{"id": volume.(map[string]interface{})["id"]}
+					}
+				}
+			},
+		},
+		"instance/private_nics": {
+			wrapper:     "private_nic",
+			listKey:     "private_nics",
+			parent:      "servers",
+			parentField: "server_id",
+			onCreate: func(f *fakeAPI, zone string, privateNIC map[string]interface{}) {
+				privateNIC["mac_address"] = fmt.Sprintf("02:00:00:00:%02x:%02x", f.idCounter/256, f.idCounter%256)
+			},
+		},
+		"instance/ips": {
+			wrapper: "ip",
+			listKey: "ips",
+			defaults: map[string]interface{}{
+				"address": "51.15.0.1",
+				"tags":    []interface{}{},
+			},
+		},
+		"instance/security_groups": {
+			wrapper: "security_group",
+			listKey: "security_groups",
+		},
+		"instance/placement_groups": {
+			wrapper: "placement_group",
+			listKey: "placement_groups",
+		},
+		"lb/lbs": {
+			listKey:      "lbs",
+			statusField:  "status",
+			createStatus: "pending",
+			transitions: map[string]string{
+				"pending": "ready",
+			},
+			deleteStatus: "to_delete",
+			defaults: map[string]interface{}{
+				"tags": []interface{}{},
+			},
+			onCreate: func(f *fakeAPI, region string, lb map[string]interface{}) {
+				ip := f.get("lb", region, "ips", fmt.Sprint(lb["ip_id"]))
+				if ip == nil {
+					ip = f.create("lb", region, "ips", map[string]interface{}{})
+				}
+				ip["lb_id"] = lb["id"]
+				lb["ip"] = []interface{}{ip}
+			},
+		},
+		"lb/ips": {
+			listKey: "ips",
+			defaults: map[string]interface{}{
+				"ip_address": "51.159.0.1",
+				"reverse":    "",
+			},
+		},
+		"rdb/instances": {
+			listKey:      "instances",
+			statusField:  "status",
+			createStatus: "provisioning",
+			transitions: map[string]string{
+				"provisioning": "ready",
+				"initializing": "ready",
+				"configuring":  "ready",
+				"backuping":    "ready",
+			},
+			deleteStatus: "deleting",
+			defaults: map[string]interface{}{
+				"tags":            []interface{}{},
+				"settings":        []interface{}{},
+				"read_replicas":   []interface{}{},
+				"endpoint":        map[string]interface{}{"ip": "51.159.0.2", "port": 5432},
+				"backup_schedule": map[string]interface{}{"frequency": 24, "retention": 7, "disabled": false},
+				"volume":          map[string]interface{}{"type": "lssd", "size": 5000000000},
+			},
+			subResources: map[string]func(f *fakeAPI, instance map[string]interface{}, body map[string]interface{}) (int, interface{}){
+				"GET certificate": func(f *fakeAPI, instance map[string]interface{}, body map[string]interface{}) (int, interface{}) {
+					return http.StatusOK, map[string]interface{}{
+						"name":         "ssl.crt",
+						"content_type": "application/x-pem-file",
+						"content":      base64.StdEncoding.EncodeToString([]byte("-----BEGIN CERTIFICATE-----")),
+					}
+				},
+			},
+		},
+		"k8s/clusters": {
+			listKey:      "clusters",
+			statusField:  "status",
+			createStatus: "creating",
+			transitions: map[string]string{
+				"creating": "pool_required",
+				"updating": "ready",
+			},
+			deleteStatus: "deleting",
+			defaults: map[string]interface{}{
+				"tags": []interface{}{},
+			},
+		},
+		"k8s/pools": {
+			listKey:      "pools",
+			parent:       "clusters",
+			parentField:  "cluster_id",
+			statusField:  "status",
+			createStatus: "scaling",
+			transitions: map[string]string{
+				"scaling":   "ready",
+				"upgrading": "ready",
+			},
+			deleteStatus: "deleting",
+			defaults: map[string]interface{}{
+				"tags": []interface{}{},
+			},
+			onCreate: func(f *fakeAPI, region string, pool map[string]interface{}) {
+				if cluster := f.get("k8s", region, "clusters", fmt.Sprint(pool["cluster_id"])); cluster != nil && cluster["status"] == "pool_required" {
+					cluster["status"] = "ready"
+				}
+			},
+		},
+		"vpc/private-networks": {
+			listKey: "private_networks",
+			defaults: map[string]interface{}{
+				"tags": []interface{}{},
+			},
+		},
+	}
+}
+
+// fakeAPIError returns the body of a Scaleway API error.
+func fakeAPIError(errorType string, message string) map[string]interface{} {
+	return map[string]interface{}{
+		"type":    errorType,
+		"message": message,
+	}
+}
+
+// fakeAPIResponse is a response returned by the fake API instead of the default behavior.
+type fakeAPIResponse struct {
+	method     string
+	path       string
+	statusCode int
+	body       interface{}
+	// once removes the response after it has been used.
+	once bool
+}
+
+// fakeAPI is an in-process stateful fake of the Scaleway instance, lb, rdb, k8s and vpc APIs.
+//
+// Resources are stored as JSON objects and follow the status transitions of their fakeResourceKind.
+// It can be used to unit test resources behaviors that cannot be recorded in cassettes.
+type fakeAPI struct {
+	t      *testing.T
+	server *httptest.Server
+
+	mu        sync.Mutex
+	resources map[string]map[string]map[string]interface{} // product/locality/collection -> id -> resource
+	responses []*fakeAPIResponse
+	requests  []string
+	idCounter int
+
+	restoreEnv func()
+}
+
+// newFakeAPI starts a fake API. The returned fakeAPI must be closed once done with it.
+func newFakeAPI(t *testing.T) *fakeAPI {
+	f := &fakeAPI{
+		t:         t,
+		resources: map[string]map[string]map[string]interface{}{},
+	}
+	f.server = httptest.NewServer(http.HandlerFunc(f.serveHTTP))
+
+	// Make sure no configuration from the environment or a configuration file targets a real API.
+	f.restoreEnv = setTestEnv(t, map[string]string{
+		scw.ScwConfigPathEnv: filepath.Join(os.TempDir(), "scw-fake-api-config-does-not-exist.yaml"),
+	})
+
+	return f
+}
+
+// close stops the fake API.
+func (f *fakeAPI) close() {
+	f.server.Close()
+	f.restoreEnv()
+}
+
+// meta returns a Meta whose clients target the fake API.
+func (f *fakeAPI) meta() *Meta {
+	return f.metaWithConfig(nil)
+}
+
+// metaWithConfig returns a Meta whose clients target the fake API, with additional provider attributes.
+func (f *fakeAPI) metaWithConfig(config map[string]interface{}) *Meta {
+	raw := map[string]interface{}{
+		"api_url":    f.server.URL,
+		"access_key": fakeAPIAccessKey,
+		"secret_key": fakeAPISecretKey,
+		"project_id": fakeAPIProjectID,
+		"region":     scw.RegionFrPar.String(),
+		"zone":       scw.ZoneFrPar1.String(),
+	}
+	for key, value := range config {
+		raw[key] = value
+	}
+	d := schema.TestResourceDataRaw(f.t, Provider(DefaultProviderConfig())().Schema, raw)
+
+	meta, err := buildMeta(&MetaConfig{
+		providerSchema:   d,
+		terraformVersion: "terraform-tests",
+		httpClient:       f.server.Client(),
+	})
+	require.NoError(f.t, err)
+	return meta
+}
+
+// resourceData returns the data of a resource with the given id and attributes.
+func (f *fakeAPI) resourceData(resource *schema.Resource, id string, raw map[string]interface{}) *schema.ResourceData {
+	d := schema.TestResourceDataRaw(f.t, resource.Schema, raw)
+	d.SetId(id)
+	return d
+}
+
+// plan computes the diff creating a resource with the given attributes, running its CustomizeDiff.
+func (f *fakeAPI) plan(meta *Meta, resource *schema.Resource, raw map[string]interface{}) error {
+	_, err := resource.Diff(context.Background(), nil, terraform.NewResourceConfigRaw(raw), meta)
+	return err
+}
+
+// planUpdate computes the diff updating the resource read in d to the given attributes, running its CustomizeDiff.
+func (f *fakeAPI) planUpdate(meta *Meta, resource *schema.Resource, d *schema.ResourceData, raw map[string]interface{}) (*terraform.InstanceDiff, error) {
+	return resource.Diff(context.Background(), d.State(), terraform.NewResourceConfigRaw(raw), meta)
+}
+
+// applyUpdate plans and applies the update of the resource read in d to the given attributes and returns the new state.
+func (f *fakeAPI) applyUpdate(meta *Meta, resource *schema.Resource, d *schema.ResourceData, raw map[string]interface{}) (*terraform.InstanceState, error) {
+	diff, err := f.planUpdate(meta, resource, d, raw)
+	if err != nil {
+		return nil, err
+	}
+	state, diags := resource.Apply(context.Background(), d.State(), diff, meta)
+	if diags.HasError() {
+		return state, fmt.Errorf("%s", diags[0].Summary)
+	}
+	return state, nil
+}
+
+// newID returns a new unique UUID.
+func (f *fakeAPI) newID() string {
+	f.idCounter++
+	return fmt.Sprintf("%08d-0000-4000-8000-%012d", f.idCounter, f.idCounter)
+}
+
+func fakeAPICollectionKey(product, locality, collection string) string {
+	return product + "/" + locality + "/" + collection
+}
+
+// create stores a new resource. It must be called with the lock held or before requests are sent.
+func (f *fakeAPI) create(product, locality, collection string, resource map[string]interface{}) map[string]interface{} {
+	kind := fakeAPIKinds[product+"/"+collection]
+
+	now := time.Now().UTC().Format(time.RFC3339)
+	for key, value := range kind.defaults {
+		if _, exist := resource[key]; !exist {
+			resource[key] = deepCopyJSON(value)
+		}
+	}
+	resource["id"] = f.newID()
+	resource["created_at"] = now
+	resource["updated_at"] = now
+	resource["creation_date"] = now
+	resource["modification_date"] = now
+	if resource["project_id"] == nil && resource["project"] == nil {
+		resource["project_id"] = fakeAPIProjectID
+		resource["project"] = fakeAPIProjectID
+	}
+	resource["organization_id"] = fakeAPIOrganizationID
+	resource["organization"] = fakeAPIOrganizationID
+	if strings.Count(locality, "-") == 2 {
+		resource["zone"] = locality
+	} else {
+		resource["region"] = locality
+	}
+	if kind.statusField != "" && kind.createStatus != "" {
+		resource[kind.statusField] = kind.createStatus
+	}
+	if kind.onCreate != nil {
+		kind.onCreate(f, locality, resource)
+	}
+
+	key := fakeAPICollectionKey(product, locality, collection)
+	if f.resources[key] == nil {
+		f.resources[key] = map[string]map[string]interface{}{}
+	}
+	f.resources[key][resource["id"].(string)] = resource
+	return resource
+}
+
+// get returns a stored resource or nil. It must be called with the lock held or before requests are sent.
+func (f *fakeAPI) get(product, locality, collection, id string) map[string]interface{} {
+	return f.resources[fakeAPICollectionKey(product, locality, collection)][id]
+}
+
+// seed stores a resource as if it had been created through the API and returns it.
+func (f *fakeAPI) seed(product, locality, collection string, resource map[string]interface{}) map[string]interface{} {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.create(product, locality, collection, resource)
+}
+
+// update applies fields on a stored resource, eg to simulate a change made outside of terraform.
+func (f *fakeAPI) update(product, locality, collection, id string, fields map[string]interface{}) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	resource := f.get(product, locality, collection, id)
+	require.NotNil(f.t, resource, "resource %s/%s/%s/%s not found", product, locality, collection, id)
+	for key, value := range fields {
+		resource[key] = value
+	}
+}
+
+// remove deletes a stored resource, eg to simulate a deletion made outside of terraform.
+func (f *fakeAPI) remove(product, locality, collection, id string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	delete(f.resources[fakeAPICollectionKey(product, locality, collection)], id)
+}
+
+// lookup returns a copy of a stored resource or nil.
+func (f *fakeAPI) lookup(product, locality, collection, id string) map[string]interface{} {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	resource := f.get(product, locality, collection, id)
+	if resource == nil {
+		return nil
+	}
+	return deepCopyJSON(resource).(map[string]interface{})
+}
+
+// respond makes the fake API answer the given request with statusCode and body instead of its default behavior.
+// If once is true, the response is only used for the next matching request.
+func (f *fakeAPI) respond(method, path string, statusCode int, body interface{}, once bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.responses = append(f.responses, &fakeAPIResponse{
+		method:     method,
+		path:       path,
+		statusCode: statusCode,
+		body:       body,
+		once:       once,
+	})
+}
+
+// receivedRequests returns the list of requests received by the fake API formatted as "METHOD /path".
+func (f *fakeAPI) receivedRequests() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]string(nil), f.requests...)
+}
+
+func (f *fakeAPI) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.requests = append(f.requests, r.Method+" "+r.URL.Path)
+
+	body := map[string]interface{}{}
+	// The handler runs in the goroutine of the http server, where t.FailNow must not be called.
+	rawBody, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		f.t.Errorf("fake API: cannot read the body of %s %s: %s", r.Method, r.URL.Path, err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	if len(rawBody) > 0 {
+		if err := json.Unmarshal(rawBody, &body); err != nil {
+			f.write(w, http.StatusBadRequest, fakeAPIError("invalid_request_error", err.Error()))
+			return
+		}
+	}
+
+	for i, response := range f.responses {
+		if response.method == r.Method && response.path == r.URL.Path {
+			if response.once {
+				f.responses = append(f.responses[:i], f.responses[i+1:]...)
+			}
+			f.write(w, response.statusCode, response.body)
+			return
+		}
+	}
+
+	statusCode, responseBody := f.handle(r, body)
+	f.write(w, statusCode, responseBody)
+}
+
+func (f *fakeAPI) write(w http.ResponseWriter, statusCode int, body interface{}) {
+	if body == nil {
+		w.WriteHeader(statusCode)
+		return
+	}
+	raw, err := json.Marshal(body)
+	if err != nil {
+		f.t.Errorf("fake API: cannot encode response body: %s", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	_, _ = w.Write(raw)
+}
+
+// handle routes a request following the Scaleway API path conventions:
+// /{product}/{version}/{zones|regions}/{locality}/{collection}[/{id}[/{sub}]]
+// /{product}/{version}/{zones|regions}/{locality}/{parent}/{parentID}/{collection}[/{id}]
+func (f *fakeAPI) handle(r *http.Request, body map[string]interface{}) (int, interface{}) {
+	parts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if len(parts) < 5 || (parts[2] != "zones" && parts[2] != "regions") {
+		return http.StatusNotFound, fakeAPIError("not_found", "unknown path "+r.URL.Path)
+	}
+	product, locality, collection := parts[0], parts[3], parts[4]
+
+	switch len(parts) {
+	case 5:
+		kind, exist := fakeAPIKinds[product+"/"+collection]
+		if !exist {
+			break
+		}
+		switch r.Method {
+		case http.MethodGet:
+			return f.list(r, kind, product, locality, collection, "")
+		case http.MethodPost:
+			return http.StatusOK, f.wrap(kind, f.create(product, locality, collection, body))
+		}
+	case 6:
+		kind, exist := fakeAPIKinds[product+"/"+collection]
+		if !exist {
+			break
+		}
+		return f.handleResource(r, kind, product, locality, collection, parts[5], body)
+	case 7:
+		// Nested collection, eg /k8s/v1/regions/fr-par/clusters/{cluster_id}/pools
+		if kind, exist := fakeAPIKinds[product+"/"+parts[6]]; exist && kind.parent == collection {
+			if f.get(product, locality, collection, parts[5]) == nil {
+				return http.StatusNotFound, fakeAPIError("not_found", collection+" "+parts[5]+" not found")
+			}
+			switch r.Method {
+			case http.MethodGet:
+				return f.list(r, kind, product, locality, parts[6], parts[5])
+			case http.MethodPost:
+				body[kind.parentField] = parts[5]
+				return http.StatusOK, f.wrap(kind, f.create(product, locality, parts[6], body))
+			}
+			break
+		}
+
+		// Sub resource, eg /instance/v1/zones/fr-par-1/servers/{server_id}/action
+		kind, exist := fakeAPIKinds[product+"/"+collection]
+		if !exist {
+			break
+		}
+		resource := f.read(kind, product, locality, collection, parts[5])
+		if resource == nil {
+			return http.StatusNotFound, fakeAPIError("not_found", collection+" "+parts[5]+" not found")
+		}
+		if handler, exist := kind.subResources[r.Method+" "+parts[6]]; exist {
+			return handler(f, resource, body)
+		}
+	case 8:
+		// Nested resource, eg /instance/v1/zones/fr-par-1/servers/{server_id}/private_nics/{private_nic_id}
+		kind, exist := fakeAPIKinds[product+"/"+parts[6]]
+		if !exist || kind.parent != collection {
+			break
+		}
+		if f.get(product, locality, collection, parts[5]) == nil {
+			return http.StatusNotFound, fakeAPIError("not_found", collection+" "+parts[5]+" not found")
+		}
+		if resource := f.get(product, locality, parts[6], parts[7]); resource == nil || resource[kind.parentField] != parts[5] {
+			return http.StatusNotFound, fakeAPIError("not_found", parts[6]+" "+parts[7]+" not found")
+		}
+		return f.handleResource(r, kind, product, locality, parts[6], parts[7], body)
+	}
+
+	return http.StatusNotFound, fakeAPIError("not_found", "unknown path "+r.URL.Path)
+}
+
+func (f *fakeAPI) handleResource(r *http.Request, kind *fakeResourceKind, product, locality, collection, id string, body map[string]interface{}) (int, interface{}) {
+	resource := f.read(kind, product, locality, collection, id)
+	if resource == nil {
+		return http.StatusNotFound, map[string]interface{}{
+			"type":          "not_found",
+			"resource":      collection,
+			"resource_id":   id,
+			"message":       "resource is not found",
+			"resource_type": collection,
+		}
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		return http.StatusOK, f.wrap(kind, resource)
+	case http.MethodPatch, http.MethodPut:
+		for key, value := range body {
+			if value != nil {
+				resource[key] = value
+			}
+		}
+		resource["updated_at"] = time.Now().UTC().Format(time.RFC3339)
+		resource["modification_date"] = resource["updated_at"]
+		return http.StatusOK, f.wrap(kind, resource)
+	case http.MethodDelete:
+		if kind.statusField != "" && kind.statusField == "state" && resource["state"] == "running" {
+			return http.StatusBadRequest, fakeAPIError("invalid_request_error", "resource must be stopped to be deleted")
+		}
+		if kind.deleteStatus != "" {
+			resource[kind.statusField] = kind.deleteStatus
+		} else {
+			delete(f.resources[fakeAPICollectionKey(product, locality, collection)], id)
+		}
+		if kind.wrapper != "" {
+			return http.StatusNoContent, nil
+		}
+		return http.StatusOK, resource
+	}
+
+	return http.StatusMethodNotAllowed, fakeAPIError("invalid_request_error", "method not allowed")
+}
+
+// read returns a resource after applying its pending status transition.
+func (f *fakeAPI) read(kind *fakeResourceKind, product, locality, collection, id string) map[string]interface{} {
+	resource := f.get(product, locality, collection, id)
+	if resource == nil {
+		return nil
+	}
+
+	if kind.statusField == "" {
+		return resource
+	}
+	status := fmt.Sprint(resource[kind.statusField])
+	if kind.deleteStatus != "" && status == kind.deleteStatus {
+		delete(f.resources[fakeAPICollectionKey(product, locality, collection)], id)
+		return nil
+	}
+	if next, exist := kind.transitions[status]; exist {
+		resource[kind.statusField] = next
+	}
+	return resource
+}
+
+func (f *fakeAPI) list(r *http.Request, kind *fakeResourceKind, product, locality, collection, parentID string) (int, interface{}) {
+	query := r.URL.Query()
+
+	ids := []string(nil)
+	for id := range f.resources[fakeAPICollectionKey(product, locality, collection)] {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	resources := []interface{}{}
+	for _, id := range ids {
+		resource := f.get(product, locality, collection, id)
+		if parentID != "" && resource[kind.parentField] != parentID {
+			continue
+		}
+		if name := query.Get("name"); name != "" && !strings.Contains(fmt.Sprint(resource["name"]), name) {
+			continue
+		}
+		resources = append(resources, resource)
+	}
+	totalCount := len(resources)
+
+	// All the resources are returned on the first page.
+	if page, err := strconv.Atoi(query.Get("page")); err == nil && page > 1 {
+		resources = []interface{}{}
+	}
+
+	return http.StatusOK, map[string]interface{}{
+		kind.listKey:  resources,
+		"total_count": totalCount,
+	}
+}
+
+func (f *fakeAPI) wrap(kind *fakeResourceKind, resource map[string]interface{}) interface{} {
+	if kind.wrapper == "" {
+		return resource
+	}
+	return map[string]interface{}{kind.wrapper: resource}
+}
+
+// deepCopyJSON copies a decoded JSON value.
+func deepCopyJSON(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		copied := make(map[string]interface{}, len(v))
+		for key, child := range v {
+			copied[key] = deepCopyJSON(child)
+		}
+		return copied
+	case []interface{}:
+		copied := make([]interface{}, len(v))
+		for i, child := range v {
+			copied[i] = deepCopyJSON(child)
+		}
+		return copied
+	}
+	return value
+}
@@ -1,17 +1,22 @@
 package scaleway
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net"
 	"net/http"
+	"sort"
+	"strconv"
 	"strings"
 	"time"
 
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/scaleway/scaleway-sdk-go/namegenerator"
 	"github.com/scaleway/scaleway-sdk-go/scw"
+	sdkValidation "github.com/scaleway/scaleway-sdk-go/validation"
 	"golang.org/x/xerrors"
 )
 
@@ -86,6 +91,240 @@ func parseLocalizedID(localizedID string) (locality string, ID string, err error
 	return tab[0], tab[1], nil
 }
 
+// importStateByName returns an importer accepting a localized ID or a localized name, eg fr-par-1/my-server.
+//
+// listIDs must return the localized IDs of the resources named after the ID part of the given localized name.
+// The import fails if no resource or several resources have this name.
+func importStateByName(kind string, listIDs func(ctx context.Context, meta interface{}, localizedName string) ([]string, error)) schema.StateContextFunc {
+	return func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+		locality, nameOrID, err := parseLocalizedID(d.Id())
+		if err != nil {
+			return nil, fmt.Errorf("cannot import %s %q, expected {locality}/{id} or {locality}/{name}", kind, d.Id())
+		}
+		if sdkValidation.IsUUID(nameOrID) {
+			return []*schema.ResourceData{d}, nil
+		}
+
+		ids, err := listIDs(ctx, meta, d.Id())
+		if err != nil {
+			return nil, err
+		}
+
+		switch len(ids) {
+		case 0:
+			return nil, fmt.Errorf("no %s named %q found in %s", kind, nameOrID, locality)
+		case 1:
+			d.SetId(ids[0])
+			return []*schema.ResourceData{d}, nil
+		default:
+			return nil, fmt.Errorf("%d %ss named %q found in %s, import one of them by ID: %s", len(ids), kind, nameOrID, locality, strings.Join(ids, ", "))
+		}
+	}
+}
+
+// customizeDiffCatalogType returns a CustomizeDiffFunc failing the plan when the value of attribute
+// is not part of the catalog returned by listTypes. Values are compared once normalized.
+//
+// The catalog is only fetched when the value is known and changed. If it cannot be fetched the
+// check is skipped so that an API hiccup does not prevent planning.
+func customizeDiffCatalogType(attribute string, kind string, normalize func(string) string, listTypes func(ctx context.Context, diff *schema.ResourceDiff, meta interface{}) ([]string, error)) schema.CustomizeDiffFunc {
+	return func(ctx context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+		if !diff.NewValueKnown(attribute) || !diff.HasChange(attribute) {
+			return nil
+		}
+		value := diff.Get(attribute).(string)
+
+		types, err := listTypes(ctx, diff, meta)
+		if err != nil {
+			l.Warningf("cannot validate %s %q: %s", kind, value, err)
+			return nil
+		}
+
+		for _, t := range types {
+			if normalize(t) == normalize(value) {
+				return nil
+			}
+		}
+
+		matches := closestMatches(value, types, normalize)
+		if len(matches) == 0 {
+			return fmt.Errorf("%s: unknown %s %q", attribute, kind, value)
+		}
+		return fmt.Errorf("%s: unknown %s %q, did you mean %s?", attribute, kind, value, strings.Join(matches, ", "))
+	}
+}
+
+// customizeDiffLocalityCheck returns a CustomizeDiffFunc checking that the localized IDs referenced by the given attributes
+// are in a locality compatible with the one of the resource: the same zone or region, or a zone inside its region.
+// localityAttribute is the "zone" or "region" attribute of the resource. When it is not set, the default locality of the
+// provider is used. When empty, the referenced IDs must be compatible with the first of them.
+// IDs without locality and unknown values are not checked.
+func customizeDiffLocalityCheck(localityAttribute string, attributes ...string) schema.CustomizeDiffFunc {
+	return func(ctx context.Context, diff *schema.ResourceDiff, m interface{}) error {
+		locality, localityDescription := "", ""
+		switch {
+		case localityAttribute != "zone" && localityAttribute != "region":
+		case diff.NewValueKnown(localityAttribute):
+			var err error
+			if localityAttribute == "zone" {
+				var zone scw.Zone
+				zone, err = extractZone(diff, m.(*Meta))
+				locality = zone.String()
+			} else {
+				var region scw.Region
+				region, err = extractRegion(diff, m.(*Meta))
+				locality = region.String()
+			}
+			if err == nil {
+				localityDescription = "the resource is in " + localityAttribute + " " + locality
+			} else {
+				locality = ""
+			}
+		default:
+			// The locality is unknown when it is not set at creation, the resource is then created in the default
+			// locality of the provider. The SDK does not tell it apart from a locality set to a computed value.
+			if localityAttribute == "zone" {
+				if zone, exist := m.(*Meta).scwClient.GetDefaultZone(); exist {
+					locality = zone.String()
+				}
+			} else if region, exist := m.(*Meta).scwClient.GetDefaultRegion(); exist {
+				locality = region.String()
+			}
+			if locality != "" {
+				localityDescription = "the resource is in the default " + localityAttribute + " " + locality
+			}
+		}
+
+		for _, attribute := range attributes {
+			// An attribute of a list of blocks is referenced as block.#.attribute.
+			block, blockAttribute := attribute, ""
+			if parts := strings.SplitN(attribute, ".#.", 2); len(parts) == 2 {
+				block, blockAttribute = parts[0], parts[1]
+			}
+			if !diff.NewValueKnown(block) {
+				continue
+			}
+
+			ids := []string(nil)
+			switch value := diff.Get(block).(type) {
+			case string:
+				ids = append(ids, value)
+			case []interface{}:
+				if blockAttribute == "" {
+					ids = expandStrings(value)
+					break
+				}
+				for _, rawBlock := range value {
+					if blockValues, isMap := rawBlock.(map[string]interface{}); isMap {
+						if id, isString := blockValues[blockAttribute].(string); isString {
+							ids = append(ids, id)
+						}
+					}
+				}
+			case *schema.Set:
+				ids = expandStrings(value.List())
+			}
+
+			for _, id := range ids {
+				idLocality, _, err := parseLocalizedID(id)
+				if err != nil || idLocality == "" {
+					continue
+				}
+				if locality == "" {
+					locality, localityDescription = idLocality, fmt.Sprintf("%s is in %s", attribute, idLocality)
+					continue
+				}
+				if !localitiesAreCompatible(locality, idLocality) {
+					return fmt.Errorf("%s: %s is in %s but %s", attribute, id, idLocality, localityDescription)
+				}
+			}
+		}
+		return nil
+	}
+}
+
+// localitiesAreCompatible returns true if two localities are the same zone or region,
+// or if one of them is a zone inside the other one.
+func localitiesAreCompatible(a, b string) bool {
+	return a == b || (localityRegion(a) == localityRegion(b) && (!sdkValidation.IsZone(a) || !sdkValidation.IsZone(b)))
+}
+
+// localityRegion returns the region of a zone, eg fr-par for fr-par-1, or the locality itself if it is a region.
+func localityRegion(locality string) string {
+	if sdkValidation.IsZone(locality) {
+		return locality[:strings.LastIndex(locality, "-")]
+	}
+	return locality
+}
+
+// closestMatches returns up to 5 candidates close to value, the closest first.
+// A candidate is close when its edit distance to value is small or when one contains the other.
+func closestMatches(value string, candidates []string, normalize func(string) string) []string {
+	const maxMatches = 5
+
+	type match struct {
+		candidate string
+		distance  int
+	}
+
+	normalizedValue := normalize(value)
+	maxDistance := len(normalizedValue) / 3
+	if maxDistance < 2 {
+		maxDistance = 2
+	}
+
+	matches := []match(nil)
+	for _, candidate := range candidates {
+		normalizedCandidate := normalize(candidate)
+		distance := levenshteinDistance(normalizedValue, normalizedCandidate)
+		if distance <= maxDistance || strings.Contains(normalizedCandidate, normalizedValue) || strings.Contains(normalizedValue, normalizedCandidate) {
+			matches = append(matches, match{candidate: candidate, distance: distance})
+		}
+	}
+
+	sort.Slice(matches, func(i, j int) bool {
+		if matches[i].distance != matches[j].distance {
+			return matches[i].distance < matches[j].distance
+		}
+		return matches[i].candidate < matches[j].candidate
+	})
+
+	res := []string(nil)
+	for i := 0; i < len(matches) && i < maxMatches; i++ {
+		res = append(res, matches[i].candidate)
+	}
+	return res
+}
+
+// levenshteinDistance returns the number of single character edits needed to turn a into b.
+func levenshteinDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	previous := make([]int, len(rb)+1)
+	current := make([]int, len(rb)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		current[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			current[j] = previous[j-1] + cost
+			if previous[j]+1 < current[j] {
+				current[j] = previous[j] + 1
+			}
+			if current[j-1]+1 < current[j] {
+				current[j] = current[j-1] + 1
+			}
+		}
+		previous, current = current, previous
+	}
+	return previous[len(rb)]
+}
+
 // parseLocalizedNestedID parses a localizedNestedID and extracts the resource locality, the inner and outer id.
 func parseLocalizedNestedID(localizedID string) (locality string, innerID, outerID string, err error) {
 	tab := strings.SplitN(localizedID, "/", -1)
@@ -152,14 +391,12 @@ func newRegionalIDString(region scw.Region, id string) string {
 	return fmt.Sprintf("%s/%s", region, id)
 }
 
-// terraformResourceData is an interface for *schema.ResourceData. (used for mock)
+// terraformResourceData is an interface for *schema.ResourceData and *schema.ResourceDiff. (used for mock)
 type terraformResourceData interface {
 	HasChange(string) bool
 	GetOkExists(string) (interface{}, bool)
 	GetOk(string) (interface{}, bool)
 	Get(string) interface{}
-	Set(string, interface{}) error
-	SetId(string)
 	Id() string
 }
 
@@ -172,7 +409,11 @@ var ErrZoneNotFound = fmt.Errorf("could not detect zone. Scaleway uses regions a
 func extractZone(d terraformResourceData, meta *Meta) (scw.Zone, error) {
 	rawZone, exist := d.GetOkExists("zone")
 	if exist {
-		return scw.ParseZone(rawZone.(string))
+		zone, err := scw.ParseZone(strings.ToLower(rawZone.(string)))
+		if err != nil {
+			return "", err
+		}
+		return zone, meta.localities.validateZone(zone)
 	}
 
 	zone, exist := meta.scwClient.GetDefaultZone()
@@ -192,7 +433,11 @@ var ErrRegionNotFound = fmt.Errorf("could not detect region")
 func extractRegion(d terraformResourceData, meta *Meta) (scw.Region, error) {
 	rawRegion, exist := d.GetOkExists("region")
 	if exist {
-		return scw.ParseRegion(rawRegion.(string))
+		region, err := scw.ParseRegion(strings.ToLower(rawRegion.(string)))
+		if err != nil {
+			return "", err
+		}
+		return region, meta.localities.validateRegion(region)
 	}
 
 	region, exist := meta.scwClient.GetDefaultRegion()
@@ -228,6 +473,160 @@ func is403Error(err error) bool {
 	return isHTTPCodeError(err, http.StatusForbidden) || xerrors.As(err, &permissionsDeniedError)
 }
 
+// diagFromErr converts an error to diagnostics, translating Scaleway API errors into a human summary
+// and a remediation detail. Errors about an argument point to the matching attribute.
+// Other errors are converted as is.
+func diagFromErr(err error) diag.Diagnostics {
+	if err == nil {
+		return nil
+	}
+
+	invalidArgumentsError := &scw.InvalidArgumentsError{}
+	if xerrors.As(err, &invalidArgumentsError) && len(invalidArgumentsError.Details) > 0 {
+		diags := diag.Diagnostics(nil)
+		for _, detail := range invalidArgumentsError.Details {
+			diags = append(diags, diag.Diagnostic{
+				Severity:      diag.Error,
+				Summary:       fmt.Sprintf("invalid argument %s: %s", detail.ArgumentName, invalidArgumentReason(detail.Reason)),
+				Detail:        detail.HelpMessage,
+				AttributePath: attributePathFromArgumentName(detail.ArgumentName),
+			})
+		}
+		return diags
+	}
+
+	quotasExceededError := &scw.QuotasExceededError{}
+	if xerrors.As(err, &quotasExceededError) && len(quotasExceededError.Details) > 0 {
+		diags := diag.Diagnostics(nil)
+		for _, detail := range quotasExceededError.Details {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  fmt.Sprintf("quota exceeded for %s", detail.Resource),
+				Detail: fmt.Sprintf("%d %s are used out of a quota of %d. Delete unused %s or ask for a quota increase in the console: https://console.scaleway.com/organization/quotas",
+					detail.Current, detail.Resource, detail.Quota, detail.Resource),
+			})
+		}
+		return diags
+	}
+
+	outOfStockError := &scw.OutOfStockError{}
+	if xerrors.As(err, &outOfStockError) {
+		return diag.Diagnostics{{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("%s is out of stock", outOfStockError.Resource),
+			Detail:   "Retry later or choose another type or zone.",
+		}}
+	}
+
+	permissionsDeniedError := &scw.PermissionsDeniedError{}
+	if xerrors.As(err, &permissionsDeniedError) {
+		actions := []string(nil)
+		for _, detail := range permissionsDeniedError.Details {
+			actions = append(actions, fmt.Sprintf("%s %s", detail.Action, detail.Resource))
+		}
+		return diag.Diagnostics{{
+			Severity: diag.Error,
+			Summary:  "insufficient permissions: " + strings.Join(actions, ", "),
+			Detail:   "Check that the access key in use belongs to the organization of the project and is allowed to perform these actions.",
+		}}
+	}
+
+	transientStateError := &scw.TransientStateError{}
+	if xerrors.As(err, &transientStateError) {
+		return diag.Diagnostics{{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("%s %s is in a transient state: %s", transientStateError.Resource, transientStateError.ResourceID, transientStateError.CurrentState),
+			Detail:   "Another operation is in progress on this resource. Wait for it to complete and retry.",
+		}}
+	}
+
+	return diag.FromErr(err)
+}
+
+// invalidArgumentReason returns a human description of the reason of an InvalidArgumentsErrorDetail.
+func invalidArgumentReason(reason string) string {
+	switch reason {
+	case "required":
+		return "is required"
+	case "format":
+		return "is wrongly formatted"
+	case "constraint":
+		return "does not respect constraint"
+	default:
+		return "is invalid"
+	}
+}
+
+// attributePathFromArgumentName returns the attribute path of an API argument name.
+// e.g. volumes.0.size or volumes[0].size == volumes -> 0 -> size
+func attributePathFromArgumentName(name string) cty.Path {
+	path := cty.Path(nil)
+	name = strings.NewReplacer("[", ".", "]", "").Replace(name)
+	for _, step := range strings.Split(name, ".") {
+		if step == "" {
+			continue
+		}
+		if index, err := strconv.Atoi(step); err == nil {
+			path = path.IndexInt(index)
+		} else {
+			path = path.GetAttr(step)
+		}
+	}
+	return path
+}
+
+// withSchemaAttributePaths wraps the CRUD functions of a resource so that the attribute paths of their diagnostics
+// always exist in its schema. API argument names usually match attribute names but not always,
+// e.g. commercial_type is the type of an instance server: such paths are removed.
+func withSchemaAttributePaths(resource *schema.Resource) *schema.Resource {
+	wrap := func(f func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics) func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics {
+		if f == nil {
+			return nil
+		}
+		return func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+			diags := f(ctx, d, meta)
+			for i := range diags {
+				if diags[i].AttributePath != nil && !schemaHasAttributePath(resource.Schema, diags[i].AttributePath) {
+					diags[i].AttributePath = nil
+				}
+			}
+			return diags
+		}
+	}
+
+	resource.CreateContext = wrap(resource.CreateContext)
+	resource.ReadContext = wrap(resource.ReadContext)
+	resource.UpdateContext = wrap(resource.UpdateContext)
+	resource.DeleteContext = wrap(resource.DeleteContext)
+	return resource
+}
+
+// schemaHasAttributePath returns true if path targets an attribute of the given schema.
+func schemaHasAttributePath(attributes map[string]*schema.Schema, path cty.Path) bool {
+	for i := 0; i < len(path); i++ {
+		step, isAttr := path[i].(cty.GetAttrStep)
+		if !isAttr || attributes == nil {
+			return false
+		}
+		attribute, exist := attributes[step.Name]
+		if !exist {
+			return false
+		}
+
+		attributes = nil
+		if elem, isResource := attribute.Elem.(*schema.Resource); isResource {
+			attributes = elem.Schema
+		}
+		// Skip the index of a list, set or map element.
+		if i+1 < len(path) {
+			if _, isIndex := path[i+1].(cty.IndexStep); isIndex {
+				i++
+			}
+		}
+	}
+	return true
+}
+
 // organizationIDSchema returns a standard schema for a organization_id
 func organizationIDSchema() *schema.Schema {
 	return &schema.Schema{
@@ -251,39 +650,27 @@ func projectIDSchema() *schema.Schema {
 
 // zoneSchema returns a standard schema for a zone
 func zoneSchema() *schema.Schema {
-	var allZones []string
-	for _, z := range scw.AllZones {
-		allZones = append(allZones, z.String())
-	}
 	return &schema.Schema{
-		Type:        schema.TypeString,
-		Description: "The zone you want to attach the resource to",
-		Optional:    true,
-		ForceNew:    true,
-		Computed:    true,
-		ValidateFunc: validation.StringInSlice(
-			allZones,
-			true,
-		),
+		Type:             schema.TypeString,
+		Description:      "The zone you want to attach the resource to",
+		Optional:         true,
+		ForceNew:         true,
+		Computed:         true,
+		ValidateFunc:     validationZone(),
+		DiffSuppressFunc: diffSuppressFuncLocalityCase,
 	}
 }
 
 // regionSchema returns a standard schema for a zone
 func regionSchema() *schema.Schema {
-	var allRegions []string
-	for _, z := range scw.AllRegions {
-		allRegions = append(allRegions, z.String())
-	}
 	return &schema.Schema{
-		Type:        schema.TypeString,
-		Description: "The region you want to attach the resource to",
-		Optional:    true,
-		ForceNew:    true,
-		Computed:    true,
-		ValidateFunc: validation.StringInSlice(
-			allRegions,
-			true,
-		),
+		Type:             schema.TypeString,
+		Description:      "The region you want to attach the resource to",
+		Optional:         true,
+		ForceNew:         true,
+		Computed:         true,
+		ValidateFunc:     validationRegion(),
+		DiffSuppressFunc: diffSuppressFuncLocalityCase,
 	}
 }
 
@@ -342,6 +729,111 @@ func expandStrings(data interface{}) []string {
 	return stringSlice
 }
 
+// splitTag splits a `key:value` tag in its key and value parts.
+// A tag without separator is considered as a key with an empty value.
+func splitTag(tag string) (key string, value string) {
+	parts := strings.SplitN(tag, ":", 2)
+	if len(parts) == 1 {
+		return parts[0], ""
+	}
+	return parts[0], parts[1]
+}
+
+// expandTags returns the tags of a resource merged with the provider default tags.
+// A resource tag overrides the default tag sharing the same key.
+func expandTags(m interface{}, data interface{}) []string {
+	tags := expandStrings(data)
+
+	keys := make(map[string]bool, len(tags))
+	for _, tag := range tags {
+		key, _ := splitTag(tag)
+		keys[key] = true
+	}
+
+	for _, tag := range m.(*Meta).defaultTags {
+		key, _ := splitTag(tag)
+		if !keys[key] {
+			tags = append(tags, tag)
+		}
+	}
+
+	return tags
+}
+
+// flattenTags removes the provider default tags from the tags returned by the API
+// so they do not show up as a diff. Default tags overridden by a resource tag are kept.
+func flattenTags(m interface{}, tags []string, data interface{}) []string {
+	defaultTags := m.(*Meta).defaultTags
+	if len(defaultTags) == 0 {
+		return tags
+	}
+
+	resourceKeys := map[string]bool{}
+	for _, tag := range expandStringsOrEmpty(data) {
+		key, _ := splitTag(tag)
+		resourceKeys[key] = true
+	}
+
+	isDefaultTag := make(map[string]bool, len(defaultTags))
+	for _, tag := range defaultTags {
+		isDefaultTag[tag] = true
+	}
+
+	flattenedTags := []string{}
+	for _, tag := range tags {
+		key, _ := splitTag(tag)
+		if isDefaultTag[tag] && !resourceKeys[key] {
+			continue
+		}
+		flattenedTags = append(flattenedTags, tag)
+	}
+
+	return flattenedTags
+}
+
+// tagsAllSchema returns the schema of the tags of a resource merged with the provider default tags.
+func tagsAllSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Computed:    true,
+		Description: "The tags of the resource, including the provider default tags",
+		Elem: &schema.Schema{
+			Type: schema.TypeString,
+		},
+	}
+}
+
+// customizeDiffTagsAll plans tags_all to the tags of the resource merged with the provider default tags.
+// tags_all holds the tags read from the API, so a change of the default tags updates the existing resources.
+func customizeDiffTagsAll(_ context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+	if !diff.NewValueKnown("tags") {
+		return diff.SetNewComputed("tags_all")
+	}
+	tags := expandTags(meta, diff.Get("tags"))
+	if diff.Id() != "" && sameStringSets(tags, expandStringsOrEmpty(diff.Get("tags_all"))) {
+		return nil
+	}
+	return diff.SetNew("tags_all", tags)
+}
+
+// sameStringSets returns true when both slices hold the same strings, in any order.
+func sameStringSets(a []string, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	counts := make(map[string]int, len(a))
+	for _, s := range a {
+		counts[s]++
+	}
+	for _, s := range b {
+		if counts[s] == 0 {
+			return false
+		}
+		counts[s]--
+	}
+	return true
+}
+
 func expandStringsOrEmpty(data interface{}) []string {
 	if data == nil {
 		return []string{}
@@ -468,6 +960,12 @@ func diffSuppressFuncIgnoreCase(k, old, new string, d *schema.ResourceData) bool
 	return strings.EqualFold(old, new)
 }
 
+// diffSuppressFuncLocalityCase ignores the case of a zone or region, which the API returns in lower case.
+// A locality which is not set yet is not suppressed so it is still computed.
+func diffSuppressFuncLocalityCase(k, old, new string, d *schema.ResourceData) bool {
+	return old != "" && strings.EqualFold(old, new)
+}
+
 func diffSuppressFuncIgnoreCaseAndHyphen(k, old, new string, d *schema.ResourceData) bool {
 	return strings.Replace(strings.ToLower(old), "-", "_", -1) == strings.Replace(strings.ToLower(new), "-", "_", -1)
 }
@@ -477,3 +975,93 @@ func diffSuppressFuncIgnoreCaseAndHyphen(k, old, new string, d *schema.ResourceD
 func diffSuppressFuncLocality(k, old, new string, d *schema.ResourceData) bool {
 	return expandID(old) == expandID(new)
 }
+
+// withLocalizedIDsStateUpgrade adds a StateUpgrader to a resource, bumping its SchemaVersion.
+// The upgrade rewrites the given attributes storing IDs without locality, as written by older versions of the provider,
+// to their localized form, zone/uuid or region/uuid. attributes maps the attribute names to "zone" or "region".
+// Both string and list of strings attributes are supported. The schema of the resource is unchanged.
+func withLocalizedIDsStateUpgrade(resource *schema.Resource, attributes map[string]string) *schema.Resource {
+	resource.StateUpgraders = append(resource.StateUpgraders, schema.StateUpgrader{
+		Version: resource.SchemaVersion,
+		Type:    resource.CoreConfigSchema().ImpliedType(),
+		Upgrade: upgradeStateLocalizedIDs(attributes),
+	})
+	resource.SchemaVersion++
+	return resource
+}
+
+// upgradeStateLocalizedIDs returns a StateUpgradeFunc localizing the IDs of the given attributes.
+// See withLocalizedIDsStateUpgrade.
+func upgradeStateLocalizedIDs(attributes map[string]string) schema.StateUpgradeFunc {
+	return func(ctx context.Context, rawState map[string]interface{}, meta interface{}) (map[string]interface{}, error) {
+		for attribute, localityKind := range attributes {
+			switch value := rawState[attribute].(type) {
+			case string:
+				if !sdkValidation.IsUUID(value) {
+					continue
+				}
+				locality, err := stateLocality(rawState, attributes, localityKind, meta)
+				if err != nil {
+					return nil, err
+				}
+				rawState[attribute] = locality + "/" + value
+			case []interface{}:
+				for i, item := range value {
+					if id, isString := item.(string); isString && sdkValidation.IsUUID(id) {
+						locality, err := stateLocality(rawState, attributes, localityKind, meta)
+						if err != nil {
+							return nil, err
+						}
+						value[i] = locality + "/" + id
+					}
+				}
+			}
+		}
+		return rawState, nil
+	}
+}
+
+// stateLocality returns the zone or region (according to localityKind) of a raw state.
+// It is read from the zone or region attribute of the state, then from the attributes of the same kind
+// already localized, falling back to the default locality of the provider.
+func stateLocality(rawState map[string]interface{}, attributes map[string]string, localityKind string, m interface{}) (string, error) {
+	if locality, ok := rawState[localityKind].(string); ok && locality != "" {
+		return locality, nil
+	}
+	if zone, ok := rawState["zone"].(string); ok && zone != "" && localityKind == "region" {
+		var localities *localities
+		if meta, ok := m.(*Meta); ok && meta != nil {
+			localities = meta.localities
+		}
+		region, err := localities.zoneRegion(scw.Zone(zone))
+		if err == nil {
+			return region.String(), nil
+		}
+	}
+
+	names := []string(nil)
+	for attribute, kind := range attributes {
+		if kind == localityKind {
+			names = append(names, attribute)
+		}
+	}
+	sort.Strings(names)
+	for _, attribute := range names {
+		if value, ok := rawState[attribute].(string); ok {
+			if locality, _, err := parseLocalizedID(value); err == nil && locality != "" {
+				return locality, nil
+			}
+		}
+	}
+
+	if meta, ok := m.(*Meta); ok && meta != nil {
+		if localityKind == "zone" {
+			if zone, exist := meta.scwClient.GetDefaultZone(); exist {
+				return zone.String(), nil
+			}
+		} else if region, exist := meta.scwClient.GetDefaultRegion(); exist {
+			return region.String(), nil
+		}
+	}
+	return "", fmt.Errorf("cannot upgrade state: could not detect %s", localityKind)
+}
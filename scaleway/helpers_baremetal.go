@@ -1,6 +1,8 @@
 package scaleway
 
 import (
+	"context"
+	"fmt"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -99,3 +101,36 @@ func flattenBaremetalIPs(ips []*baremetal.IP) interface{} {
 	}
 	return flattendIPs
 }
+
+// baremetalOffers returns the offers of a zone.
+// The result comes from the catalog cache of meta.
+func baremetalOffers(ctx context.Context, m interface{}, zone scw.Zone) ([]*baremetal.Offer, error) {
+	meta := m.(*Meta)
+	offers, err := meta.catalogCache.get("baremetal/offers/"+zone.String(), func() (interface{}, error) {
+		res, err := baremetal.NewAPI(meta.scwClient).ListOffers(&baremetal.ListOffersRequest{
+			Zone: zone,
+		}, scw.WithAllPages(), scw.WithContext(ctx))
+		if err != nil {
+			return nil, err
+		}
+		return res.Offers, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return offers.([]*baremetal.Offer), nil
+}
+
+// baremetalOfferByName returns the offer of a zone with the given name.
+func baremetalOfferByName(ctx context.Context, m interface{}, zone scw.Zone, name string) (*baremetal.Offer, error) {
+	offers, err := baremetalOffers(ctx, m, zone)
+	if err != nil {
+		return nil, err
+	}
+	for _, offer := range offers {
+		if offer.Name == name {
+			return offer, nil
+		}
+	}
+	return nil, fmt.Errorf("could not find the offer ID from name %s", name)
+}
@@ -3,6 +3,7 @@ package scaleway
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"sort"
 	"time"
 
@@ -23,6 +24,9 @@ const (
 	defaultInstanceSecurityGroupRuleTimeout = 1 * time.Minute
 	defaultInstancePlacementGroupTimeout    = 1 * time.Minute
 	defaultInstanceIPTimeout                = 1 * time.Minute
+	defaultInstancePrivateNICTimeout        = 10 * time.Minute
+	defaultInstanceSnapshotWaitTimeout      = 1 * time.Hour
+	defaultInstanceImageWaitTimeout         = 1 * time.Hour
 )
 
 // instanceAPIWithZone returns a new instance API and the zone for a Create request
@@ -105,7 +109,7 @@ func serverStateExpand(rawState string) (instance.ServerState, error) {
 	return apiState, nil
 }
 
-func reachState(ctx context.Context, instanceAPI *instance.API, zone scw.Zone, serverID string, toState instance.ServerState) error {
+func reachState(ctx context.Context, instanceAPI *instance.API, zone scw.Zone, serverID string, toState instance.ServerState, timeout time.Duration) error {
 	response, err := instanceAPI.GetServer(&instance.GetServerRequest{
 		Zone:     zone,
 		ServerID: serverID,
@@ -138,8 +142,8 @@ func reachState(ctx context.Context, instanceAPI *instance.API, zone scw.Zone, s
 			ServerID: serverID,
 			Action:   a,
 			Zone:     zone,
-			Timeout:  scw.TimeDurationPtr(defaultInstanceServerWaitTimeout),
-		})
+			Timeout:  scw.TimeDurationPtr(timeout),
+		}, scw.WithContext(ctx))
 		if err != nil {
 			return err
 		}
@@ -147,17 +151,177 @@ func reachState(ctx context.Context, instanceAPI *instance.API, zone scw.Zone, s
 	return nil
 }
 
+// resizeInstanceVolume grows a block volume to sizeInGB once it is available.
+func resizeInstanceVolume(ctx context.Context, instanceAPI *instance.API, zone scw.Zone, volumeID string, sizeInGB int, timeout time.Duration) error {
+	_, err := instanceAPI.WaitForVolume(&instance.WaitForVolumeRequest{
+		VolumeID: volumeID,
+		Zone:     zone,
+		Timeout:  scw.TimeDurationPtr(timeout),
+	}, scw.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+
+	volumeSizeInBytes := scw.Size(uint64(sizeInGB) * gb)
+	_, err = instanceAPI.UpdateVolume(&instance.UpdateVolumeRequest{
+		VolumeID: volumeID,
+		Zone:     zone,
+		Size:     &volumeSizeInBytes,
+	}, scw.WithContext(ctx))
+	if err != nil {
+		return fmt.Errorf("couldn't resize volume: %w", err)
+	}
+	return nil
+}
+
+// updateInstanceServerType changes the commercial type of a stopped server.
+// UpdateServerRequest of the SDK does not handle the commercial type yet, so the request is sent with the client of meta.
+func updateInstanceServerType(ctx context.Context, m interface{}, zone scw.Zone, serverID string, commercialType string) error {
+	req := &scw.ScalewayRequest{
+		Method:  http.MethodPatch,
+		Path:    "/instance/v1/zones/" + zone.String() + "/servers/" + serverID,
+		Headers: http.Header{},
+	}
+	err := req.SetBody(map[string]string{"commercial_type": commercialType})
+	if err != nil {
+		return err
+	}
+
+	err = m.(*Meta).scwClient.Do(req, &instance.UpdateServerResponse{}, scw.WithContext(ctx))
+	if err != nil {
+		return fmt.Errorf("couldn't change server type to %s: %w", commercialType, err)
+	}
+	return nil
+}
+
+// instanceServerTypesCompatible returns whether a server can move from one type to the other in place:
+// both types must have the same architecture and boot on the same kind of volume.
+func instanceServerTypesCompatible(from *instance.ServerType, to *instance.ServerType) bool {
+	return from.Arch == to.Arch && isInstanceServerTypeBootOnBlock(from) == isInstanceServerTypeBootOnBlock(to)
+}
+
+// isInstanceServerTypeBootOnBlock returns true when the servers of the given type boot on a block volume.
+func isInstanceServerTypeBootOnBlock(serverType *instance.ServerType) bool {
+	return serverType.VolumesConstraint == nil || serverType.VolumesConstraint.MaxSize == 0
+}
+
+// expandInstanceServerPrivateNetworkIDs returns the IDs of the private networks of the private_network blocks of a server.
+func expandInstanceServerPrivateNetworkIDs(raw interface{}) []string {
+	privateNetworkIDs := []string(nil)
+	for _, privateNetwork := range raw.([]interface{}) {
+		privateNetworkIDs = append(privateNetworkIDs, expandID(privateNetwork.(map[string]interface{})["private_network_id"]))
+	}
+	return privateNetworkIDs
+}
+
+// flattenInstanceServerPrivateNICs returns the private_network blocks of the given private networks, in their order.
+// Only the networks of the current blocks are flattened, the other NICs of the server are left to scaleway_instance_private_nic.
+// A network without a private NIC anymore is removed.
+func flattenInstanceServerPrivateNICs(zone scw.Zone, privateNICs []*instance.PrivateNIC, current interface{}) []map[string]interface{} {
+	privateNICsByNetwork := make(map[string]*instance.PrivateNIC, len(privateNICs))
+	for _, privateNIC := range privateNICs {
+		if _, exist := privateNICsByNetwork[privateNIC.PrivateNetworkID]; !exist {
+			privateNICsByNetwork[privateNIC.PrivateNetworkID] = privateNIC
+		}
+	}
+
+	privateNetworks := []map[string]interface{}(nil)
+	for _, privateNetworkID := range expandInstanceServerPrivateNetworkIDs(current) {
+		privateNIC, exist := privateNICsByNetwork[privateNetworkID]
+		if !exist {
+			continue
+		}
+		privateNetworks = append(privateNetworks, map[string]interface{}{
+			"private_network_id": newZonedIDString(zone, privateNIC.PrivateNetworkID),
+			"mac_address":        privateNIC.MacAddress,
+		})
+		delete(privateNICsByNetwork, privateNetworkID)
+	}
+	return privateNetworks
+}
+
+// updateInstanceServerPrivateNICs moves a server from the private networks it was attached to inline to the new ones,
+// deleting the private NICs of the removed networks and creating the missing ones.
+// The NICs of the networks that were not attached inline, such as the ones of scaleway_instance_private_nic, are left untouched.
+func updateInstanceServerPrivateNICs(ctx context.Context, instanceAPI *instance.API, zone scw.Zone, serverID string, oldPrivateNetworkIDs []string, privateNetworkIDs []string) error {
+	res, err := instanceAPI.ListPrivateNICs(&instance.ListPrivateNICsRequest{
+		Zone:     zone,
+		ServerID: serverID,
+	}, scw.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+
+	wanted := make(map[string]bool, len(privateNetworkIDs))
+	for _, privateNetworkID := range privateNetworkIDs {
+		wanted[privateNetworkID] = true
+	}
+	removed := make(map[string]bool, len(oldPrivateNetworkIDs))
+	for _, privateNetworkID := range oldPrivateNetworkIDs {
+		removed[privateNetworkID] = !wanted[privateNetworkID]
+	}
+	attached := make(map[string]bool, len(res.PrivateNics))
+	for _, privateNIC := range res.PrivateNics {
+		if !removed[privateNIC.PrivateNetworkID] {
+			attached[privateNIC.PrivateNetworkID] = true
+			continue
+		}
+		err = instanceAPI.DeletePrivateNIC(&instance.DeletePrivateNICRequest{
+			Zone:         zone,
+			ServerID:     serverID,
+			PrivateNicID: privateNIC.ID,
+		}, scw.WithContext(ctx))
+		if err != nil && !is404Error(err) {
+			return fmt.Errorf("couldn't detach private network %s: %w", privateNIC.PrivateNetworkID, err)
+		}
+	}
+
+	for _, privateNetworkID := range privateNetworkIDs {
+		if attached[privateNetworkID] {
+			continue
+		}
+		_, err = instanceAPI.CreatePrivateNIC(&instance.CreatePrivateNICRequest{
+			Zone:             zone,
+			ServerID:         serverID,
+			PrivateNetworkID: privateNetworkID,
+		}, scw.WithContext(ctx))
+		if err != nil {
+			return fmt.Errorf("couldn't attach private network %s: %w", privateNetworkID, err)
+		}
+		attached[privateNetworkID] = true
+	}
+
+	return nil
+}
+
+// instanceServerTypes returns the server types available in a zone indexed by commercial type.
+// The result comes from the catalog cache of meta.
+func instanceServerTypes(ctx context.Context, m interface{}, zone scw.Zone) (map[string]*instance.ServerType, error) {
+	meta := m.(*Meta)
+	serverTypes, err := meta.catalogCache.get("instance/server-types/"+zone.String(), func() (interface{}, error) {
+		res, err := instance.NewAPI(meta.scwClient).ListServersTypes(&instance.ListServersTypesRequest{
+			Zone: zone,
+		}, scw.WithAllPages(), scw.WithContext(ctx))
+		if err != nil {
+			return nil, err
+		}
+		return res.Servers, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return serverTypes.(map[string]*instance.ServerType), nil
+}
+
 // getServerType is a util to get a instance.ServerType by its commercialType
-func getServerType(apiInstance *instance.API, zone scw.Zone, commercialType string) *instance.ServerType {
+func getServerType(ctx context.Context, m interface{}, zone scw.Zone, commercialType string) *instance.ServerType {
 	serverType := (*instance.ServerType)(nil)
 
-	serverTypesRes, err := apiInstance.ListServersTypes(&instance.ListServersTypesRequest{
-		Zone: zone,
-	})
+	serverTypes, err := instanceServerTypes(ctx, m, zone)
 	if err != nil {
 		l.Warningf("cannot get server types: %s", err)
 	} else {
-		serverType = serverTypesRes.Servers[commercialType]
+		serverType = serverTypes[commercialType]
 		if serverType == nil {
 			l.Warningf("unrecognized server type: %s", commercialType)
 		}
@@ -225,3 +389,165 @@ func sanitizeVolumeMap(serverName string, volumes map[string]*instance.VolumeTem
 
 	return m
 }
+
+// instanceServerIDsByName returns the zoned IDs of the servers with the given zoned name.
+func instanceServerIDsByName(ctx context.Context, m interface{}, zonedName string) ([]string, error) {
+	instanceAPI, zone, name, err := instanceAPIWithZoneAndID(m, zonedName)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := instanceAPI.ListServers(&instance.ListServersRequest{
+		Zone: zone,
+		Name: scw.StringPtr(name),
+	}, scw.WithAllPages(), scw.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	ids := []string(nil)
+	for _, server := range res.Servers {
+		if server.Name == name {
+			ids = append(ids, newZonedIDString(zone, server.ID))
+		}
+	}
+	return ids, nil
+}
+
+// instanceSecurityGroupIDsByName returns the zoned IDs of the security groups with the given zoned name.
+func instanceSecurityGroupIDsByName(ctx context.Context, m interface{}, zonedName string) ([]string, error) {
+	instanceAPI, zone, name, err := instanceAPIWithZoneAndID(m, zonedName)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := instanceAPI.ListSecurityGroups(&instance.ListSecurityGroupsRequest{
+		Zone: zone,
+		Name: scw.StringPtr(name),
+	}, scw.WithAllPages(), scw.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	ids := []string(nil)
+	for _, securityGroup := range res.SecurityGroups {
+		if securityGroup.Name == name {
+			ids = append(ids, newZonedIDString(zone, securityGroup.ID))
+		}
+	}
+	return ids, nil
+}
+
+// instanceVolumeIDsByName returns the zoned IDs of the volumes with the given zoned name.
+func instanceVolumeIDsByName(ctx context.Context, m interface{}, zonedName string) ([]string, error) {
+	instanceAPI, zone, name, err := instanceAPIWithZoneAndID(m, zonedName)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := instanceAPI.ListVolumes(&instance.ListVolumesRequest{
+		Zone: zone,
+		Name: scw.StringPtr(name),
+	}, scw.WithAllPages(), scw.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	ids := []string(nil)
+	for _, volume := range res.Volumes {
+		if volume.Name == name {
+			ids = append(ids, newZonedIDString(zone, volume.ID))
+		}
+	}
+	return ids, nil
+}
+
+// instanceSnapshotIDsByName returns the zoned IDs of the snapshots named after the ID part of zonedName.
+func instanceSnapshotIDsByName(ctx context.Context, m interface{}, zonedName string) ([]string, error) {
+	instanceAPI, zone, name, err := instanceAPIWithZoneAndID(m, zonedName)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := instanceAPI.ListSnapshots(&instance.ListSnapshotsRequest{
+		Zone: zone,
+		Name: scw.StringPtr(name),
+	}, scw.WithAllPages(), scw.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	ids := []string(nil)
+	for _, snapshot := range res.Snapshots {
+		if snapshot.Name == name {
+			ids = append(ids, newZonedIDString(zone, snapshot.ID))
+		}
+	}
+	return ids, nil
+}
+
+// waitForInstanceSnapshot waits for a snapshot to be available or in error.
+func waitForInstanceSnapshot(ctx context.Context, instanceAPI *instance.API, zone scw.Zone, id string, timeout time.Duration) (*instance.Snapshot, error) {
+	return instanceAPI.WaitForSnapshot(&instance.WaitForSnapshotRequest{
+		SnapshotID: id,
+		Zone:       zone,
+		Timeout:    scw.TimeDurationPtr(timeout),
+	}, scw.WithContext(ctx))
+}
+
+// instanceImageIDsByName returns the zoned IDs of the images named after the ID part of zonedName.
+func instanceImageIDsByName(ctx context.Context, m interface{}, zonedName string) ([]string, error) {
+	instanceAPI, zone, name, err := instanceAPIWithZoneAndID(m, zonedName)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := instanceAPI.ListImages(&instance.ListImagesRequest{
+		Zone: zone,
+		Name: scw.StringPtr(name),
+	}, scw.WithAllPages(), scw.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	ids := []string(nil)
+	for _, image := range res.Images {
+		if image.Name == name {
+			ids = append(ids, newZonedIDString(zone, image.ID))
+		}
+	}
+	return ids, nil
+}
+
+// waitForInstanceImage waits for an image to be available or in error.
+func waitForInstanceImage(ctx context.Context, instanceAPI *instance.API, zone scw.Zone, id string, timeout time.Duration) (*instance.Image, error) {
+	return instanceAPI.WaitForImage(&instance.WaitForImageRequest{
+		ImageID: id,
+		Zone:    zone,
+		Timeout: scw.TimeDurationPtr(timeout),
+	}, scw.WithContext(ctx))
+}
+
+// instanceServerTypeNames returns the commercial types available in the zone of the server being planned.
+func instanceServerTypeNames(ctx context.Context, diff *schema.ResourceDiff, m interface{}) ([]string, error) {
+	zone, err := extractZone(diff, m.(*Meta))
+	if err != nil {
+		return nil, err
+	}
+	return listInstanceServerTypeNames(ctx, m, zone)
+}
+
+// listInstanceServerTypeNames returns the sorted commercial types available in a zone.
+func listInstanceServerTypeNames(ctx context.Context, m interface{}, zone scw.Zone) ([]string, error) {
+	serverTypes, err := instanceServerTypes(ctx, m, zone)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(serverTypes))
+	for name := range serverTypes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names, nil
+}
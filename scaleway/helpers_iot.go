@@ -1,13 +1,22 @@
 package scaleway
 
 import (
+	"context"
 	"fmt"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	iot "github.com/scaleway/scaleway-sdk-go/api/iot/v1"
 	"github.com/scaleway/scaleway-sdk-go/scw"
 )
 
+const (
+	defaultIotHubTimeout     = 20 * time.Minute
+	defaultIotDeviceTimeout  = 5 * time.Minute
+	defaultIotRouteTimeout   = 5 * time.Minute
+	defaultIotNetworkTimeout = 5 * time.Minute
+)
+
 func iotAPIWithRegion(d *schema.ResourceData, m interface{}) (*iot.API, scw.Region, error) {
 	meta := m.(*Meta)
 	iotAPI := iot.NewAPI(meta.scwClient)
@@ -25,11 +34,12 @@ func iotAPIWithRegionAndID(m interface{}, id string) (*iot.API, scw.Region, stri
 	return iotAPI, region, ID, err
 }
 
-func waitIotHub(iotAPI *iot.API, region scw.Region, hubID string, desiredStates ...iot.HubStatus) error {
+func waitIotHub(ctx context.Context, iotAPI *iot.API, region scw.Region, hubID string, timeout time.Duration, desiredStates ...iot.HubStatus) error {
 	hub, err := iotAPI.WaitForHub(&iot.WaitForHubRequest{
-		HubID:  hubID,
-		Region: region,
-	})
+		HubID:   hubID,
+		Region:  region,
+		Timeout: scw.TimeDurationPtr(timeout),
+	}, scw.WithContext(ctx))
 	if err != nil {
 		return err
 	}
@@ -38,11 +38,8 @@ type KubeconfigStruct struct {
 }
 
 const (
-	defaultK8SClusterTimeout             = 10 * time.Minute
-	defaultK8SPoolTimeout                = 10 * time.Minute
-	K8SClusterWaitForPoolRequiredTimeout = 10 * time.Minute
-	K8SClusterWaitForDeletedTimeout      = 10 * time.Minute
-	K8SPoolWaitForReadyTimeout           = 10 * time.Minute
+	defaultK8SClusterTimeout = 10 * time.Minute
+	defaultK8SPoolTimeout    = 10 * time.Minute
 )
 
 func k8sAPIWithRegion(d *schema.ResourceData, m interface{}) (*k8s.API, scw.Region, error) {
@@ -76,21 +73,38 @@ func k8sGetMinorVersionFromFull(version string) (string, error) {
 	return versionSplit[0] + "." + versionSplit[1], nil
 }
 
+// k8sVersions returns the kubernetes versions available in a region.
+// The result comes from the catalog cache of meta.
+func k8sVersions(ctx context.Context, m interface{}, region scw.Region) ([]*k8s.Version, error) {
+	meta := m.(*Meta)
+	versions, err := meta.catalogCache.get("k8s/versions/"+region.String(), func() (interface{}, error) {
+		res, err := k8s.NewAPI(meta.scwClient).ListVersions(&k8s.ListVersionsRequest{
+			Region: region,
+		}, scw.WithContext(ctx))
+		if err != nil {
+			return nil, err
+		}
+		return res.Versions, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return versions.([]*k8s.Version), nil
+}
+
 // k8sGetLatestVersionFromMinor returns the latest full version (x.y.z) for a given minor version (x.y)
-func k8sGetLatestVersionFromMinor(ctx context.Context, k8sAPI *k8s.API, region scw.Region, version string) (string, error) {
+func k8sGetLatestVersionFromMinor(ctx context.Context, m interface{}, region scw.Region, version string) (string, error) {
 	versionSplit := strings.Split(version, ".")
 	if len(versionSplit) != 2 {
 		return "", fmt.Errorf("minor version should be like x.y not %s", version)
 	}
 
-	versionsResp, err := k8sAPI.ListVersions(&k8s.ListVersionsRequest{
-		Region: region,
-	}, scw.WithContext(ctx))
+	versions, err := k8sVersions(ctx, m, region)
 	if err != nil {
 		return "", err
 	}
 
-	for _, v := range versionsResp.Versions {
+	for _, v := range versions {
 		vSplit := strings.Split(v.Name, ".")
 		if len(vSplit) != 3 {
 			return "", fmt.Errorf("upstream version %s is not correctly formatted", v.Name) // should never happen
@@ -102,11 +116,11 @@ func k8sGetLatestVersionFromMinor(ctx context.Context, k8sAPI *k8s.API, region s
 	return "", fmt.Errorf("no available upstream version found for %s", version)
 }
 
-func waitK8SCluster(ctx context.Context, k8sAPI *k8s.API, region scw.Region, clusterID string, desiredStates ...k8s.ClusterStatus) error {
+func waitK8SCluster(ctx context.Context, k8sAPI *k8s.API, region scw.Region, clusterID string, timeout time.Duration, desiredStates ...k8s.ClusterStatus) error {
 	cluster, err := k8sAPI.WaitForCluster(&k8s.WaitForClusterRequest{
 		ClusterID: clusterID,
 		Region:    region,
-		Timeout:   scw.TimeDurationPtr(K8SClusterWaitForPoolRequiredTimeout),
+		Timeout:   scw.TimeDurationPtr(timeout),
 	}, scw.WithContext(ctx))
 	if err != nil {
 		return err
@@ -121,11 +135,11 @@ func waitK8SCluster(ctx context.Context, k8sAPI *k8s.API, region scw.Region, clu
 	return fmt.Errorf("cluster %s has state %s, wants one of %+q", clusterID, cluster.Status, desiredStates)
 }
 
-func waitK8SClusterDeleted(ctx context.Context, k8sAPI *k8s.API, region scw.Region, clusterID string) error {
+func waitK8SClusterDeleted(ctx context.Context, k8sAPI *k8s.API, region scw.Region, clusterID string, timeout time.Duration) error {
 	cluster, err := k8sAPI.WaitForCluster(&k8s.WaitForClusterRequest{
 		ClusterID: clusterID,
 		Region:    region,
-		Timeout:   scw.TimeDurationPtr(K8SClusterWaitForDeletedTimeout),
+		Timeout:   scw.TimeDurationPtr(timeout),
 	}, scw.WithContext(ctx))
 	if err != nil {
 		if is404Error(err) {
@@ -137,11 +151,11 @@ func waitK8SClusterDeleted(ctx context.Context, k8sAPI *k8s.API, region scw.Regi
 	return fmt.Errorf("cluster %s has state %s, wants %s", clusterID, cluster.Status, k8s.ClusterStatusDeleted)
 }
 
-func waitK8SPoolReady(ctx context.Context, k8sAPI *k8s.API, region scw.Region, poolID string) error {
+func waitK8SPoolReady(ctx context.Context, k8sAPI *k8s.API, region scw.Region, poolID string, timeout time.Duration) error {
 	pool, err := k8sAPI.WaitForPool(&k8s.WaitForPoolRequest{
 		PoolID:  poolID,
 		Region:  region,
-		Timeout: scw.TimeDurationPtr(K8SPoolWaitForReadyTimeout),
+		Timeout: scw.TimeDurationPtr(timeout),
 	}, scw.WithContext(ctx))
 
 	if err != nil {
@@ -262,3 +276,87 @@ func flattenKubeletArgs(args map[string]string) map[string]interface{} {
 
 	return kubeletArgs
 }
+
+// k8sClusterIDsByName returns the regional IDs of the clusters with the given regional name.
+func k8sClusterIDsByName(ctx context.Context, m interface{}, regionalName string) ([]string, error) {
+	k8sAPI, region, name, err := k8sAPIWithRegionAndID(m, regionalName)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := k8sAPI.ListClusters(&k8s.ListClustersRequest{
+		Region: region,
+		Name:   scw.StringPtr(name),
+	}, scw.WithAllPages(), scw.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	ids := []string(nil)
+	for _, cluster := range res.Clusters {
+		if cluster.Name == name {
+			ids = append(ids, newRegionalIDString(region, cluster.ID))
+		}
+	}
+	return ids, nil
+}
+
+// k8sPoolIDsByName returns the regional IDs of the pools with the given regional name in all the clusters of the region.
+func k8sPoolIDsByName(ctx context.Context, m interface{}, regionalName string) ([]string, error) {
+	k8sAPI, region, name, err := k8sAPIWithRegionAndID(m, regionalName)
+	if err != nil {
+		return nil, err
+	}
+
+	clusters, err := k8sAPI.ListClusters(&k8s.ListClustersRequest{
+		Region: region,
+	}, scw.WithAllPages(), scw.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	ids := []string(nil)
+	for _, cluster := range clusters.Clusters {
+		pools, err := k8sAPI.ListPools(&k8s.ListPoolsRequest{
+			Region:    region,
+			ClusterID: cluster.ID,
+			Name:      scw.StringPtr(name),
+		}, scw.WithAllPages(), scw.WithContext(ctx))
+		if err != nil {
+			return nil, err
+		}
+		for _, pool := range pools.Pools {
+			if pool.Name == name {
+				ids = append(ids, newRegionalIDString(region, pool.ID))
+			}
+		}
+	}
+	return ids, nil
+}
+
+// k8sPoolNodeTypeNames returns the node types available for the pool being planned.
+// Pool nodes are instances so the instance server types of the pool zone are used,
+// falling back to the first zone of the pool region when no zone is set.
+func k8sPoolNodeTypeNames(ctx context.Context, diff *schema.ResourceDiff, m interface{}) ([]string, error) {
+	meta := m.(*Meta)
+
+	zone := scw.Zone(strings.ToLower(diff.Get("zone").(string)))
+	if zone == "" {
+		region, err := extractRegion(diff, meta)
+		if err != nil {
+			return nil, err
+		}
+		zones := meta.localities.regionZones(region)
+		if len(zones) == 0 {
+			return nil, fmt.Errorf("no zone found in region %s", region)
+		}
+		zone = zones[0]
+	}
+
+	return listInstanceServerTypeNames(ctx, meta, zone)
+}
+
+// k8sNormalizeNodeType normalizes a node type the way the API does, e.g. DEV1-M and dev1_m are equivalent.
+func k8sNormalizeNodeType(nodeType string) string {
+	return strings.Replace(strings.ToLower(nodeType), "-", "_", -1)
+}
@@ -1,6 +1,7 @@
 package scaleway
 
 import (
+	"context"
 	"strings"
 	"time"
 
@@ -10,7 +11,6 @@ import (
 )
 
 const (
-	LbWaitForTimeout   = 10 * time.Minute
 	defaultLbLbTimeout = 10 * time.Minute
 )
 
@@ -259,3 +259,71 @@ func expandLbProxyProtocol(raw interface{}) lb.ProxyProtocol {
 func flattenLbProxyProtocol(pp lb.ProxyProtocol) interface{} {
 	return strings.TrimPrefix(pp.String(), "proxy_protocol_")
 }
+
+// lbIDsByName returns the regional IDs of the load balancers with the given regional name.
+func lbIDsByName(ctx context.Context, m interface{}, regionalName string) ([]string, error) {
+	lbAPI, region, name, err := lbAPIWithRegionAndID(m, regionalName)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := lbAPI.ListLBs(&lb.ListLBsRequest{
+		Region: region,
+		Name:   scw.StringPtr(name),
+	}, scw.WithAllPages(), scw.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	ids := []string(nil)
+	for _, loadBalancer := range res.LBs {
+		if loadBalancer.Name == name {
+			ids = append(ids, newRegionalIDString(region, loadBalancer.ID))
+		}
+	}
+	return ids, nil
+}
+
+// lbTypes returns the load balancer types of a region.
+// The result comes from the catalog cache of meta.
+func lbTypes(ctx context.Context, m interface{}, region scw.Region) ([]*lb.LBType, error) {
+	meta := m.(*Meta)
+	types, err := meta.catalogCache.get("lb/lb-types/"+region.String(), func() (interface{}, error) {
+		res, err := lbAPI(meta).ListLBTypes(&lb.ListLBTypesRequest{
+			Region: region,
+		}, scw.WithAllPages(), scw.WithContext(ctx))
+		if err != nil {
+			return nil, err
+		}
+		return res.LBTypes, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return types.([]*lb.LBType), nil
+}
+
+// lbTypeNames returns the load balancer types available in the region of the load balancer being planned.
+func lbTypeNames(ctx context.Context, diff *schema.ResourceDiff, m interface{}) ([]string, error) {
+	meta := m.(*Meta)
+	region, err := extractRegion(diff, meta)
+	if err != nil {
+		return nil, err
+	}
+
+	lbTypes, err := lbTypes(ctx, meta, region)
+	if err != nil {
+		return nil, err
+	}
+
+	// Types are listed once per zone of the region.
+	seen := map[string]bool{}
+	names := []string(nil)
+	for _, lbType := range lbTypes {
+		if !seen[lbType.Name] {
+			seen[lbType.Name] = true
+			names = append(names, lbType.Name)
+		}
+	}
+	return names, nil
+}
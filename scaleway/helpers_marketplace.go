@@ -1,19 +1,25 @@
 package scaleway
 
 import (
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"context"
+
 	"github.com/scaleway/scaleway-sdk-go/api/marketplace/v1"
 	"github.com/scaleway/scaleway-sdk-go/scw"
 )
 
-// marketplaceAPIWithZone returns a new marketplace API and the zone for a Create request
-func marketplaceAPIWithZone(d *schema.ResourceData, m interface{}) (*marketplace.API, scw.Zone, error) {
+// marketplaceLocalImageIDByLabel returns the ID of the local image of a marketplace image for a commercial type.
+// The result comes from the catalog cache of meta.
+func marketplaceLocalImageIDByLabel(ctx context.Context, m interface{}, zone scw.Zone, commercialType string, label string) (string, error) {
 	meta := m.(*Meta)
-	marketplaceAPI := marketplace.NewAPI(meta.scwClient)
-
-	zone, err := extractZone(d, meta)
+	imageID, err := meta.catalogCache.get("marketplace/local-images/"+zone.String()+"/"+commercialType+"/"+label, func() (interface{}, error) {
+		return marketplace.NewAPI(meta.scwClient).GetLocalImageIDByLabel(&marketplace.GetLocalImageIDByLabelRequest{
+			ImageLabel:     label,
+			CommercialType: commercialType,
+			Zone:           zone,
+		}, scw.WithContext(ctx))
+	})
 	if err != nil {
-		return nil, "", err
+		return "", err
 	}
-	return marketplaceAPI, zone, nil
+	return imageID.(string), nil
 }
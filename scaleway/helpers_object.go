@@ -1,10 +1,12 @@
 package scaleway
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"net/http"
 	"os"
+	"reflect"
 	"strings"
 	"time"
 
@@ -15,21 +17,29 @@ import (
 	"github.com/aws/aws-sdk-go/service/s3"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/scaleway/scaleway-sdk-go/scw"
+	sdkValidation "github.com/scaleway/scaleway-sdk-go/validation"
 )
 
 const (
 	defaultObjectBucketTimeout = 10 * time.Minute
+
+	s3AccessKeyEnv = "SCW_S3_ACCESS_KEY"
+	s3SecretKeyEnv = "SCW_S3_SECRET_KEY"
 )
 
-func newS3Client(httpClient *http.Client, region, accessKey, secretKey string) (*s3.S3, error) {
+func newS3Client(httpClient *http.Client, endpoints *endpointsConfig, region, accessKey, secretKey string) (*s3.S3, error) {
+	// Mark the requests as object storage ones, a custom endpoint may not look like Scaleway's.
+	s3HTTPClient := *httpClient
+	s3HTTPClient.Transport = &productTransport{transport: httpClientTransport(httpClient), product: "s3"}
+
 	config := &aws.Config{}
 	config.WithRegion(region)
 	config.WithCredentials(credentials.NewStaticCredentials(accessKey, secretKey, ""))
-	config.WithEndpoint("https://s3." + region + ".scw.cloud")
-	config.WithHTTPClient(httpClient)
-	if strings.ToLower(os.Getenv("TF_LOG")) == "debug" {
-		config.WithLogLevel(aws.LogDebugWithHTTPBody)
-	}
+	config.WithEndpoint(endpoints.s3Endpoint(region))
+	config.WithS3ForcePathStyle(endpoints.forcePathStyle())
+	config.WithHTTPClient(&s3HTTPClient)
+	// Retries are handled by the retryable transport of the http client.
+	config.WithMaxRetries(0)
 
 	s, err := session.NewSession(config)
 	if err != nil {
@@ -40,9 +50,7 @@ func newS3Client(httpClient *http.Client, region, accessKey, secretKey string) (
 
 func newS3ClientFromMeta(meta *Meta) (*s3.S3, error) {
 	region, _ := meta.scwClient.GetDefaultRegion()
-	accessKey, _ := meta.scwClient.GetAccessKey()
-	secretKey, _ := meta.scwClient.GetSecretKey()
-	return newS3Client(meta.httpClient, region.String(), accessKey, secretKey)
+	return meta.s3Clients.get(meta, region.String(), "")
 }
 
 func s3ClientWithRegion(d *schema.ResourceData, m interface{}) (*s3.S3, scw.Region, error) {
@@ -52,10 +60,7 @@ func s3ClientWithRegion(d *schema.ResourceData, m interface{}) (*s3.S3, scw.Regi
 		return nil, "", err
 	}
 
-	accessKey, _ := meta.scwClient.GetAccessKey()
-	secretKey, _ := meta.scwClient.GetSecretKey()
-
-	s3Client, err := newS3Client(meta.httpClient, region.String(), accessKey, secretKey)
+	s3Client, err := meta.s3Clients.get(meta, region.String(), d.Get("project_id").(string))
 	if err != nil {
 		return nil, "", err
 	}
@@ -63,21 +68,84 @@ func s3ClientWithRegion(d *schema.ResourceData, m interface{}) (*s3.S3, scw.Regi
 	return s3Client, region, err
 }
 
-func s3ClientWithRegionAndName(m interface{}, name string) (*s3.S3, scw.Region, string, error) {
+func s3ClientWithRegionAndName(d *schema.ResourceData, m interface{}, name string) (*s3.S3, scw.Region, string, error) {
 	meta := m.(*Meta)
 	region, name, err := parseRegionalID(name)
 	if err != nil {
 		return nil, "", name, err
 	}
-	accessKey, _ := meta.scwClient.GetAccessKey()
-	secretKey, _ := meta.scwClient.GetSecretKey()
-	s3Client, err := newS3Client(meta.httpClient, region.String(), accessKey, secretKey)
+	s3Client, err := meta.s3Clients.get(meta, region.String(), d.Get("project_id").(string))
 	if err != nil {
 		return nil, "", "", err
 	}
 	return s3Client, region, name, err
 }
 
+// importObjectBucket imports a bucket from its {region}/{bucketName} ID. The buckets of another project than the default
+// one of the object storage access key are imported with {region}/{bucketName}@{projectID}.
+func importObjectBucket(_ context.Context, d *schema.ResourceData, _ interface{}) ([]*schema.ResourceData, error) {
+	id, projectID := d.Id(), ""
+	if i := strings.LastIndex(id, "@"); i >= 0 {
+		id, projectID = id[:i], id[i+1:]
+		if !sdkValidation.IsUUID(projectID) {
+			return nil, fmt.Errorf("cannot import bucket %q, expected {region}/{bucketName} or {region}/{bucketName}@{projectID}", d.Id())
+		}
+		_ = d.Set("project_id", projectID)
+	}
+	if _, _, err := parseRegionalID(id); err != nil {
+		return nil, fmt.Errorf("cannot import bucket %q, expected {region}/{bucketName} or {region}/{bucketName}@{projectID}", d.Id())
+	}
+	d.SetId(id)
+	return []*schema.ResourceData{d}, nil
+}
+
+// s3AccessKeyWithProjectID returns an access key managing the buckets of a project
+// instead of the ones of the default project of the key.
+func s3AccessKeyWithProjectID(accessKey, projectID string) string {
+	if projectID == "" {
+		return accessKey
+	}
+	return accessKey + "@" + projectID
+}
+
+// s3Credentials are the keys used by object storage clients, which may differ from the ones of the other APIs.
+type s3Credentials struct {
+	accessKey string
+	secretKey string
+}
+
+// loadS3Credentials returns the object storage keys. The SCW_S3_ACCESS_KEY and SCW_S3_SECRET_KEY environment variables
+// take precedence over the s3_access_key and s3_secret_key provider attributes, the keys of the Scaleway client are used
+// when none of them is set.
+func loadS3Credentials(d *schema.ResourceData, scwClient *scw.Client) (*s3Credentials, error) {
+	credentials := &s3Credentials{}
+	source := "environment variables"
+	credentials.accessKey, credentials.secretKey = os.Getenv(s3AccessKeyEnv), os.Getenv(s3SecretKeyEnv)
+	if credentials.accessKey == "" && credentials.secretKey == "" && d != nil {
+		credentials.accessKey, credentials.secretKey = d.Get("s3_access_key").(string), d.Get("s3_secret_key").(string)
+		source = "provider attributes"
+	}
+
+	if credentials.accessKey == "" && credentials.secretKey == "" {
+		credentials.accessKey, _ = scwClient.GetAccessKey()
+		credentials.secretKey, _ = scwClient.GetSecretKey()
+		return credentials, nil
+	}
+
+	switch {
+	case credentials.accessKey == "":
+		return nil, fmt.Errorf("s3_access_key is missing while s3_secret_key is set (from %s)", source)
+	case credentials.secretKey == "":
+		return nil, fmt.Errorf("s3_secret_key is missing while s3_access_key is set (from %s)", source)
+	case !sdkValidation.IsAccessKey(credentials.accessKey):
+		return nil, fmt.Errorf("s3_access_key (from %s) %q is not a valid access key, expected SCWXXXXXXXXXXXXXXXXX format", source, credentials.accessKey)
+	case !sdkValidation.IsSecretKey(credentials.secretKey):
+		return nil, fmt.Errorf("s3_secret_key (from %s) is not a valid secret key, expected a UUID: xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxxx", source)
+	}
+	l.Debugf("object storage uses the keys of the %s", source)
+	return credentials, nil
+}
+
 func flattenObjectBucketTags(tagsSet []*s3.Tag) map[string]interface{} {
 	tags := map[string]interface{}{}
 
@@ -96,9 +164,42 @@ func flattenObjectBucketTags(tagsSet []*s3.Tag) map[string]interface{} {
 	return tags
 }
 
-func expandObjectBucketTags(tags interface{}) []*s3.Tag {
+// flattenObjectBucketTagsWithoutDefaults flattens the bucket tags and removes the provider
+// default tags that are not overridden by the tags of the resource.
+func flattenObjectBucketTagsWithoutDefaults(tagsSet []*s3.Tag, defaultTags []string, resourceTags interface{}) map[string]interface{} {
+	tags := flattenObjectBucketTags(tagsSet)
+	rawResourceTags, _ := resourceTags.(map[string]interface{})
+
+	for _, defaultTag := range defaultTags {
+		key, value := splitTag(defaultTag)
+		if _, isResourceTag := rawResourceTags[key]; isResourceTag {
+			continue
+		}
+		if tags[key] == value {
+			delete(tags, key)
+		}
+	}
+
+	return tags
+}
+
+// expandObjectBucketTags returns the bucket tags merged with the provider default tags.
+// Default tags are `key:value` strings, a bucket tag overrides the default tag sharing the same key.
+func expandObjectBucketTags(tags interface{}, defaultTags []string) []*s3.Tag {
 	tagsSet := []*s3.Tag(nil)
-	for key, value := range tags.(map[string]interface{}) {
+	rawTags := tags.(map[string]interface{})
+	for key, value := range rawTags {
+		tagsSet = append(tagsSet, &s3.Tag{
+			Key:   scw.StringPtr(key),
+			Value: expandStringPtr(value),
+		})
+	}
+
+	for _, defaultTag := range defaultTags {
+		key, value := splitTag(defaultTag)
+		if _, isOverridden := rawTags[key]; isOverridden {
+			continue
+		}
 		tagsSet = append(tagsSet, &s3.Tag{
 			Key:   scw.StringPtr(key),
 			Value: expandStringPtr(value),
@@ -108,6 +209,19 @@ func expandObjectBucketTags(tags interface{}) []*s3.Tag {
 	return tagsSet
 }
 
+// customizeDiffObjectBucketTagsAll plans tags_all to the bucket tags merged with the provider default tags.
+// tags_all holds the tags read from the API, so a change of the default tags updates the existing buckets.
+func customizeDiffObjectBucketTagsAll(_ context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+	if !diff.NewValueKnown("tags") {
+		return diff.SetNewComputed("tags_all")
+	}
+	tags := flattenObjectBucketTags(expandObjectBucketTags(diff.Get("tags"), meta.(*Meta).defaultTags))
+	if diff.Id() != "" && reflect.DeepEqual(tags, diff.Get("tags_all")) {
+		return nil
+	}
+	return diff.SetNew("tags_all", tags)
+}
+
 func objectBucketEndpointURL(bucketName string, region scw.Region) string {
 	return fmt.Sprintf("https://%s.s3.%s.scw.cloud", bucketName, region)
 }
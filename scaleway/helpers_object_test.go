@@ -1,18 +1,22 @@
 package scaleway
 
 import (
+	"os"
 	"testing"
 
 	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/scaleway/scaleway-sdk-go/scw"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestExpandObjectBucketTags(t *testing.T) {
 	tests := []struct {
-		name string
-		tags interface{}
-		want []*s3.Tag
+		name        string
+		tags        interface{}
+		defaultTags []string
+		want        []*s3.Tag
 	}{
 		{
 			name: "no tags",
@@ -41,10 +45,79 @@ func TestExpandObjectBucketTags(t *testing.T) {
 				{Key: scw.StringPtr("key3"), Value: scw.StringPtr("val3")},
 			},
 		},
+		{
+			name: "default tags",
+			tags: map[string]interface{}{
+				"key1": "val1",
+				"env":  "staging",
+			},
+			defaultTags: []string{"env:prod", "team:x"},
+			want: []*s3.Tag{
+				{Key: scw.StringPtr("key1"), Value: scw.StringPtr("val1")},
+				{Key: scw.StringPtr("env"), Value: scw.StringPtr("staging")},
+				{Key: scw.StringPtr("team"), Value: scw.StringPtr("x")},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			assert.ElementsMatch(t, tt.want, expandObjectBucketTags(tt.tags))
+			assert.ElementsMatch(t, tt.want, expandObjectBucketTags(tt.tags, tt.defaultTags))
 		})
 	}
 }
+
+func TestFlattenObjectBucketTagsWithoutDefaults(t *testing.T) {
+	tagsSet := []*s3.Tag{
+		{Key: scw.StringPtr("key1"), Value: scw.StringPtr("val1")},
+		{Key: scw.StringPtr("env"), Value: scw.StringPtr("prod")},
+		{Key: scw.StringPtr("team"), Value: scw.StringPtr("x")},
+	}
+
+	assert.Equal(t, map[string]interface{}{
+		"key1": "val1",
+		"env":  "prod",
+	}, flattenObjectBucketTagsWithoutDefaults(tagsSet, []string{"env:prod", "team:x"}, map[string]interface{}{
+		"key1": "val1",
+		"env":  "prod",
+	}))
+}
+
+func TestLoadS3Credentials(t *testing.T) {
+	defer setTestEnv(t, nil)()
+	scwClient, err := scw.NewClient(scw.WithAuth("SCWXXXXXXXXXXXXXXXXX", "11111111-1111-1111-1111-111111111111"))
+	require.NoError(t, err)
+	providerSchema := Provider(DefaultProviderConfig())().Schema
+
+	credentials, err := loadS3Credentials(schema.TestResourceDataRaw(t, providerSchema, nil), scwClient)
+	require.NoError(t, err)
+	assert.Equal(t, &s3Credentials{accessKey: "SCWXXXXXXXXXXXXXXXXX", secretKey: "11111111-1111-1111-1111-111111111111"}, credentials)
+
+	d := schema.TestResourceDataRaw(t, providerSchema, map[string]interface{}{
+		"s3_access_key": "SCWS3XXXXXXXXXXXXXXX",
+		"s3_secret_key": "22222222-2222-2222-2222-222222222222",
+	})
+	credentials, err = loadS3Credentials(d, scwClient)
+	require.NoError(t, err)
+	assert.Equal(t, &s3Credentials{accessKey: "SCWS3XXXXXXXXXXXXXXX", secretKey: "22222222-2222-2222-2222-222222222222"}, credentials)
+
+	_, err = loadS3Credentials(schema.TestResourceDataRaw(t, providerSchema, map[string]interface{}{"s3_access_key": "SCWS3XXXXXXXXXXXXXXX"}), scwClient)
+	assert.EqualError(t, err, "s3_secret_key is missing while s3_access_key is set (from provider attributes)")
+
+	// Environment variables take precedence over the provider attributes.
+	defer setTestEnv(t, map[string]string{
+		s3AccessKeyEnv: "SCWENVXXXXXXXXXXXXXX",
+		s3SecretKeyEnv: "33333333-3333-3333-3333-333333333333",
+	})()
+	credentials, err = loadS3Credentials(d, scwClient)
+	require.NoError(t, err)
+	assert.Equal(t, &s3Credentials{accessKey: "SCWENVXXXXXXXXXXXXXX", secretKey: "33333333-3333-3333-3333-333333333333"}, credentials)
+
+	require.NoError(t, os.Setenv(s3AccessKeyEnv, "invalid"))
+	_, err = loadS3Credentials(nil, scwClient)
+	assert.EqualError(t, err, `s3_access_key (from environment variables) "invalid" is not a valid access key, expected SCWXXXXXXXXXXXXXXXXX format`)
+}
+
+func TestS3AccessKeyWithProjectID(t *testing.T) {
+	assert.Equal(t, "SCWXXXXXXXXXXXXXXXXX", s3AccessKeyWithProjectID("SCWXXXXXXXXXXXXXXXXX", ""))
+	assert.Equal(t, "SCWXXXXXXXXXXXXXXXXX@11111111-1111-1111-1111-111111111111", s3AccessKeyWithProjectID("SCWXXXXXXXXXXXXXXXXX", "11111111-1111-1111-1111-111111111111"))
+}
@@ -1,6 +1,7 @@
 package scaleway
 
 import (
+	"context"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -10,6 +11,8 @@ import (
 
 const (
 	defaultRdbInstanceTimeout = 15 * time.Minute
+	// defaultRdbInstanceUpdateTimeout is longer as upgrades of the node type or the HA mode take time.
+	defaultRdbInstanceUpdateTimeout = 30 * time.Minute
 )
 
 // rdbAPIWithRegion returns a new lb API and the region for a Create request
@@ -69,3 +72,68 @@ func expandInstanceSettings(i interface{}) []*rdb.InstanceSetting {
 
 	return res
 }
+
+// rdbInstanceIDsByName returns the regional IDs of the database instances with the given regional name.
+func rdbInstanceIDsByName(ctx context.Context, m interface{}, regionalName string) ([]string, error) {
+	rdbAPI, region, name, err := rdbAPIWithRegionAndID(m, regionalName)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := rdbAPI.ListInstances(&rdb.ListInstancesRequest{
+		Region: region,
+		Name:   scw.StringPtr(name),
+	}, scw.WithAllPages(), scw.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	ids := []string(nil)
+	for _, instance := range res.Instances {
+		if instance.Name == name {
+			ids = append(ids, newRegionalIDString(region, instance.ID))
+		}
+	}
+	return ids, nil
+}
+
+// rdbNodeTypes returns the node types of a region, including disabled ones.
+// The result comes from the catalog cache of meta.
+func rdbNodeTypes(ctx context.Context, m interface{}, region scw.Region) ([]*rdb.NodeType, error) {
+	meta := m.(*Meta)
+	nodeTypes, err := meta.catalogCache.get("rdb/node-types/"+region.String(), func() (interface{}, error) {
+		res, err := rdb.NewAPI(meta.scwClient).ListNodeTypes(&rdb.ListNodeTypesRequest{
+			Region:               region,
+			IncludeDisabledTypes: true,
+		}, scw.WithAllPages(), scw.WithContext(ctx))
+		if err != nil {
+			return nil, err
+		}
+		return res.NodeTypes, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return nodeTypes.([]*rdb.NodeType), nil
+}
+
+// rdbNodeTypeNames returns the node types available in the region of the instance being planned.
+// Disabled node types are included as they are still valid, only temporarily unavailable.
+func rdbNodeTypeNames(ctx context.Context, diff *schema.ResourceDiff, m interface{}) ([]string, error) {
+	meta := m.(*Meta)
+	region, err := extractRegion(diff, meta)
+	if err != nil {
+		return nil, err
+	}
+
+	nodeTypes, err := rdbNodeTypes(ctx, meta, region)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(nodeTypes))
+	for _, nodeType := range nodeTypes {
+		names = append(names, nodeType.Name)
+	}
+	return names, nil
+}
@@ -1,6 +1,7 @@
 package scaleway
 
 import (
+	"context"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -35,3 +36,27 @@ func registryAPIWithRegionAndID(m interface{}, id string) (*registry.API, scw.Re
 	}
 	return api, region, id, nil
 }
+
+// registryNamespaceIDsByName returns the regional IDs of the namespaces with the given regional name.
+func registryNamespaceIDsByName(ctx context.Context, m interface{}, regionalName string) ([]string, error) {
+	registryAPI, region, name, err := registryAPIWithRegionAndID(m, regionalName)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := registryAPI.ListNamespaces(&registry.ListNamespacesRequest{
+		Region: region,
+		Name:   scw.StringPtr(name),
+	}, scw.WithAllPages(), scw.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	ids := []string(nil)
+	for _, namespace := range res.Namespaces {
+		if namespace.Name == name {
+			ids = append(ids, newRegionalIDString(region, namespace.ID))
+		}
+	}
+	return ids, nil
+}
@@ -1,6 +1,7 @@
 package scaleway
 
 import (
+	"context"
 	"fmt"
 	"net"
 	"net/http"
@@ -8,7 +9,10 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	"github.com/scaleway/scaleway-sdk-go/scw"
 	"github.com/stretchr/testify/assert"
@@ -216,3 +220,317 @@ func testCheckResourceAttrIPv6(name string, key string) resource.TestCheckFunc {
 		return nil
 	})
 }
+
+func TestExpandTags(t *testing.T) {
+	meta := &Meta{defaultTags: []string{"env:prod", "team:x", "terraform"}}
+
+	assert.Equal(t, []string{"env:prod", "team:x", "terraform"}, expandTags(meta, []interface{}{}))
+	assert.Equal(t, []string{"web", "env:staging", "team:x", "terraform"}, expandTags(meta, []interface{}{"web", "env:staging"}))
+	assert.Equal(t, []string{"web"}, expandTags(&Meta{}, []interface{}{"web"}))
+}
+
+func TestFlattenTags(t *testing.T) {
+	meta := &Meta{defaultTags: []string{"env:prod", "team:x"}}
+
+	assert.Equal(t, []string{"web"}, flattenTags(meta, []string{"web", "env:prod", "team:x"}, []interface{}{"web"}))
+	assert.Equal(t, []string{"web", "env:staging"}, flattenTags(meta, []string{"web", "env:staging", "team:x"}, []interface{}{"web", "env:staging"}))
+	assert.Equal(t, []string{"env:prod"}, flattenTags(meta, []string{"env:prod", "team:x"}, []interface{}{"env:prod"}))
+	assert.Equal(t, []string{"web", "env:prod"}, flattenTags(&Meta{}, []string{"web", "env:prod"}, nil))
+}
+
+func TestSameStringSets(t *testing.T) {
+	assert.True(t, sameStringSets([]string{"web", "env:prod"}, []string{"env:prod", "web"}))
+	assert.True(t, sameStringSets(nil, []string{}))
+	assert.False(t, sameStringSets([]string{"web"}, []string{"web", "env:prod"}))
+	assert.False(t, sameStringSets([]string{"web", "web"}, []string{"web", "env:prod"}))
+}
+
+func TestClosestMatches(t *testing.T) {
+	candidates := []string{"DEV1-S", "DEV1-M", "DEV1-L", "DEV1-XL", "GP1-XS", "GP1-S", "STARDUST1-S"}
+
+	assert.Equal(t, []string{"DEV1-S", "DEV1-L", "DEV1-M", "DEV1-XL"}, closestMatches("dev1-s", candidates, strings.ToUpper)[:4])
+	assert.Equal(t, []string{"GP1-XS", "GP1-S"}, closestMatches("GP1-XXS", candidates, strings.ToUpper))
+	assert.Equal(t, []string{"STARDUST1-S"}, closestMatches("STARDUST", candidates, strings.ToUpper))
+	assert.Empty(t, closestMatches("ENT1-XXL", candidates, strings.ToUpper))
+}
+
+func TestLevenshteinDistance(t *testing.T) {
+	assert.Equal(t, 0, levenshteinDistance("", ""))
+	assert.Equal(t, 3, levenshteinDistance("", "abc"))
+	assert.Equal(t, 1, levenshteinDistance("DEV1-S", "DEV1-M"))
+	assert.Equal(t, 3, levenshteinDistance("kitten", "sitting"))
+}
+
+func TestDiagFromErr(t *testing.T) {
+	assert.Nil(t, diagFromErr(nil))
+	assert.Equal(t, diag.FromErr(fmt.Errorf("boom")), diagFromErr(fmt.Errorf("boom")))
+
+	diags := diagFromErr(fmt.Errorf("cannot create server: %w", &scw.InvalidArgumentsError{Details: []scw.InvalidArgumentsErrorDetail{
+		{ArgumentName: "name", Reason: "constraint", HelpMessage: "must be at most 63 characters"},
+		{ArgumentName: "volumes.0.size", Reason: "required"},
+	}}))
+	require.Len(t, diags, 2)
+	assert.Equal(t, "invalid argument name: does not respect constraint", diags[0].Summary)
+	assert.Equal(t, "must be at most 63 characters", diags[0].Detail)
+	assert.Equal(t, cty.GetAttrPath("name"), diags[0].AttributePath)
+	assert.Equal(t, "invalid argument volumes.0.size: is required", diags[1].Summary)
+	assert.Equal(t, cty.GetAttrPath("volumes").IndexInt(0).GetAttr("size"), diags[1].AttributePath)
+
+	diags = diagFromErr(&scw.QuotasExceededError{Details: []scw.QuotasExceededErrorDetail{{Resource: "compute_instances", Quota: 10, Current: 10}}})
+	require.Len(t, diags, 1)
+	assert.Equal(t, "quota exceeded for compute_instances", diags[0].Summary)
+	assert.Contains(t, diags[0].Detail, "10 compute_instances are used out of a quota of 10")
+
+	diags = diagFromErr(&scw.OutOfStockError{Resource: "GP1-XL"})
+	assert.Equal(t, "GP1-XL is out of stock", diags[0].Summary)
+
+	diags = diagFromErr(&scw.TransientStateError{Resource: "server", ResourceID: "11111111-1111-1111-1111-111111111111", CurrentState: "starting"})
+	assert.Equal(t, "server 11111111-1111-1111-1111-111111111111 is in a transient state: starting", diags[0].Summary)
+	assert.NotEmpty(t, diags[0].Detail)
+}
+
+func TestSchemaHasAttributePath(t *testing.T) {
+	attributes := map[string]*schema.Schema{
+		"name": {Type: schema.TypeString},
+		"tags": {Type: schema.TypeList, Elem: &schema.Schema{Type: schema.TypeString}},
+		"volumes": {Type: schema.TypeList, Elem: &schema.Resource{Schema: map[string]*schema.Schema{
+			"size": {Type: schema.TypeInt},
+		}}},
+	}
+
+	assert.True(t, schemaHasAttributePath(attributes, cty.GetAttrPath("name")))
+	assert.True(t, schemaHasAttributePath(attributes, cty.GetAttrPath("tags").IndexInt(1)))
+	assert.True(t, schemaHasAttributePath(attributes, cty.GetAttrPath("volumes").IndexInt(0).GetAttr("size")))
+	assert.False(t, schemaHasAttributePath(attributes, cty.GetAttrPath("commercial_type")))
+	assert.False(t, schemaHasAttributePath(attributes, cty.GetAttrPath("volumes").IndexInt(0).GetAttr("type")))
+	assert.False(t, schemaHasAttributePath(attributes, cty.GetAttrPath("name").GetAttr("first")))
+}
+
+func TestWithLocalizedIDsStateUpgrade(t *testing.T) {
+	testCases := []struct {
+		name     string
+		resource *schema.Resource
+		rawState map[string]interface{}
+		expected map[string]interface{}
+	}{
+		{
+			name:     "instance server with zone",
+			resource: resourceScalewayInstanceServer(),
+			rawState: map[string]interface{}{
+				"id":                    "11111111-1111-1111-1111-111111111111",
+				"zone":                  "nl-ams-1",
+				"security_group_id":     "22222222-2222-2222-2222-222222222222",
+				"placement_group_id":    "",
+				"ip_id":                 "nl-ams-1/33333333-3333-3333-3333-333333333333",
+				"additional_volume_ids": []interface{}{"44444444-4444-4444-4444-444444444444", "nl-ams-1/55555555-5555-5555-5555-555555555555"},
+				"image":                 "ubuntu_focal",
+			},
+			expected: map[string]interface{}{
+				"id":                    "nl-ams-1/11111111-1111-1111-1111-111111111111",
+				"zone":                  "nl-ams-1",
+				"security_group_id":     "nl-ams-1/22222222-2222-2222-2222-222222222222",
+				"placement_group_id":    "",
+				"ip_id":                 "nl-ams-1/33333333-3333-3333-3333-333333333333",
+				"additional_volume_ids": []interface{}{"nl-ams-1/44444444-4444-4444-4444-444444444444", "nl-ams-1/55555555-5555-5555-5555-555555555555"},
+				"image":                 "ubuntu_focal",
+			},
+		},
+		{
+			name:     "lb frontend without region uses its localized lb_id",
+			resource: resourceScalewayLbFrontend(),
+			rawState: map[string]interface{}{
+				"id":         "11111111-1111-1111-1111-111111111111",
+				"lb_id":      "nl-ams/22222222-2222-2222-2222-222222222222",
+				"backend_id": "33333333-3333-3333-3333-333333333333",
+			},
+			expected: map[string]interface{}{
+				"id":         "nl-ams/11111111-1111-1111-1111-111111111111",
+				"lb_id":      "nl-ams/22222222-2222-2222-2222-222222222222",
+				"backend_id": "nl-ams/33333333-3333-3333-3333-333333333333",
+			},
+		},
+		{
+			name:     "k8s pool mixing regional and zoned attributes",
+			resource: resourceScalewayK8SPool(),
+			rawState: map[string]interface{}{
+				"id":                 "11111111-1111-1111-1111-111111111111",
+				"region":             "fr-par",
+				"zone":               "fr-par-2",
+				"cluster_id":         "22222222-2222-2222-2222-222222222222",
+				"placement_group_id": "33333333-3333-3333-3333-333333333333",
+			},
+			expected: map[string]interface{}{
+				"id":                 "fr-par/11111111-1111-1111-1111-111111111111",
+				"region":             "fr-par",
+				"zone":               "fr-par-2",
+				"cluster_id":         "fr-par/22222222-2222-2222-2222-222222222222",
+				"placement_group_id": "fr-par-2/33333333-3333-3333-3333-333333333333",
+			},
+		},
+		{
+			name:     "rdb user keeps its nested id",
+			resource: resourceScalewayRdbUser(),
+			rawState: map[string]interface{}{
+				"id":          "fr-par/11111111-1111-1111-1111-111111111111/admin",
+				"region":      "fr-par",
+				"instance_id": "11111111-1111-1111-1111-111111111111",
+			},
+			expected: map[string]interface{}{
+				"id":          "fr-par/11111111-1111-1111-1111-111111111111/admin",
+				"region":      "fr-par",
+				"instance_id": "fr-par/11111111-1111-1111-1111-111111111111",
+			},
+		},
+		{
+			name:     "already localized state is unchanged",
+			resource: resourceScalewayIotRoute(),
+			rawState: map[string]interface{}{
+				"id":     "fr-par/11111111-1111-1111-1111-111111111111",
+				"hub_id": "fr-par/22222222-2222-2222-2222-222222222222",
+			},
+			expected: map[string]interface{}{
+				"id":     "fr-par/11111111-1111-1111-1111-111111111111",
+				"hub_id": "fr-par/22222222-2222-2222-2222-222222222222",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, 1, tc.resource.SchemaVersion)
+			require.Len(t, tc.resource.StateUpgraders, 1)
+			require.Equal(t, 0, tc.resource.StateUpgraders[0].Version)
+
+			actual, err := tc.resource.StateUpgraders[0].Upgrade(context.Background(), tc.rawState, nil)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, actual)
+		})
+	}
+}
+
+func TestWithLocalizedIDsStateUpgradeDefaultLocality(t *testing.T) {
+	upgrade := resourceScalewayLbBackend().StateUpgraders[0].Upgrade
+
+	// Without locality in the state, the default region of the provider is used.
+	client, err := scw.NewClient(scw.WithDefaultRegion(scw.RegionNlAms))
+	require.NoError(t, err)
+	actual, err := upgrade(context.Background(), map[string]interface{}{
+		"id":    "11111111-1111-1111-1111-111111111111",
+		"lb_id": "22222222-2222-2222-2222-222222222222",
+	}, &Meta{scwClient: client})
+	require.NoError(t, err)
+	assert.Equal(t, "nl-ams/11111111-1111-1111-1111-111111111111", actual["id"])
+	assert.Equal(t, "nl-ams/22222222-2222-2222-2222-222222222222", actual["lb_id"])
+
+	_, err = upgrade(context.Background(), map[string]interface{}{
+		"id": "11111111-1111-1111-1111-111111111111",
+	}, nil)
+	assert.EqualError(t, err, "cannot upgrade state: could not detect region")
+}
+
+func TestLocalitiesAreCompatible(t *testing.T) {
+	assert.True(t, localitiesAreCompatible("fr-par-1", "fr-par-1"))
+	assert.True(t, localitiesAreCompatible("fr-par", "fr-par"))
+	assert.True(t, localitiesAreCompatible("fr-par", "fr-par-2"))
+	assert.True(t, localitiesAreCompatible("nl-ams-1", "nl-ams"))
+	assert.False(t, localitiesAreCompatible("fr-par-1", "fr-par-2"))
+	assert.False(t, localitiesAreCompatible("fr-par", "nl-ams"))
+	assert.False(t, localitiesAreCompatible("fr-par", "nl-ams-1"))
+}
+
+func TestCustomizeDiffLocalityCheck(t *testing.T) {
+	f := newFakeAPI(t)
+	defer f.close()
+	meta := f.meta()
+
+	testCases := []struct {
+		name     string
+		resource *schema.Resource
+		config   map[string]interface{}
+		err      string
+	}{
+		{
+			name:     "server with references in its zone",
+			resource: resourceScalewayInstanceServer(),
+			config: map[string]interface{}{
+				"type":                  "DEV1-S",
+				"image":                 "ubuntu_focal",
+				"zone":                  "fr-par-1",
+				"placement_group_id":    "fr-par-1/11111111-1111-1111-1111-111111111111",
+				"additional_volume_ids": []interface{}{"fr-par-1/22222222-2222-2222-2222-222222222222", "33333333-3333-3333-3333-333333333333"},
+			},
+		},
+		{
+			name:     "server with a volume in another zone",
+			resource: resourceScalewayInstanceServer(),
+			config: map[string]interface{}{
+				"type":                  "DEV1-S",
+				"image":                 "ubuntu_focal",
+				"zone":                  "fr-par-1",
+				"additional_volume_ids": []interface{}{"fr-par-1/22222222-2222-2222-2222-222222222222", "fr-par-2/33333333-3333-3333-3333-333333333333"},
+			},
+			err: "additional_volume_ids: fr-par-2/33333333-3333-3333-3333-333333333333 is in fr-par-2 but the resource is in zone fr-par-1",
+		},
+		{
+			name:     "server without zone referencing another zone than the default one",
+			resource: resourceScalewayInstanceServer(),
+			config: map[string]interface{}{
+				"type":                  "DEV1-S",
+				"image":                 "ubuntu_focal",
+				"placement_group_id":    "fr-par-2/11111111-1111-1111-1111-111111111111",
+				"additional_volume_ids": []interface{}{"fr-par-2/22222222-2222-2222-2222-222222222222"},
+			},
+			err: "placement_group_id: fr-par-2/11111111-1111-1111-1111-111111111111 is in fr-par-2 but the resource is in the default zone fr-par-1",
+		},
+		{
+			name:     "lb frontend with references in the same region",
+			resource: resourceScalewayLbFrontend(),
+			config: map[string]interface{}{
+				"lb_id":        "nl-ams/11111111-1111-1111-1111-111111111111",
+				"backend_id":   "nl-ams/22222222-2222-2222-2222-222222222222",
+				"inbound_port": 80,
+			},
+		},
+		{
+			name:     "lb frontend with references in different regions",
+			resource: resourceScalewayLbFrontend(),
+			config: map[string]interface{}{
+				"lb_id":        "nl-ams/11111111-1111-1111-1111-111111111111",
+				"backend_id":   "fr-par/22222222-2222-2222-2222-222222222222",
+				"inbound_port": 80,
+			},
+			err: "backend_id: fr-par/22222222-2222-2222-2222-222222222222 is in fr-par but lb_id is in nl-ams",
+		},
+		{
+			name:     "snapshot of a volume in another zone",
+			resource: resourceScalewayInstanceSnapshot(),
+			config: map[string]interface{}{
+				"zone":      "fr-par-1",
+				"volume_id": "nl-ams-1/11111111-1111-1111-1111-111111111111",
+			},
+			err: "volume_id: nl-ams-1/11111111-1111-1111-1111-111111111111 is in nl-ams-1 but the resource is in zone fr-par-1",
+		},
+		{
+			name:     "image with an additional snapshot in another zone",
+			resource: resourceScalewayInstanceImage(),
+			config: map[string]interface{}{
+				"zone":                  "fr-par-1",
+				"root_volume_id":        "fr-par-1/11111111-1111-1111-1111-111111111111",
+				"additional_volume_ids": []interface{}{"nl-ams-1/22222222-2222-2222-2222-222222222222"},
+			},
+			err: "additional_volume_ids: nl-ams-1/22222222-2222-2222-2222-222222222222 is in nl-ams-1 but the resource is in zone fr-par-1",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := f.plan(meta, tc.resource, tc.config)
+			if tc.err == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tc.err)
+		})
+	}
+}
@@ -1,11 +1,18 @@
 package scaleway
 
 import (
+	"context"
+	"time"
+
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/scaleway/scaleway-sdk-go/api/vpc/v1"
 	"github.com/scaleway/scaleway-sdk-go/scw"
 )
 
+const (
+	defaultVPCPrivateNetworkTimeout = 10 * time.Minute
+)
+
 // vpcAPIWithZone returns a new VPC API and the zone for a Create request
 func vpcAPIWithZone(d *schema.ResourceData, m interface{}) (*vpc.API, scw.Zone, error) {
 	meta := m.(*Meta)
@@ -29,3 +36,27 @@ func vpcAPIWithZoneAndID(m interface{}, id string) (*vpc.API, scw.Zone, string,
 	}
 	return vpcAPI, zone, ID, err
 }
+
+// vpcPrivateNetworkIDsByName returns the zoned IDs of the private networks with the given zoned name.
+func vpcPrivateNetworkIDsByName(ctx context.Context, m interface{}, zonedName string) ([]string, error) {
+	vpcAPI, zone, name, err := vpcAPIWithZoneAndID(m, zonedName)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := vpcAPI.ListPrivateNetworks(&vpc.ListPrivateNetworksRequest{
+		Zone: zone,
+		Name: scw.StringPtr(name),
+	}, scw.WithAllPages(), scw.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	ids := []string(nil)
+	for _, pn := range res.PrivateNetworks {
+		if pn.Name == name {
+			ids = append(ids, newZonedIDString(zone, pn.ID))
+		}
+	}
+	return ids, nil
+}
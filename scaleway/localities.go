@@ -0,0 +1,208 @@
+package scaleway
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	sdkValidation "github.com/scaleway/scaleway-sdk-go/validation"
+)
+
+const (
+	// customRegionsEnv declares custom regions outside of the provider configuration, e.g. for sweepers.
+	// Its format is region:zone,zone;region:zone, e.g. fr-tst:fr-tst-1,fr-tst-2;nl-tst:nl-tst-1
+	customRegionsEnv = "SCW_CUSTOM_REGIONS"
+)
+
+// localities lists the zones and regions accepted by the provider and the region of each zone.
+// They are the ones known by the SDK completed by the custom regions of the provider configuration,
+// so that new zones can be used before the provider ships them.
+//
+// A nil localities only knows the zones and regions of the SDK.
+type localities struct {
+	zones   map[scw.Zone]scw.Region
+	regions map[scw.Region][]scw.Zone
+	// skipValidation accepts any well-formed zone or region.
+	skipValidation bool
+}
+
+// newLocalities returns the localities known by the SDK.
+func newLocalities() *localities {
+	loc := &localities{
+		zones:   map[scw.Zone]scw.Region{},
+		regions: map[scw.Region][]scw.Zone{},
+	}
+	for _, region := range scw.AllRegions {
+		loc.regions[region] = nil
+	}
+	for _, zone := range scw.AllZones {
+		region, err := zone.Region()
+		if err != nil {
+			continue
+		}
+		loc.zones[zone] = region
+		loc.regions[region] = append(loc.regions[region], zone)
+	}
+	return loc
+}
+
+// addRegion declares a custom region with its zones.
+// A zone already known to be in another region is refused.
+func (loc *localities) addRegion(region scw.Region, zones []scw.Zone) error {
+	if !sdkValidation.IsRegion(region.String()) {
+		return fmt.Errorf("custom region %q is not a valid region", region)
+	}
+	if _, exist := loc.regions[region]; !exist {
+		loc.regions[region] = nil
+	}
+	for _, zone := range zones {
+		if !sdkValidation.IsZone(zone.String()) {
+			return fmt.Errorf("zone %q of custom region %s is not a valid zone", zone, region)
+		}
+		if knownRegion, exist := loc.zones[zone]; exist {
+			if knownRegion != region {
+				return fmt.Errorf("zone %s of custom region %s is already in region %s", zone, region, knownRegion)
+			}
+			continue
+		}
+		loc.zones[zone] = region
+		loc.regions[region] = append(loc.regions[region], zone)
+	}
+	return nil
+}
+
+// validateZone returns an error when zone is not known by the provider.
+func (loc *localities) validateZone(zone scw.Zone) error {
+	if loc != nil && loc.skipValidation {
+		return nil
+	}
+	if _, exist := loc.all().zones[zone]; !exist {
+		return fmt.Errorf("zone %s is not known by the provider, declare it in a custom_region block of the provider configuration or set skip_locality_validation = true", zone)
+	}
+	return nil
+}
+
+// validateRegion returns an error when region is not known by the provider.
+func (loc *localities) validateRegion(region scw.Region) error {
+	if loc != nil && loc.skipValidation {
+		return nil
+	}
+	if _, exist := loc.all().regions[region]; !exist {
+		return fmt.Errorf("region %s is not known by the provider, declare it in a custom_region block of the provider configuration or set skip_locality_validation = true", region)
+	}
+	return nil
+}
+
+// zoneRegion returns the region of a zone, guessed from its name for unknown zones.
+func (loc *localities) zoneRegion(zone scw.Zone) (scw.Region, error) {
+	if region, exist := loc.all().zones[zone]; exist {
+		return region, nil
+	}
+	return zone.Region()
+}
+
+// regionZones returns the zones of a region sorted by name.
+func (loc *localities) regionZones(region scw.Region) []scw.Zone {
+	zones := append([]scw.Zone(nil), loc.all().regions[region]...)
+	sort.Slice(zones, func(i, j int) bool { return zones[i] < zones[j] })
+	return zones
+}
+
+// all returns loc, or the localities known by the SDK when loc is nil.
+func (loc *localities) all() *localities {
+	if loc == nil {
+		return newLocalities()
+	}
+	return loc
+}
+
+// loadLocalities returns the localities known by the SDK completed by the custom regions
+// of the SCW_CUSTOM_REGIONS environment variable and of the provider configuration.
+func loadLocalities(d *schema.ResourceData) (*localities, error) {
+	loc := newLocalities()
+
+	if rawRegions := os.Getenv(customRegionsEnv); rawRegions != "" {
+		for _, rawRegion := range strings.Split(rawRegions, ";") {
+			parts := strings.SplitN(strings.TrimSpace(rawRegion), ":", 2)
+			if len(parts) != 2 {
+				return nil, fmt.Errorf("invalid %s: %q is not formatted as region:zone,zone", customRegionsEnv, rawRegion)
+			}
+			zones := []scw.Zone(nil)
+			for _, zone := range strings.Split(parts[1], ",") {
+				zones = append(zones, scw.Zone(strings.TrimSpace(zone)))
+			}
+			if err := loc.addRegion(scw.Region(parts[0]), zones); err != nil {
+				return nil, fmt.Errorf("invalid %s: %s", customRegionsEnv, err)
+			}
+		}
+	}
+
+	if d == nil {
+		return loc, nil
+	}
+	for _, rawRegion := range d.Get("custom_region").([]interface{}) {
+		customRegion := rawRegion.(map[string]interface{})
+		zones := []scw.Zone(nil)
+		for _, zone := range customRegion["zones"].([]interface{}) {
+			zones = append(zones, scw.Zone(zone.(string)))
+		}
+		if err := loc.addRegion(scw.Region(customRegion["name"].(string)), zones); err != nil {
+			return nil, err
+		}
+	}
+	loc.skipValidation = d.Get("skip_locality_validation").(bool)
+
+	return loc, nil
+}
+
+// withLocalityValidation adds to the CustomizeDiff of a resource the validation of its zone and region attributes,
+// so the zones and regions unknown by the provider are refused at plan. The provider configuration is needed to
+// know the custom regions: the schema validation only checks their format.
+func withLocalityValidation(resource *schema.Resource) *schema.Resource {
+	attributes := []string(nil)
+	for _, attribute := range []string{"zone", "region"} {
+		if _, exist := resource.Schema[attribute]; exist {
+			attributes = append(attributes, attribute)
+		}
+	}
+	if len(attributes) == 0 {
+		return resource
+	}
+
+	validate := customizeDiffLocalityValidation(attributes...)
+	if resource.CustomizeDiff != nil {
+		validate = customdiff.All(validate, resource.CustomizeDiff)
+	}
+	resource.CustomizeDiff = validate
+	return resource
+}
+
+// customizeDiffLocalityValidation returns a CustomizeDiffFunc refusing the zone and region attributes unknown by the provider.
+func customizeDiffLocalityValidation(attributes ...string) schema.CustomizeDiffFunc {
+	return func(ctx context.Context, diff *schema.ResourceDiff, m interface{}) error {
+		loc := m.(*Meta).localities
+		for _, attribute := range attributes {
+			rawLocality, exist := diff.GetOk(attribute)
+			if !exist || !diff.NewValueKnown(attribute) {
+				continue
+			}
+			locality := strings.ToLower(rawLocality.(string))
+
+			var err error
+			if attribute == "zone" {
+				err = loc.validateZone(scw.Zone(locality))
+			} else {
+				err = loc.validateRegion(scw.Region(locality))
+			}
+			if err != nil {
+				return fmt.Errorf("%s: %s", attribute, err)
+			}
+		}
+		return nil
+	}
+}
@@ -0,0 +1,95 @@
+package scaleway
+
+import (
+	"os"
+	"testing"
+
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLocalities(t *testing.T) {
+	localities := newLocalities()
+	require.NoError(t, localities.addRegion("fr-tst", []scw.Zone{"fr-tst-1", "fr-tst-2"}))
+	require.NoError(t, localities.addRegion("fr-par", []scw.Zone{"fr-par-9"}))
+
+	assert.NoError(t, localities.validateZone("fr-par-1"))
+	assert.NoError(t, localities.validateZone("fr-tst-2"))
+	assert.NoError(t, localities.validateRegion("fr-tst"))
+	assert.EqualError(t, localities.validateZone("nl-tst-1"), "zone nl-tst-1 is not known by the provider, declare it in a custom_region block of the provider configuration or set skip_locality_validation = true")
+	assert.Error(t, localities.validateRegion("nl-tst"))
+
+	region, err := localities.zoneRegion("fr-par-9")
+	require.NoError(t, err)
+	assert.Equal(t, scw.RegionFrPar, region)
+	assert.Equal(t, []scw.Zone{"fr-tst-1", "fr-tst-2"}, localities.regionZones("fr-tst"))
+	assert.Contains(t, localities.regionZones(scw.RegionFrPar), scw.Zone("fr-par-9"))
+
+	assert.EqualError(t, localities.addRegion("nl-tst", []scw.Zone{"fr-tst-1"}), "zone fr-tst-1 of custom region nl-tst is already in region fr-tst")
+	assert.Error(t, localities.addRegion("not a region", nil))
+
+	localities.skipValidation = true
+	assert.NoError(t, localities.validateZone("nl-tst-1"))
+	assert.NoError(t, localities.validateRegion("nl-tst"))
+}
+
+func TestLocalitiesNil(t *testing.T) {
+	var localities *localities
+	assert.NoError(t, localities.validateZone(scw.ZoneFrPar1))
+	assert.Error(t, localities.validateZone("fr-tst-1"))
+	assert.Equal(t, []scw.Zone{scw.ZoneNlAms1}, localities.regionZones(scw.RegionNlAms))
+}
+
+func TestLoadLocalitiesFromEnv(t *testing.T) {
+	previous, exist := os.LookupEnv(customRegionsEnv)
+	defer func() {
+		if exist {
+			_ = os.Setenv(customRegionsEnv, previous)
+		} else {
+			_ = os.Unsetenv(customRegionsEnv)
+		}
+	}()
+
+	require.NoError(t, os.Setenv(customRegionsEnv, "fr-tst:fr-tst-1,fr-tst-2;nl-tst:nl-tst-1"))
+	localities, err := loadLocalities(nil)
+	require.NoError(t, err)
+	assert.Equal(t, []scw.Zone{"fr-tst-1", "fr-tst-2"}, localities.regionZones("fr-tst"))
+	assert.Equal(t, []scw.Zone{"nl-tst-1"}, localities.regionZones("nl-tst"))
+
+	require.NoError(t, os.Setenv(customRegionsEnv, "fr-tst"))
+	_, err = loadLocalities(nil)
+	assert.EqualError(t, err, `invalid SCW_CUSTOM_REGIONS: "fr-tst" is not formatted as region:zone,zone`)
+}
+
+func TestLocalityValidationAtPlan(t *testing.T) {
+	f := newFakeAPI(t)
+	defer f.close()
+	meta := f.metaWithConfig(map[string]interface{}{
+		"custom_region": []interface{}{
+			map[string]interface{}{"name": "fr-tst", "zones": []interface{}{"fr-tst-1"}},
+		},
+	})
+	resources := Provider(DefaultProviderConfig())().ResourcesMap
+
+	require.NoError(t, f.plan(meta, resources["scaleway_instance_ip"], map[string]interface{}{"zone": "fr-par-1"}))
+	require.NoError(t, f.plan(meta, resources["scaleway_instance_ip"], map[string]interface{}{"zone": "FR-PAR-1"}))
+	require.NoError(t, f.plan(meta, resources["scaleway_instance_ip"], map[string]interface{}{"zone": "fr-tst-1"}))
+	require.NoError(t, f.plan(meta, resources["scaleway_lb_ip"], map[string]interface{}{"region": "Fr-Tst"}))
+
+	err := f.plan(meta, resources["scaleway_instance_ip"], map[string]interface{}{"zone": "fr-par-9"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "zone: zone fr-par-9 is not known by the provider")
+
+	err = f.plan(meta, resources["scaleway_lb_ip"], map[string]interface{}{"region": "nl-tst"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "region: region nl-tst is not known by the provider")
+
+	// The resources keep their own CustomizeDiff.
+	err = f.plan(meta, resources["scaleway_instance_snapshot"], map[string]interface{}{
+		"zone":      "fr-par-1",
+		"volume_id": "nl-ams-1/11111111-1111-1111-1111-111111111111",
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "volume_id: nl-ams-1/11111111-1111-1111-1111-111111111111 is in nl-ams-1")
+}
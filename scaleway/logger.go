@@ -16,6 +16,11 @@ type logger struct {
 // l is the global logger singleton
 var l = logger{}
 
+// Tracef logs to the TRACE log. Arguments are handled in the manner of fmt.Printf.
+func (l logger) Tracef(format string, args ...interface{}) {
+	log.Printf("[TRACE] "+format, args...)
+}
+
 // Debugf logs to the DEBUG log. Arguments are handled in the manner of fmt.Printf.
 func (l logger) Debugf(format string, args ...interface{}) {
 	log.Printf("[DEBUG] "+format, args...)
@@ -41,6 +46,11 @@ func (l logger) Printf(format string, args ...interface{}) {
 	l.Debugf(format, args...)
 }
 
+// isTraceEnabled returns true if terraform logs at the TRACE level.
+func (l logger) isTraceEnabled() bool {
+	return logging.LogLevel() == "TRACE"
+}
+
 // ShouldLog allow the SDK to log only in DEBUG or TRACE levels.
 func (l logger) ShouldLog(level sdkLogger.LogLevel) bool {
 	return logging.IsDebugOrHigher()
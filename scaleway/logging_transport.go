@@ -0,0 +1,177 @@
+package scaleway
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	// maxLoggedBodySize is the maximum size of a body written in the logs.
+	maxLoggedBodySize = 16 * 1024
+	redactedValue     = "[REDACTED]"
+)
+
+// redactedHeaders are the headers whose value is never logged.
+var redactedHeaders = []string{
+	"X-Auth-Token",
+	"Authorization",
+	"X-Amz-Security-Token",
+}
+
+// redactedQueryParameters are the query parameters whose value is never logged. (presigned S3 URLs)
+var redactedQueryParameters = []string{
+	"X-Amz-Signature",
+	"X-Amz-Credential",
+	"X-Amz-Security-Token",
+}
+
+// redactedBodyKeys are the JSON keys whose value is never logged, whatever their depth.
+// This covers API secret keys, rdb user passwords, IoT route database passwords and IoT device private keys.
+var redactedBodyKeys = map[string]bool{
+	"secret_key":  true,
+	"password":    true,
+	"token":       true,
+	"private_key": true,
+	"key":         true,
+}
+
+// redactedBodyPathSuffixes are the URL path suffixes whose responses are never logged. (kubeconfig)
+var redactedBodyPathSuffixes = []string{
+	"/kubeconfig",
+}
+
+// loggingTransport is a http transport logging the requests and responses at the TRACE level with secrets redacted.
+type loggingTransport struct {
+	transport http.RoundTripper
+}
+
+// newLoggingTransport creates a http transport logging the traffic of the given transport.
+func newLoggingTransport(transport http.RoundTripper) http.RoundTripper {
+	return &loggingTransport{transport: transport}
+}
+
+// RoundTrip logs the request and its response when logging at the TRACE level.
+func (t *loggingTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	if !l.isTraceEnabled() {
+		return t.transport.RoundTrip(r)
+	}
+
+	requestBody, err := readBody(&r.Body)
+	if err != nil {
+		return nil, err
+	}
+	l.Tracef("HTTP request %s %s\nheaders: %s\nbody: %s", r.Method, redactURL(r.URL), redactHeaders(r.Header), redactBody(r.URL, requestBody, false))
+
+	start := time.Now()
+	resp, err := t.transport.RoundTrip(r)
+	latency := time.Since(start).Round(time.Millisecond)
+	if err != nil {
+		l.Tracef("HTTP request %s %s failed after %s: %s", r.Method, redactURL(r.URL), latency, err)
+		return resp, err
+	}
+
+	responseBody, err := readBody(&resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	l.Tracef("HTTP response %s %s: %s in %s\nheaders: %s\nbody: %s", r.Method, redactURL(r.URL), resp.Status, latency, redactHeaders(resp.Header), redactBody(r.URL, responseBody, true))
+
+	return resp, nil
+}
+
+// readBody reads a body and replaces it with a copy that can be read again.
+func readBody(body *io.ReadCloser) ([]byte, error) {
+	if *body == nil || *body == http.NoBody {
+		return nil, nil
+	}
+	content, err := ioutil.ReadAll(*body)
+	if err != nil {
+		return nil, err
+	}
+	_ = (*body).Close()
+	*body = ioutil.NopCloser(bytes.NewReader(content))
+	return content, nil
+}
+
+// redactURL returns the URL with sensitive query parameters redacted.
+func redactURL(u *url.URL) string {
+	query := u.Query()
+	redacted := false
+	for _, parameter := range redactedQueryParameters {
+		if query.Get(parameter) != "" {
+			query.Set(parameter, redactedValue)
+			redacted = true
+		}
+	}
+	if !redacted {
+		return u.String()
+	}
+	redactedURL := *u
+	redactedURL.RawQuery = query.Encode()
+	return redactedURL.String()
+}
+
+// redactHeaders returns the headers as a string with sensitive values redacted.
+func redactHeaders(headers http.Header) string {
+	redactedHeadersCopy := headers.Clone()
+	for _, header := range redactedHeaders {
+		if redactedHeadersCopy.Get(header) != "" {
+			redactedHeadersCopy.Set(header, redactedValue)
+		}
+	}
+	raw, _ := json.Marshal(redactedHeadersCopy)
+	return string(raw)
+}
+
+// redactBody returns the body as a string with sensitive values redacted.
+func redactBody(u *url.URL, body []byte, isResponse bool) string {
+	if len(body) == 0 {
+		return ""
+	}
+
+	if isResponse {
+		for _, suffix := range redactedBodyPathSuffixes {
+			if strings.HasSuffix(u.Path, suffix) {
+				return redactedValue
+			}
+		}
+	}
+
+	var content interface{}
+	if err := json.Unmarshal(body, &content); err == nil {
+		raw, err := json.Marshal(redactJSON(content))
+		if err == nil {
+			body = raw
+		}
+	}
+
+	if len(body) > maxLoggedBodySize {
+		return string(body[:maxLoggedBodySize]) + "...(truncated)"
+	}
+	return string(body)
+}
+
+// redactJSON redacts the values of the sensitive keys of a decoded JSON document.
+func redactJSON(content interface{}) interface{} {
+	switch value := content.(type) {
+	case map[string]interface{}:
+		for key, child := range value {
+			if redactedBodyKeys[strings.ToLower(key)] {
+				value[key] = redactedValue
+				continue
+			}
+			value[key] = redactJSON(child)
+		}
+	case []interface{}:
+		for i, child := range value {
+			value[i] = redactJSON(child)
+		}
+	}
+	return content
+}
@@ -0,0 +1,131 @@
+package scaleway
+
+import (
+	"bytes"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRedactBody(t *testing.T) {
+	testCases := []struct {
+		name       string
+		url        string
+		body       string
+		isResponse bool
+		expected   string
+	}{
+		{
+			name:     "rdb user password",
+			url:      "https://api.scaleway.com/rdb/v1/regions/fr-par/instances/xxx/users",
+			body:     `{"name":"foo","password":"thiZ_is_v&ry_s3cret","is_admin":true}`,
+			expected: `{"is_admin":true,"name":"foo","password":"[REDACTED]"}`,
+		},
+		{
+			name:     "iot route database password",
+			url:      "https://api.scaleway.com/iot/v1/regions/fr-par/routes",
+			body:     `{"name":"route","db_config":{"host":"localhost","password":"secret"}}`,
+			expected: `{"db_config":{"host":"localhost","password":"[REDACTED]"},"name":"route"}`,
+		},
+		{
+			name:       "kubeconfig",
+			url:        "https://api.scaleway.com/k8s/v1/regions/fr-par/clusters/xxx/kubeconfig",
+			body:       `{"name":"kubeconfig","content":"YXBpVmVyc2lvbjogdjE="}`,
+			isResponse: true,
+			expected:   redactedValue,
+		},
+		{
+			name:     "not json",
+			url:      "https://s3.fr-par.scw.cloud/bucket",
+			body:     `<Tagging></Tagging>`,
+			expected: `<Tagging></Tagging>`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			u, err := url.Parse(tc.url)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, redactBody(u, []byte(tc.body), tc.isResponse))
+		})
+	}
+}
+
+func TestRedactHeadersAndURL(t *testing.T) {
+	headers := http.Header{}
+	headers.Set("X-Auth-Token", "11111111-1111-1111-1111-111111111111")
+	headers.Set("Content-Type", "application/json")
+	redacted := redactHeaders(headers)
+	assert.NotContains(t, redacted, "11111111-1111-1111-1111-111111111111")
+	assert.Contains(t, redacted, "application/json")
+
+	u, err := url.Parse("https://bucket.s3.fr-par.scw.cloud/object?X-Amz-Signature=signature&X-Amz-Expires=60")
+	require.NoError(t, err)
+	assert.NotContains(t, redactURL(u), "signature")
+	assert.Contains(t, redactURL(u), "X-Amz-Expires=60")
+}
+
+func TestLoggingTransport(t *testing.T) {
+	previous, exist := os.LookupEnv("TF_LOG")
+	require.NoError(t, os.Setenv("TF_LOG", "TRACE"))
+	defer func() {
+		if exist {
+			_ = os.Setenv("TF_LOG", previous)
+		} else {
+			_ = os.Unsetenv("TF_LOG")
+		}
+	}()
+
+	// Capture the output of the logger.
+	logs := &bytes.Buffer{}
+	previousOutput := log.Writer()
+	log.SetOutput(logs)
+	defer log.SetOutput(previousOutput)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		_, _ = w.Write(body)
+	}))
+	defer server.Close()
+
+	const (
+		authToken = "11111111-1111-1111-1111-111111111111"
+		secretKey = "22222222-2222-2222-2222-222222222222"
+		password  = "thiZ_is_v&ry_s3cret"
+	)
+	requestBody := `{"name":"foo","secret_key":"` + secretKey + `","user":{"password":"` + password + `"}}`
+	req, err := http.NewRequest(http.MethodPost, server.URL+"/rdb/v1/regions/fr-par/instances", strings.NewReader(requestBody))
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Auth-Token", authToken)
+
+	client := &http.Client{Transport: newLoggingTransport(http.DefaultTransport)}
+	resp, err := client.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	// The bodies must still be readable after being logged.
+	body, err := ioutil.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Equal(t, requestBody, string(body))
+
+	// The request and its response are logged with their secrets redacted.
+	output := logs.String()
+	assert.Contains(t, output, "[TRACE] HTTP request POST")
+	assert.Contains(t, output, "[TRACE] HTTP response POST")
+	assert.Contains(t, output, `"name":"foo"`)
+	assert.Contains(t, output, `"X-Auth-Token":["`+redactedValue+`"]`)
+	assert.Contains(t, output, `"secret_key":"`+redactedValue+`"`)
+	assert.Contains(t, output, `"password":"`+redactedValue+`"`)
+	assert.NotContains(t, output, authToken)
+	assert.NotContains(t, output, secretKey)
+	assert.NotContains(t, output, password)
+}
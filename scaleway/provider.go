@@ -4,11 +4,15 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"sort"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/plugin"
 	"github.com/scaleway/scaleway-sdk-go/scw"
+	sdkValidation "github.com/scaleway/scaleway-sdk-go/validation"
 )
 
 // Provider config can be used to provide additional config when creating provider.
@@ -28,6 +32,11 @@ func Provider(config *ProviderConfig) plugin.ProviderFunc {
 	return func() *schema.Provider {
 		p := &schema.Provider{
 			Schema: map[string]*schema.Schema{
+				"profile": {
+					Type:        schema.TypeString,
+					Optional:    true,
+					Description: "The Scaleway profile to use from the configuration file.",
+				},
 				"access_key": {
 					Type:        schema.TypeString,
 					Optional:    true,
@@ -45,6 +54,18 @@ func Provider(config *ProviderConfig) plugin.ProviderFunc {
 					Description:  "The Scaleway project ID.",
 					ValidateFunc: validationUUID(),
 				},
+				"s3_access_key": {
+					Type:        schema.TypeString,
+					Optional:    true,
+					Description: "The access key used for object storage instead of access_key.",
+				},
+				"s3_secret_key": {
+					Type:         schema.TypeString,
+					Optional:     true,
+					Sensitive:    true,
+					Description:  "The secret key used for object storage instead of secret_key.",
+					ValidateFunc: validationUUID(),
+				},
 				"region": regionSchema(),
 				"zone":   zoneSchema(),
 				"api_url": {
@@ -52,6 +73,155 @@ func Provider(config *ProviderConfig) plugin.ProviderFunc {
 					Optional:    true,
 					Description: "The Scaleway API URL to use.",
 				},
+				"default_tags": {
+					Type:        schema.TypeList,
+					Optional:    true,
+					MaxItems:    1,
+					Description: "Tags applied to every resource supporting tags.",
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							"tags": {
+								Type:        schema.TypeList,
+								Optional:    true,
+								Description: "The tags merged into the tags of every resource. A resource tag with the same key takes precedence.",
+								Elem: &schema.Schema{
+									Type: schema.TypeString,
+								},
+							},
+						},
+					},
+				},
+				"endpoints": {
+					Type:        schema.TypeList,
+					Optional:    true,
+					MaxItems:    1,
+					Description: "Endpoints overriding api_url for some products, and object storage options.",
+					Elem: &schema.Resource{
+						Schema: endpointsSchema(),
+					},
+				},
+				"custom_region": {
+					Type:        schema.TypeList,
+					Optional:    true,
+					Description: "Regions, with their zones, accepted in addition to the ones known by the provider.",
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							"name": {
+								Type:         schema.TypeString,
+								Required:     true,
+								Description:  "The name of the region.",
+								ValidateFunc: validationRegion(),
+							},
+							"zones": {
+								Type:        schema.TypeList,
+								Optional:    true,
+								Description: "The zones of the region.",
+								Elem: &schema.Schema{
+									Type:         schema.TypeString,
+									ValidateFunc: validationZone(),
+								},
+							},
+						},
+					},
+				},
+				"skip_locality_validation": {
+					Type:        schema.TypeBool,
+					Optional:    true,
+					Default:     false,
+					Description: "Accept any well-formed zone or region, even the ones unknown by the provider.",
+				},
+				"read_only": {
+					Type:        schema.TypeBool,
+					Optional:    true,
+					Default:     false,
+					Description: "Refuse every request that may modify a resource, to Scaleway APIs as well as object storage.",
+				},
+				"retry": {
+					Type:        schema.TypeList,
+					Optional:    true,
+					MaxItems:    1,
+					Description: "The retry policy applied to the requests made to Scaleway APIs and object storage.",
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							"max_attempts": {
+								Type:         schema.TypeInt,
+								Optional:     true,
+								Default:      defaultRetryMaxAttempts,
+								Description:  "The maximum number of attempts for a request, including the first one.",
+								ValidateFunc: validation.IntAtLeast(1),
+							},
+							"min_wait": {
+								Type:         schema.TypeString,
+								Optional:     true,
+								Default:      defaultRetryMinWait.String(),
+								Description:  "The minimum time to wait between two attempts.",
+								ValidateFunc: validateDuration(),
+							},
+							"max_wait": {
+								Type:         schema.TypeString,
+								Optional:     true,
+								Default:      defaultRetryMaxWait.String(),
+								Description:  "The maximum time to wait between two attempts.",
+								ValidateFunc: validateDuration(),
+							},
+							"jitter": {
+								Type:        schema.TypeBool,
+								Optional:    true,
+								Default:     false,
+								Description: "Randomize the exponential backoff between two attempts.",
+							},
+							"retryable_status_codes": {
+								Type:        schema.TypeList,
+								Optional:    true,
+								Description: "The HTTP status codes triggering a retry. Defaults to 429 and 5xx responses.",
+								Elem: &schema.Schema{
+									Type:         schema.TypeInt,
+									ValidateFunc: validation.IntBetween(100, 599),
+								},
+							},
+							"respect_retry_after": {
+								Type:        schema.TypeBool,
+								Optional:    true,
+								Default:     true,
+								Description: "Wait for the duration sent by the API in the Retry-After header before retrying.",
+							},
+						},
+					},
+				},
+				"rate_limit": {
+					Type:        schema.TypeList,
+					Optional:    true,
+					Description: "Client side rate limits applied to the requests of a product.",
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							"product": {
+								Type:         schema.TypeString,
+								Required:     true,
+								Description:  "The product the rate limit applies to.",
+								ValidateFunc: validation.StringInSlice(rateLimitProducts, false),
+							},
+							"requests_per_second": {
+								Type:         schema.TypeFloat,
+								Optional:     true,
+								Description:  "The number of requests per second allowed for the product.",
+								ValidateFunc: validation.FloatAtLeast(0),
+							},
+							"burst": {
+								Type:         schema.TypeInt,
+								Optional:     true,
+								Default:      1,
+								Description:  "The number of requests allowed to exceed the rate.",
+								ValidateFunc: validation.IntAtLeast(1),
+							},
+							"max_concurrent_requests": {
+								Type:         schema.TypeInt,
+								Optional:     true,
+								Description:  "The maximum number of in-flight requests for the product.",
+								ValidateFunc: validation.IntAtLeast(0),
+							},
+						},
+					},
+				},
 			},
 
 			ResourcesMap: map[string]*schema.Resource{
@@ -66,6 +236,8 @@ func Provider(config *ProviderConfig) plugin.ProviderFunc {
 				"scaleway_instance_server":               resourceScalewayInstanceServer(),
 				"scaleway_instance_placement_group":      resourceScalewayInstancePlacementGroup(),
 				"scaleway_instance_private_nic":          resourceScalewayInstancePrivateNIC(),
+				"scaleway_instance_snapshot":             resourceScalewayInstanceSnapshot(),
+				"scaleway_instance_image":                resourceScalewayInstanceImage(),
 				"scaleway_iot_hub":                       resourceScalewayIotHub(),
 				"scaleway_iot_device":                    resourceScalewayIotDevice(),
 				"scaleway_iot_route":                     resourceScalewayIotRoute(),
@@ -88,6 +260,7 @@ func Provider(config *ProviderConfig) plugin.ProviderFunc {
 				"scaleway_account_ssh_key":         dataSourceScalewayAccountSSHKey(),
 				"scaleway_instance_security_group": dataSourceScalewayInstanceSecurityGroup(),
 				"scaleway_instance_server":         dataSourceScalewayInstanceServer(),
+				"scaleway_instance_snapshot":       dataSourceScalewayInstanceSnapshot(),
 				"scaleway_instance_image":          dataSourceScalewayInstanceImage(),
 				"scaleway_instance_volume":         dataSourceScalewayInstanceVolume(),
 				"scaleway_baremetal_offer":         dataSourceScalewayBaremetalOffer(),
@@ -102,6 +275,14 @@ func Provider(config *ProviderConfig) plugin.ProviderFunc {
 			},
 		}
 
+		for _, resource := range p.ResourcesMap {
+			withSchemaAttributePaths(resource)
+			withLocalityValidation(resource)
+		}
+		for _, dataSource := range p.DataSourcesMap {
+			withSchemaAttributePaths(dataSource)
+		}
+
 		p.ConfigureContextFunc = func(ctx context.Context, data *schema.ResourceData) (interface{}, diag.Diagnostics) {
 			terraformVersion := p.TerraformVersion
 
@@ -115,7 +296,7 @@ func Provider(config *ProviderConfig) plugin.ProviderFunc {
 				terraformVersion: terraformVersion,
 			})
 			if err != nil {
-				return nil, diag.FromErr(err)
+				return nil, diagFromErr(err)
 			}
 			return meta, nil
 		}
@@ -134,6 +315,18 @@ type Meta struct {
 	// or it can be a http.Client used to record and replay cassettes which is useful
 	// to replay recorded interactions with APIs locally
 	httpClient *http.Client
+	// defaultTags are the tags merged into the tags of every resource.
+	defaultTags []string
+	// catalogCache caches catalog responses (server types, images, versions...) shared by all resources.
+	catalogCache *catalogCache
+	// localities are the zones and regions accepted by the provider.
+	localities *localities
+	// endpoints are the endpoint overrides of the provider, used by object storage clients.
+	endpoints *endpointsConfig
+	// s3Credentials are the keys used by object storage clients.
+	s3Credentials *s3Credentials
+	// s3Clients are the object storage clients of each region, shared by all buckets.
+	s3Clients *s3ClientPool
 }
 
 type MetaConfig struct {
@@ -148,20 +341,30 @@ func buildMeta(config *MetaConfig) (*Meta, error) {
 	////
 	// Load Profile
 	////
-	profile, err := loadProfile(config.providerSchema)
+	localities, err := loadLocalities(config.providerSchema)
+	if err != nil {
+		return nil, err
+	}
+	profile, sources, err := loadProfile(config.providerSchema, localities)
 	if err != nil {
 		return nil, err
 	}
 	if config.forceZone != "" {
-		region, err := config.forceZone.Region()
+		region, err := localities.zoneRegion(config.forceZone)
 		if err != nil {
 			return nil, err
 		}
 		profile.DefaultRegion = scw.StringPtr(region.String())
 		profile.DefaultZone = scw.StringPtr(config.forceZone.String())
+		sources["region"] = "forced zone"
+		sources["zone"] = "forced zone"
 	}
 
-	// TODO validated profile
+	l.Debugf("provider configuration sources: %s", sources)
+	err = validateProfile(profile, sources, localities)
+	if err != nil {
+		return nil, err
+	}
 
 	////
 	// Create scaleway SDK client
@@ -171,10 +374,43 @@ func buildMeta(config *MetaConfig) (*Meta, error) {
 		scw.WithProfile(profile),
 	}
 
-	httpClient := &http.Client{Transport: newRetryableTransport(http.DefaultTransport)}
+	endpoints, err := loadEndpoints(config.providerSchema)
+	if err != nil {
+		return nil, err
+	}
+	baseTransport := http.DefaultTransport
+	if endpoints != nil && endpoints.caBundle != "" {
+		baseTransport, err = newCABundleTransport(endpoints.caBundle)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	retry, err := loadRetryConfig(config.providerSchema)
+	if err != nil {
+		return nil, err
+	}
+	rateLimits, err := loadRateLimits(config.providerSchema)
+	if err != nil {
+		return nil, err
+	}
+	transport := newRateLimitedTransport(baseTransport, rateLimits)
+	httpClient := &http.Client{Transport: newLoggingTransport(newRetryableTransport(transport, retry))}
 	if config.httpClient != nil {
 		httpClient = config.httpClient
 	}
+	if endpoints != nil && len(endpoints.products) > 0 {
+		// The client given in the config may be shared, rewrite the requests of a copy of it.
+		endpointClient := *httpClient
+		endpointClient.Transport = newEndpointTransport(httpClientTransport(httpClient), endpoints.products)
+		httpClient = &endpointClient
+	}
+	if config.providerSchema != nil && config.providerSchema.Get("read_only").(bool) {
+		// The client given in the config may be shared, guard a copy of it.
+		readOnlyClient := *httpClient
+		readOnlyClient.Transport = newReadOnlyTransport(httpClientTransport(httpClient))
+		httpClient = &readOnlyClient
+	}
 	opts = append(opts, scw.WithHTTPClient(httpClient))
 
 	scwClient, err := scw.NewClient(opts...)
@@ -182,19 +418,140 @@ func buildMeta(config *MetaConfig) (*Meta, error) {
 		return nil, err
 	}
 
+	s3Credentials, err := loadS3Credentials(config.providerSchema, scwClient)
+	if err != nil {
+		return nil, err
+	}
+
 	return &Meta{
-		scwClient:  scwClient,
-		httpClient: httpClient,
+		scwClient:     scwClient,
+		httpClient:    httpClient,
+		defaultTags:   loadDefaultTags(config.providerSchema),
+		catalogCache:  newCatalogCache(defaultCatalogCacheTTL),
+		localities:    localities,
+		endpoints:     endpoints,
+		s3Credentials: s3Credentials,
+		s3Clients:     newS3ClientPool(),
 	}, nil
 }
 
-func loadProfile(d *schema.ResourceData) (*scw.Profile, error) {
+// httpClientTransport returns the transport used by a http client.
+func httpClientTransport(client *http.Client) http.RoundTripper {
+	if client.Transport == nil {
+		return http.DefaultTransport
+	}
+	return client.Transport
+}
+
+// loadDefaultTags returns the tags defined in the default_tags block of the provider.
+func loadDefaultTags(d *schema.ResourceData) []string {
+	if d == nil {
+		return nil
+	}
+	if rawTags, exist := d.GetOk("default_tags.0.tags"); exist {
+		return expandStrings(rawTags)
+	}
+	return nil
+}
+
+// loadRetryConfig returns the retry policy defined in the retry block of the provider.
+func loadRetryConfig(d *schema.ResourceData) (*retryConfig, error) {
+	config := defaultRetryConfig()
+	if d == nil {
+		return config, nil
+	}
+	if _, exist := d.GetOk("retry"); !exist {
+		return config, nil
+	}
+
+	config.maxAttempts = d.Get("retry.0.max_attempts").(int)
+	config.minWait = *expandDuration(d.Get("retry.0.min_wait"))
+	config.maxWait = *expandDuration(d.Get("retry.0.max_wait"))
+	config.jitter = d.Get("retry.0.jitter").(bool)
+	config.respectRetryAfter = d.Get("retry.0.respect_retry_after").(bool)
+	for _, code := range d.Get("retry.0.retryable_status_codes").([]interface{}) {
+		config.retryableStatusCodes = append(config.retryableStatusCodes, code.(int))
+	}
+
+	if config.minWait <= 0 || config.maxWait <= 0 {
+		return nil, fmt.Errorf("retry min_wait and max_wait must be positive, got %s and %s", config.minWait, config.maxWait)
+	}
+	if config.minWait > config.maxWait {
+		return nil, fmt.Errorf("retry min_wait %s must not be greater than max_wait %s", config.minWait, config.maxWait)
+	}
+
+	return config, nil
+}
+
+// loadRateLimits returns the rate limits defined in the rate_limit blocks of the provider, indexed by product.
+func loadRateLimits(d *schema.ResourceData) (map[string]*rateLimitConfig, error) {
+	if d == nil {
+		return nil, nil
+	}
+
+	limits := map[string]*rateLimitConfig{}
+	for _, rawLimit := range d.Get("rate_limit").([]interface{}) {
+		limit := rawLimit.(map[string]interface{})
+		product := limit["product"].(string)
+		if _, exist := limits[product]; exist {
+			return nil, fmt.Errorf("rate_limit: product %s is limited more than once", product)
+		}
+		limits[product] = &rateLimitConfig{
+			requestsPerSecond:     limit["requests_per_second"].(float64),
+			burst:                 limit["burst"].(int),
+			maxConcurrentRequests: limit["max_concurrent_requests"].(int),
+		}
+	}
+
+	return limits, nil
+}
+
+// profileSources maps each profile attribute to the source it was loaded from.
+type profileSources map[string]string
+
+// profileAttributes returns the profile values indexed by their provider attribute name.
+func profileAttributes(p *scw.Profile) map[string]*string {
+	return map[string]*string{
+		"access_key": p.AccessKey,
+		"secret_key": p.SecretKey,
+		"project_id": p.DefaultProjectID,
+		"region":     p.DefaultRegion,
+		"zone":       p.DefaultZone,
+		"api_url":    p.APIURL,
+	}
+}
+
+// record marks the attributes set in the given profile as coming from source.
+func (s profileSources) record(p *scw.Profile, source string) {
+	for attribute, value := range profileAttributes(p) {
+		if value != nil {
+			s[attribute] = source
+		}
+	}
+}
+
+// String lists the source of every loaded attribute.
+func (s profileSources) String() string {
+	attributes := make([]string, 0, len(s))
+	for attribute := range s {
+		attributes = append(attributes, attribute)
+	}
+	sort.Strings(attributes)
+
+	lines := make([]string, 0, len(attributes))
+	for _, attribute := range attributes {
+		lines = append(lines, fmt.Sprintf("%s from %s", attribute, s[attribute]))
+	}
+	return strings.Join(lines, ", ")
+}
+
+func loadProfile(d *schema.ResourceData, localities *localities) (*scw.Profile, profileSources, error) {
 	config, err := scw.LoadConfig()
 	// If the config file do not exist, don't return an error as we may find config in ENV or flags.
 	if _, isNotFoundError := err.(*scw.ConfigFileNotFoundError); isNotFoundError {
 		config = &scw.Config{}
 	} else if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	// By default we set default zone and region to fr-par
@@ -203,9 +560,27 @@ func loadProfile(d *schema.ResourceData) (*scw.Profile, error) {
 		DefaultZone:   scw.StringPtr(scw.ZoneFrPar1.String()),
 	}
 
-	activeProfile, err := config.GetActiveProfile()
-	if err != nil {
-		return nil, err
+	// The profile explicitly selected in the provider takes precedence over the active profile.
+	profileName := ""
+	if d != nil {
+		if rawProfileName, exist := d.GetOk("profile"); exist {
+			profileName = rawProfileName.(string)
+		}
+	}
+
+	var fileProfile *scw.Profile
+	fileSource := "scaleway configuration file active profile"
+	if profileName != "" {
+		fileProfile, err = config.GetProfile(profileName)
+		if err != nil {
+			return nil, nil, fmt.Errorf("cannot load profile %q from the scaleway configuration file: %s", profileName, err)
+		}
+		fileSource = fmt.Sprintf("scaleway configuration file profile %q", profileName)
+	} else {
+		fileProfile, err = config.GetActiveProfile()
+		if err != nil {
+			return nil, nil, err
+		}
 	}
 	envProfile := scw.LoadEnvProfile()
 
@@ -221,17 +596,45 @@ func loadProfile(d *schema.ResourceData) (*scw.Profile, error) {
 			providerProfile.DefaultProjectID = scw.StringPtr(projectID.(string))
 		}
 		if region, exist := d.GetOk("region"); exist {
-			providerProfile.DefaultRegion = scw.StringPtr(region.(string))
+			providerProfile.DefaultRegion = scw.StringPtr(strings.ToLower(region.(string)))
 		}
 		if zone, exist := d.GetOk("zone"); exist {
-			providerProfile.DefaultZone = scw.StringPtr(zone.(string))
+			providerProfile.DefaultZone = scw.StringPtr(strings.ToLower(zone.(string)))
 		}
 		if apiURL, exist := d.GetOk("api_url"); exist {
 			providerProfile.APIURL = scw.StringPtr(apiURL.(string))
 		}
 	}
 
-	profile := scw.MergeProfiles(defaultZoneProfile, activeProfile, providerProfile, envProfile)
+	// Merge order, the last one has priority:
+	// default < configuration file < provider attributes < environment variables
+	// When a profile is explicitly selected, it has priority over the environment variables
+	// so that provider aliases can use different profiles.
+	type profileLayer struct {
+		profile *scw.Profile
+		source  string
+	}
+	layers := []profileLayer{
+		{defaultZoneProfile, "provider defaults"},
+		{fileProfile, fileSource},
+		{providerProfile, "provider attributes"},
+		{envProfile, "environment variables"},
+	}
+	if profileName != "" {
+		layers = []profileLayer{
+			{defaultZoneProfile, "provider defaults"},
+			{envProfile, "environment variables"},
+			{fileProfile, fileSource},
+			{providerProfile, "provider attributes"},
+		}
+	}
+
+	profile := &scw.Profile{}
+	sources := profileSources{}
+	for _, layer := range layers {
+		profile = scw.MergeProfiles(profile, layer.profile)
+		sources.record(layer.profile, layer.source)
+	}
 
 	// If profile have a defaultZone but no defaultRegion we set the defaultRegion
 	// to the one of the defaultZone
@@ -239,12 +642,59 @@ func loadProfile(d *schema.ResourceData) (*scw.Profile, error) {
 		(profile.DefaultRegion == nil || *profile.DefaultRegion == "") {
 		zone := scw.Zone(*profile.DefaultZone)
 		l.Debugf("guess region from %s zone", zone)
-		region, err := zone.Region()
+		region, err := localities.zoneRegion(zone)
 		if err == nil {
 			profile.DefaultRegion = scw.StringPtr(region.String())
+			sources["region"] = fmt.Sprintf("zone %s", zone)
 		} else {
 			l.Debugf("cannot guess region: %w", err)
 		}
 	}
-	return profile, nil
+	return profile, sources, nil
+}
+
+// validateProfile validates the format of the loaded profile values.
+// The returned error names the source of every invalid value.
+func validateProfile(profile *scw.Profile, sources profileSources, localities *localities) error {
+	var errs []string
+	invalid := func(attribute string, format string, args ...interface{}) {
+		errs = append(errs, fmt.Sprintf("%s (from %s) %s", attribute, sources[attribute], fmt.Sprintf(format, args...)))
+	}
+
+	if profile.SecretKey != nil && *profile.SecretKey != "" {
+		if profile.AccessKey == nil || *profile.AccessKey == "" {
+			errs = append(errs, fmt.Sprintf("access_key is missing while secret_key is set (from %s)", sources["secret_key"]))
+		}
+		if !sdkValidation.IsSecretKey(*profile.SecretKey) {
+			invalid("secret_key", "is not a valid secret key, expected a UUID: xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxxx")
+		}
+	}
+	if profile.AccessKey != nil && *profile.AccessKey != "" && !sdkValidation.IsAccessKey(*profile.AccessKey) {
+		invalid("access_key", "%q is not a valid access key, expected SCWXXXXXXXXXXXXXXXXX format", *profile.AccessKey)
+	}
+	if profile.DefaultProjectID != nil && !sdkValidation.IsProjectID(*profile.DefaultProjectID) {
+		invalid("project_id", "%q is not a valid project ID, expected a UUID: xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxxx", *profile.DefaultProjectID)
+	}
+	if profile.DefaultRegion != nil && !sdkValidation.IsRegion(*profile.DefaultRegion) {
+		invalid("region", "%q is not a valid region", *profile.DefaultRegion)
+	} else if profile.DefaultRegion != nil {
+		if err := localities.validateRegion(scw.Region(*profile.DefaultRegion)); err != nil {
+			invalid("region", "is invalid: %s", err)
+		}
+	}
+	if profile.DefaultZone != nil && !sdkValidation.IsZone(*profile.DefaultZone) {
+		invalid("zone", "%q is not a valid zone", *profile.DefaultZone)
+	} else if profile.DefaultZone != nil {
+		if err := localities.validateZone(scw.Zone(*profile.DefaultZone)); err != nil {
+			invalid("zone", "is invalid: %s", err)
+		}
+	}
+	if profile.APIURL != nil && !sdkValidation.IsURL(*profile.APIURL) {
+		invalid("api_url", "%q is not a valid URL", *profile.APIURL)
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("invalid scaleway provider configuration:\n  - %s\nconfiguration sources: %s", strings.Join(errs, "\n  - "), sources)
+	}
+	return nil
 }
@@ -3,6 +3,8 @@ package scaleway
 import (
 	"context"
 	"flag"
+	"fmt"
+	"io/ioutil"
 	"net/http"
 	"os"
 	"path/filepath"
@@ -10,9 +12,10 @@ import (
 	"strings"
 	"testing"
 
-	"github.com/dnaeon/go-vcr/cassette"
 	"github.com/dnaeon/go-vcr/recorder"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/scaleway/scaleway-sdk-go/scw"
 	"github.com/scaleway/scaleway-sdk-go/strcase"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -21,6 +24,8 @@ import (
 var (
 	// UpdateCassettes will update all cassettes of a given test
 	UpdateCassettes = flag.Bool("cassettes", os.Getenv("TF_UPDATE_CASSETTES") == "true", "Record Cassettes")
+	// ScrubCassettes will scrub the sensitive values of the recorded cassettes again
+	ScrubCassettes = flag.Bool("scrub-cassettes", false, "Scrub Cassettes")
 )
 
 func testAccPreCheck(_ *testing.T) {}
@@ -47,29 +52,46 @@ func getTestFilePath(t *testing.T, suffix string) string {
 // This cassette is then replayed whenever tests are executed again. This means that once the
 // requests are recorded in the cassette, no more real HTTP requests must be made to run the tests.
 //
+// Sensitive values are replaced by placeholders in the cassette as configured by scrubberConfig and the
+// requests are matched on their method, URL and JSON body.
+//
 // It is important to add a `defer cleanup()` so the given cassette files are correctly
 // closed and saved after the requests.
-func getHTTPRecoder(t *testing.T, update bool) (client *http.Client, cleanup func(), err error) {
+func getHTTPRecoder(t *testing.T, update bool, scrubberConfig *cassetteScrubberConfig) (client *http.Client, cleanup func(), err error) {
 	recorderMode := recorder.ModeReplaying
 	if update {
 		recorderMode = recorder.ModeRecording
 	}
 
+	// The recorder silently records real requests when the cassette of a replayed test is missing.
+	cassettePath := getTestFilePath(t, ".cassette")
+	if _, err := os.Stat(cassettePath + ".yaml"); recorderMode == recorder.ModeReplaying && os.Getenv(resource.TestEnvVar) != "" && os.IsNotExist(err) {
+		return nil, nil, fmt.Errorf("cassette %s.yaml is missing, record it with TF_UPDATE_CASSETTES=true", cassettePath)
+	}
+
 	// Setup recorder and scw client
-	r, err := recorder.NewAsMode(getTestFilePath(t, ".cassette"), recorderMode, nil)
+	r, err := recorder.NewAsMode(cassettePath, recorderMode, nil)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	// Add a filter which removes Authorization headers from all requests:
-	r.AddFilter(func(i *cassette.Interaction) error {
-		i.Request.Headers = i.Request.Headers.Clone()
-		delete(i.Request.Headers, "x-auth-token")
-		delete(i.Request.Headers, "X-Auth-Token")
-		return nil
-	})
+	// The values of the test configuration are scrubbed from the cassettes and restored at replay.
+	profile, _, err := loadProfile(nil, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	scrubber := newCassetteScrubber(scrubberConfig, profile)
+
+	// Sensitive values are scrubbed when the cassette is saved so recorded tests use the real values.
+	r.AddSaveFilter(scrubber.scrubInteraction)
+	r.SetMatcher(scrubber.matcher)
+
+	transport := http.RoundTripper(r)
+	if r.Mode() == recorder.ModeReplaying {
+		transport = &cassetteRestoringTransport{transport: r, scrubber: scrubber}
+	}
 
-	return &http.Client{Transport: newRetryableTransport(r)}, func() {
+	return &http.Client{Transport: newRetryableTransport(transport, defaultRetryConfig())}, func() {
 		assert.NoError(t, r.Stop()) // Make sure recorder is stopped once done with it
 	}, nil
 }
@@ -84,7 +106,7 @@ type TestTools struct {
 
 func NewTestTools(t *testing.T) *TestTools {
 	// Create an http client with recording capabilities
-	httpClient, cleanup, err := getHTTPRecoder(t, *UpdateCassettes)
+	httpClient, cleanup, err := getHTTPRecoder(t, *UpdateCassettes, defaultCassetteScrubberConfig())
 	require.NoError(t, err)
 
 	// Create meta that will be passed in the provider config
@@ -107,3 +129,167 @@ func NewTestTools(t *testing.T) *TestTools {
 		ctx:     context.Background(),
 	}
 }
+
+// setTestEnv sets the given environment variables and unsets the other scaleway ones.
+// It returns a function restoring the previous environment.
+func setTestEnv(t *testing.T, env map[string]string) func() {
+	previous := map[string]string{}
+	for _, key := range []string{
+		scw.ScwConfigPathEnv,
+		scw.ScwActiveProfileEnv,
+		scw.ScwAccessKeyEnv,
+		scw.ScwSecretKeyEnv,
+		scw.ScwDefaultProjectIDEnv,
+		scw.ScwDefaultRegionEnv,
+		scw.ScwDefaultZoneEnv,
+		scw.ScwAPIURLEnv,
+		s3AccessKeyEnv,
+		s3SecretKeyEnv,
+	} {
+		if value, exist := os.LookupEnv(key); exist {
+			previous[key] = value
+		}
+		require.NoError(t, os.Unsetenv(key))
+	}
+	for key, value := range env {
+		require.NoError(t, os.Setenv(key, value))
+	}
+
+	return func() {
+		for key := range env {
+			_ = os.Unsetenv(key)
+		}
+		for key, value := range previous {
+			_ = os.Setenv(key, value)
+		}
+	}
+}
+
+func TestLoadProfile(t *testing.T) {
+	configFile, err := ioutil.TempFile("", "scw-config-*.yaml")
+	require.NoError(t, err)
+	defer os.Remove(configFile.Name())
+	_, err = configFile.WriteString(`
+access_key: SCWAAAAAAAAAAAAAAAAA
+secret_key: 11111111-1111-1111-1111-111111111111
+profiles:
+  staging:
+    access_key: SCWBBBBBBBBBBBBBBBBB
+    default_zone: nl-ams-1
+`)
+	require.NoError(t, err)
+	require.NoError(t, configFile.Close())
+
+	testCases := []struct {
+		name            string
+		attributes      map[string]interface{}
+		env             map[string]string
+		accessKey       string
+		zone            string
+		accessKeySource string
+		err             string
+	}{
+		{
+			name:            "active profile",
+			accessKey:       "SCWAAAAAAAAAAAAAAAAA",
+			zone:            "fr-par-1",
+			accessKeySource: "scaleway configuration file active profile",
+		},
+		{
+			name:            "named profile",
+			attributes:      map[string]interface{}{"profile": "staging"},
+			accessKey:       "SCWBBBBBBBBBBBBBBBBB",
+			zone:            "nl-ams-1",
+			accessKeySource: `scaleway configuration file profile "staging"`,
+		},
+		{
+			name:            "named profile has priority over env",
+			attributes:      map[string]interface{}{"profile": "staging"},
+			env:             map[string]string{scw.ScwAccessKeyEnv: "SCWCCCCCCCCCCCCCCCCC"},
+			accessKey:       "SCWBBBBBBBBBBBBBBBBB",
+			zone:            "nl-ams-1",
+			accessKeySource: `scaleway configuration file profile "staging"`,
+		},
+		{
+			name:            "attributes have priority over named profile",
+			attributes:      map[string]interface{}{"profile": "staging", "access_key": "SCWDDDDDDDDDDDDDDDDD"},
+			accessKey:       "SCWDDDDDDDDDDDDDDDDD",
+			zone:            "nl-ams-1",
+			accessKeySource: "provider attributes",
+		},
+		{
+			name:            "env has priority over active profile",
+			env:             map[string]string{scw.ScwAccessKeyEnv: "SCWCCCCCCCCCCCCCCCCC"},
+			accessKey:       "SCWCCCCCCCCCCCCCCCCC",
+			zone:            "fr-par-1",
+			accessKeySource: "environment variables",
+		},
+		{
+			name:            "env has priority over attributes without named profile",
+			attributes:      map[string]interface{}{"access_key": "SCWDDDDDDDDDDDDDDDDD", "zone": "fr-par-2"},
+			env:             map[string]string{scw.ScwAccessKeyEnv: "SCWCCCCCCCCCCCCCCCCC", scw.ScwDefaultZoneEnv: "nl-ams-1"},
+			accessKey:       "SCWCCCCCCCCCCCCCCCCC",
+			zone:            "nl-ams-1",
+			accessKeySource: "environment variables",
+		},
+		{
+			name:            "attributes have priority over env with named profile",
+			attributes:      map[string]interface{}{"profile": "staging", "access_key": "SCWDDDDDDDDDDDDDDDDD", "zone": "fr-par-2"},
+			env:             map[string]string{scw.ScwAccessKeyEnv: "SCWCCCCCCCCCCCCCCCCC", scw.ScwDefaultZoneEnv: "nl-ams-1"},
+			accessKey:       "SCWDDDDDDDDDDDDDDDDD",
+			zone:            "fr-par-2",
+			accessKeySource: "provider attributes",
+		},
+		{
+			name:       "unknown profile",
+			attributes: map[string]interface{}{"profile": "unknown"},
+			err:        `cannot load profile "unknown" from the scaleway configuration file`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			env := map[string]string{scw.ScwConfigPathEnv: configFile.Name()}
+			for key, value := range tc.env {
+				env[key] = value
+			}
+			defer setTestEnv(t, env)()
+
+			d := schema.TestResourceDataRaw(t, Provider(DefaultProviderConfig())().Schema, tc.attributes)
+			profile, sources, err := loadProfile(d, nil)
+			if tc.err != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.accessKey, *profile.AccessKey)
+			assert.Equal(t, tc.zone, *profile.DefaultZone)
+			assert.Equal(t, tc.accessKeySource, sources["access_key"])
+			assert.NoError(t, validateProfile(profile, sources, nil))
+		})
+	}
+}
+
+func TestValidateProfile(t *testing.T) {
+	profile := &scw.Profile{
+		AccessKey: scw.StringPtr("invalid"),
+		SecretKey: scw.StringPtr("11111111-1111-1111-1111-111111111111"),
+		APIURL:    scw.StringPtr("https://api.scaleway.com"),
+	}
+	sources := profileSources{
+		"access_key": "environment variables",
+		"secret_key": "provider attributes",
+		"api_url":    "provider defaults",
+	}
+
+	err := validateProfile(profile, sources, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `access_key (from environment variables) "invalid" is not a valid access key`)
+	assert.Contains(t, err.Error(), "secret_key from provider attributes")
+	assert.NotContains(t, err.Error(), "11111111-1111-1111-1111-111111111111")
+}
+
+func TestProviderInternalValidate(t *testing.T) {
+	require.NoError(t, Provider(DefaultProviderConfig())().InternalValidate())
+}
@@ -0,0 +1,206 @@
+package scaleway
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// rateLimitProducts are the products that can be rate limited.
+var rateLimitProducts = []string{
+	"account",
+	"apple-silicon",
+	"baremetal",
+	"instance",
+	"iot",
+	"k8s",
+	"lb",
+	"marketplace",
+	"rdb",
+	"registry",
+	"s3",
+	"vpc",
+}
+
+// rateLimitConfig is the client side rate limit applied to the requests of a product.
+type rateLimitConfig struct {
+	// requestsPerSecond is the rate at which tokens are added to the bucket. 0 means no rate limit.
+	requestsPerSecond float64
+	// burst is the maximum number of tokens in the bucket.
+	burst int
+	// maxConcurrentRequests is the maximum number of in-flight requests. 0 means no limit.
+	maxConcurrentRequests int
+}
+
+// tokenBucket is a concurrency-safe token bucket rate limiter.
+type tokenBucket struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+func newTokenBucket(rate float64, burst int) *tokenBucket {
+	if burst < 1 {
+		burst = 1
+	}
+	return &tokenBucket{
+		rate:   rate,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   time.Now(),
+	}
+}
+
+// wait blocks until a token is available or the context is done.
+func (b *tokenBucket) wait(ctx context.Context) error {
+	for {
+		b.mu.Lock()
+		now := time.Now()
+		b.tokens += now.Sub(b.last).Seconds() * b.rate
+		if b.tokens > b.burst {
+			b.tokens = b.burst
+		}
+		b.last = now
+
+		if b.tokens >= 1 {
+			b.tokens--
+			b.mu.Unlock()
+			return nil
+		}
+		delay := time.Duration((1 - b.tokens) / b.rate * float64(time.Second))
+		b.mu.Unlock()
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// productLimiter limits the rate and the concurrency of the requests of a product.
+type productLimiter struct {
+	bucket    *tokenBucket
+	semaphore chan struct{}
+}
+
+// acquire waits for the limiter to allow a request. The returned function must be called once the request is done.
+func (p *productLimiter) acquire(ctx context.Context) (func(), error) {
+	if p.semaphore != nil {
+		select {
+		case p.semaphore <- struct{}{}:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	release := func() {
+		if p.semaphore != nil {
+			<-p.semaphore
+		}
+	}
+
+	if p.bucket != nil {
+		if err := p.bucket.wait(ctx); err != nil {
+			release()
+			return nil, err
+		}
+	}
+
+	return release, nil
+}
+
+// rateLimitedTransport is a http transport applying client side rate limits per product.
+type rateLimitedTransport struct {
+	transport http.RoundTripper
+	limiters  map[string]*productLimiter
+}
+
+// newRateLimitedTransport creates a http transport limiting the requests of the configured products.
+// If no limit is configured the given transport is returned as is.
+func newRateLimitedTransport(transport http.RoundTripper, limits map[string]*rateLimitConfig) http.RoundTripper {
+	if len(limits) == 0 {
+		return transport
+	}
+
+	limiters := make(map[string]*productLimiter, len(limits))
+	for product, limit := range limits {
+		limiter := &productLimiter{}
+		if limit.requestsPerSecond > 0 {
+			limiter.bucket = newTokenBucket(limit.requestsPerSecond, limit.burst)
+		}
+		if limit.maxConcurrentRequests > 0 {
+			limiter.semaphore = make(chan struct{}, limit.maxConcurrentRequests)
+		}
+		l.Debugf("rate limit for %s: %v requests per second, burst of %d, %d concurrent requests", product, limit.requestsPerSecond, limit.burst, limit.maxConcurrentRequests)
+		limiters[product] = limiter
+	}
+
+	return &rateLimitedTransport{
+		transport: transport,
+		limiters:  limiters,
+	}
+}
+
+// RoundTrip waits for the limiter of the request product before sending the request.
+func (t *rateLimitedTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	product := requestProduct(r)
+	limiter, exist := t.limiters[product]
+	if !exist {
+		return t.transport.RoundTrip(r)
+	}
+
+	start := time.Now()
+	release, err := limiter.acquire(r.Context())
+	if err != nil {
+		return nil, err
+	}
+
+	if waited := time.Since(start); waited > time.Millisecond {
+		l.Debugf("rate limit: %s %s waited %s", product, r.URL.Path, waited.Round(time.Millisecond))
+	}
+
+	resp, err := t.transport.RoundTrip(r)
+	if err != nil || resp.Body == nil {
+		release()
+		return resp, err
+	}
+	// The request is in flight until its body is read, keep the concurrency slot until then.
+	resp.Body = &releaseOnCloseBody{ReadCloser: resp.Body, release: release}
+	return resp, nil
+}
+
+// releaseOnCloseBody is a response body calling release once it is closed.
+type releaseOnCloseBody struct {
+	io.ReadCloser
+	release func()
+	once    sync.Once
+}
+
+func (b *releaseOnCloseBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.once.Do(b.release)
+	return err
+}
+
+// requestProduct returns the Scaleway product targeted by a request.
+//
+// Object storage requests are sent to s3.<region>.scw.cloud or <bucket>.s3.<region>.scw.cloud,
+// other APIs are prefixed by the product name, eg /instance/v1/zones/fr-par-1/servers.
+// Requests sent to an overridden endpoint carry their product in their context.
+func requestProduct(r *http.Request) string {
+	if product, ok := r.Context().Value(requestProductKey{}).(string); ok {
+		return product
+	}
+	if host := r.URL.Hostname(); strings.HasPrefix(host, "s3.") || strings.Contains(host, ".s3.") {
+		return "s3"
+	}
+	parts := strings.SplitN(strings.TrimPrefix(r.URL.Path, "/"), "/", 2)
+	return parts[0]
+}
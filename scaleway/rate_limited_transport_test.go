@@ -0,0 +1,222 @@
+package scaleway
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequestProduct(t *testing.T) {
+	testCases := map[string]string{
+		"https://api.scaleway.com/instance/v1/zones/fr-par-1/servers": "instance",
+		"https://api.scaleway.com/k8s/v1/regions/fr-par/clusters":     "k8s",
+		"https://s3.fr-par.scw.cloud/my-bucket":                       "s3",
+		"https://my-bucket.s3.nl-ams.scw.cloud/?tagging":              "s3",
+	}
+
+	for url, product := range testCases {
+		req, err := http.NewRequest(http.MethodGet, url, nil)
+		require.NoError(t, err)
+		assert.Equal(t, product, requestProduct(req), url)
+	}
+}
+
+func TestTokenBucket(t *testing.T) {
+	bucket := newTokenBucket(20, 2)
+
+	start := time.Now()
+	for i := 0; i < 4; i++ {
+		require.NoError(t, bucket.wait(context.Background()))
+	}
+	// The 2 first requests use the burst, the 2 others wait 50ms each.
+	assert.True(t, time.Since(start) >= 90*time.Millisecond)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.Error(t, bucket.wait(ctx))
+}
+
+func TestRateLimitedTransportConcurrency(t *testing.T) {
+	var inFlight, maxInFlight int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		current := atomic.AddInt32(&inFlight, 1)
+		for {
+			max := atomic.LoadInt32(&maxInFlight)
+			if current <= max || atomic.CompareAndSwapInt32(&maxInFlight, max, current) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+		atomic.AddInt32(&inFlight, -1)
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: newRateLimitedTransport(http.DefaultTransport, map[string]*rateLimitConfig{
+		"instance": {maxConcurrentRequests: 2},
+	})}
+
+	wg := sync.WaitGroup{}
+	for i := 0; i < 6; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			resp, err := client.Get(server.URL + "/instance/v1/zones/fr-par-1/servers")
+			if assert.NoError(t, err) {
+				resp.Body.Close()
+			}
+		}()
+	}
+	wg.Wait()
+
+	assert.LessOrEqual(t, maxInFlight, int32(2))
+}
+
+func TestRateLimitedTransportReleaseOnBodyClose(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"servers":[]}`))
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: newRateLimitedTransport(http.DefaultTransport, map[string]*rateLimitConfig{
+		"instance": {maxConcurrentRequests: 1},
+	})}
+	get := func(ctx context.Context) (*http.Response, error) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+"/instance/v1/zones/fr-par-1/servers", nil)
+		require.NoError(t, err)
+		return client.Do(req)
+	}
+
+	first, err := get(context.Background())
+	require.NoError(t, err)
+
+	// The body of the first response is not read yet, the request is still in flight.
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err = get(ctx)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), context.DeadlineExceeded.Error())
+
+	require.NoError(t, first.Body.Close())
+	second, err := get(context.Background())
+	require.NoError(t, err)
+	require.NoError(t, second.Body.Close())
+}
+
+// testBuildMetaWithTransports returns the meta of a provider sending its requests through the full transport chain.
+func testBuildMetaWithTransports(t *testing.T, config map[string]interface{}) *Meta {
+	raw := map[string]interface{}{
+		"access_key": fakeAPIAccessKey,
+		"secret_key": fakeAPISecretKey,
+		"project_id": fakeAPIProjectID,
+		"region":     scw.RegionFrPar.String(),
+		"zone":       scw.ZoneFrPar1.String(),
+	}
+	for key, value := range config {
+		raw[key] = value
+	}
+	meta, err := buildMeta(&MetaConfig{
+		providerSchema:   schema.TestResourceDataRaw(t, Provider(DefaultProviderConfig())().Schema, raw),
+		terraformVersion: "terraform-tests",
+	})
+	require.NoError(t, err)
+	return meta
+}
+
+func TestRateLimitedTransportEndpointProduct(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	// The product of requests rewritten to an endpoint with a path prefix cannot be read from their URL anymore.
+	meta := testBuildMetaWithTransports(t, map[string]interface{}{
+		"endpoints":  []interface{}{map[string]interface{}{"instance": server.URL + "/proxy/"}},
+		"rate_limit": []interface{}{map[string]interface{}{"product": "instance", "requests_per_second": 0.001, "burst": 1}},
+	})
+	get := func(ctx context.Context) error {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, "https://api.scaleway.invalid/instance/v1/zones/fr-par-1/servers", nil)
+		require.NoError(t, err)
+		resp, err := meta.httpClient.Do(req)
+		if err != nil {
+			return err
+		}
+		return resp.Body.Close()
+	}
+
+	require.NoError(t, get(context.Background()))
+
+	// The burst is used, the next request of the product waits for a token.
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	err := get(ctx)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), context.DeadlineExceeded.Error())
+}
+
+func TestRateLimitedTransportCancel(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"servers":[]}`))
+	}))
+	defer server.Close()
+
+	meta := testBuildMetaWithTransports(t, map[string]interface{}{
+		"api_url":    server.URL,
+		"rate_limit": []interface{}{map[string]interface{}{"product": "instance", "max_concurrent_requests": 1}},
+	})
+	get := func(ctx context.Context) (*http.Response, error) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+"/instance/v1/zones/fr-par-1/servers", nil)
+		require.NoError(t, err)
+		return meta.httpClient.Do(req)
+	}
+
+	first, err := get(context.Background())
+	require.NoError(t, err)
+	defer first.Body.Close()
+
+	// A cancelled request stops waiting for the concurrency slot held by the first one.
+	ctx, cancel := context.WithCancel(context.Background())
+	errs := make(chan error, 1)
+	go func() {
+		_, err := get(ctx)
+		errs <- err
+	}()
+	time.Sleep(20 * time.Millisecond)
+	cancel()
+	select {
+	case err := <-errs:
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), context.Canceled.Error())
+	case <-time.After(5 * time.Second):
+		t.Fatal("the cancelled request is still waiting for a concurrency slot")
+	}
+}
+
+func TestLoadRateLimits(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, Provider(DefaultProviderConfig())().Schema, map[string]interface{}{
+		"rate_limit": []interface{}{
+			map[string]interface{}{"product": "instance", "requests_per_second": 5.0},
+			map[string]interface{}{"product": "lb", "max_concurrent_requests": 2},
+		},
+	})
+	limits, err := loadRateLimits(d)
+	require.NoError(t, err)
+	assert.Equal(t, 5.0, limits["instance"].requestsPerSecond)
+	assert.Equal(t, 2, limits["lb"].maxConcurrentRequests)
+
+	d = schema.TestResourceDataRaw(t, Provider(DefaultProviderConfig())().Schema, map[string]interface{}{
+		"rate_limit": []interface{}{
+			map[string]interface{}{"product": "instance", "requests_per_second": 5.0},
+			map[string]interface{}{"product": "instance", "max_concurrent_requests": 2},
+		},
+	})
+	_, err = loadRateLimits(d)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "rate_limit: product instance is limited more than once")
+}
@@ -0,0 +1,52 @@
+package scaleway
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// readOnlyTransport is a http transport refusing every request that may modify a resource.
+// Only GET and HEAD requests are sent, to Scaleway APIs as well as object storage.
+type readOnlyTransport struct {
+	transport http.RoundTripper
+}
+
+// newReadOnlyTransport creates a http transport only letting read requests through the given transport.
+func newReadOnlyTransport(transport http.RoundTripper) http.RoundTripper {
+	return &readOnlyTransport{transport: transport}
+}
+
+// RoundTrip sends read requests and fails any other one without sending it.
+func (t *readOnlyTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	if r.Method == http.MethodGet || r.Method == http.MethodHead {
+		return t.transport.RoundTrip(r)
+	}
+
+	if r.Body != nil {
+		_ = r.Body.Close()
+	}
+	l.Warningf("read-only mode: refused %s %s", r.Method, redactURL(r.URL))
+	return nil, fmt.Errorf("read-only mode: refusing to %s %s resource %s, set read_only = false in the provider configuration to allow changes", r.Method, requestProduct(r), requestResource(r))
+}
+
+// requestResource returns the resource targeted by a request made by the provider.
+//
+// For object storage this is the bucket and object key, eg my-bucket/my/key.
+// For other APIs this is the path following the locality, eg servers/11111111-1111-1111-1111-111111111111/action.
+func requestResource(r *http.Request) string {
+	path := strings.TrimPrefix(r.URL.Path, "/")
+	if requestProduct(r) == "s3" {
+		if host := r.URL.Hostname(); strings.Contains(host, ".s3.") {
+			return strings.TrimSuffix(host[:strings.Index(host, ".s3.")]+"/"+path, "/")
+		}
+		return strings.TrimSuffix(path, "/")
+	}
+
+	// /{product}/{version}/{zones|regions}/{locality}/{resource}
+	parts := strings.SplitN(path, "/", 5)
+	if len(parts) == 5 && (parts[2] == "zones" || parts[2] == "regions") {
+		return parts[4]
+	}
+	return path
+}
@@ -0,0 +1,57 @@
+package scaleway
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequestResource(t *testing.T) {
+	testCases := map[string]string{
+		"https://api.scaleway.com/instance/v1/zones/fr-par-1/servers":                                             "servers",
+		"https://api.scaleway.com/instance/v1/zones/fr-par-1/servers/11111111-1111-1111-1111-111111111111/action": "servers/11111111-1111-1111-1111-111111111111/action",
+		"https://api.scaleway.com/account/v2alpha1/ssh-keys/11111111-1111-1111-1111-111111111111":                 "account/v2alpha1/ssh-keys/11111111-1111-1111-1111-111111111111",
+		"https://s3.fr-par.scw.cloud/my-bucket/":                                                                  "my-bucket",
+		"https://s3.fr-par.scw.cloud/my-bucket/my/key":                                                            "my-bucket/my/key",
+		"https://my-bucket.s3.nl-ams.scw.cloud/?tagging":                                                          "my-bucket",
+		"https://my-bucket.s3.nl-ams.scw.cloud/my/key":                                                            "my-bucket/my/key",
+	}
+
+	for url, resource := range testCases {
+		req, err := http.NewRequest(http.MethodGet, url, nil)
+		require.NoError(t, err)
+		assert.Equal(t, resource, requestResource(req), url)
+	}
+}
+
+func TestReadOnlyTransport(t *testing.T) {
+	methods := []string(nil)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		methods = append(methods, r.Method)
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: newReadOnlyTransport(http.DefaultTransport)}
+
+	for _, method := range []string{http.MethodGet, http.MethodHead} {
+		req, err := http.NewRequest(method, server.URL+"/instance/v1/zones/fr-par-1/servers", nil)
+		require.NoError(t, err)
+		resp, err := client.Do(req)
+		require.NoError(t, err)
+		_ = resp.Body.Close()
+	}
+
+	for _, method := range []string{http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete} {
+		req, err := http.NewRequest(method, server.URL+"/instance/v1/zones/fr-par-1/servers/11111111-1111-1111-1111-111111111111", strings.NewReader("{}"))
+		require.NoError(t, err)
+		_, err = client.Do(req)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "read-only mode: refusing to "+method+" instance resource servers/11111111-1111-1111-1111-111111111111")
+	}
+
+	assert.Equal(t, []string{http.MethodGet, http.MethodHead}, methods)
+}
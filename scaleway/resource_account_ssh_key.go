@@ -53,7 +53,7 @@ func resourceScalewayAccountSSHKeyCreate(ctx context.Context, d *schema.Resource
 		ProjectID: expandStringPtr(d.Get("project_id")),
 	}, scw.WithContext(ctx))
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	d.SetId(res.ID)
@@ -72,7 +72,7 @@ func resourceScalewayAccountSSHKeyRead(ctx context.Context, d *schema.ResourceDa
 			d.SetId("")
 			return nil
 		}
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	_ = d.Set("name", res.Name)
@@ -92,7 +92,7 @@ func resourceScalewayAccountSSHKeyUpdate(ctx context.Context, d *schema.Resource
 			Name:     expandStringPtr(d.Get("name")),
 		}, scw.WithContext(ctx))
 		if err != nil {
-			return diag.FromErr(err)
+			return diagFromErr(err)
 		}
 	}
 
@@ -106,7 +106,7 @@ func resourceScalewayAccountSSHKeyDelete(ctx context.Context, d *schema.Resource
 		SSHKeyID: d.Id(),
 	}, scw.WithContext(ctx))
 	if err != nil && !is404Error(err) {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	return nil
@@ -29,8 +29,10 @@ func testSweepAccountSSHKey(_ string) error {
 		}
 
 		for _, sshKey := range listSSHKeys.SSHKeys {
-			err := accountAPI.DeleteSSHKey(&account.DeleteSSHKeyRequest{
-				SSHKeyID: sshKey.ID,
+			err := sweep(&sweptResource{kind: "SSH key", id: sshKey.ID, name: sshKey.Name, createdAt: sshKey.CreatedAt}, func() error {
+				return accountAPI.DeleteSSHKey(&account.DeleteSSHKeyRequest{
+					SSHKeyID: sshKey.ID,
+				})
 			})
 			if err != nil {
 				return fmt.Errorf("error deleting SSH key in sweeper: %s", err)
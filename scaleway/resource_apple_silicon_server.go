@@ -11,7 +11,7 @@ import (
 )
 
 func resourceScalewayAppleSiliconServer() *schema.Resource {
-	return &schema.Resource{
+	return withLocalizedIDsStateUpgrade(&schema.Resource{
 		CreateContext: resourceScalewayAppleSiliconServerCreate,
 		ReadContext:   resourceScalewayAppleSiliconServerRead,
 		UpdateContext: resourceScalewayAppleSiliconServerUpdate,
@@ -70,13 +70,15 @@ func resourceScalewayAppleSiliconServer() *schema.Resource {
 			"organization_id": organizationIDSchema(),
 			"project_id":      projectIDSchema(),
 		},
-	}
+	}, map[string]string{
+		"id": "zone",
+	})
 }
 
 func resourceScalewayAppleSiliconServerCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	asAPI, zone, err := asAPIWithZone(d, meta)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	createReq := &applesilicon.CreateServerRequest{
@@ -87,18 +89,18 @@ func resourceScalewayAppleSiliconServerCreate(ctx context.Context, d *schema.Res
 
 	res, err := asAPI.CreateServer(createReq, scw.WithContext(ctx))
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	d.SetId(newZonedIDString(zone, res.ID))
 
 	_, err = asAPI.WaitForServer(&applesilicon.WaitForServerRequest{
 		ServerID:      res.ID,
-		Timeout:       scw.TimeDurationPtr(defaultAppleSiliconServerTimeout),
+		Timeout:       scw.TimeDurationPtr(d.Timeout(schema.TimeoutCreate)),
 		RetryInterval: nil,
 	}, scw.WithContext(ctx))
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	return resourceScalewayRdbInstanceRead(ctx, d, meta)
@@ -107,7 +109,7 @@ func resourceScalewayAppleSiliconServerCreate(ctx context.Context, d *schema.Res
 func resourceScalewayAppleSiliconServerRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	asAPI, zone, ID, err := asAPIWithZoneAndID(meta, d.Id())
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	res, err := asAPI.GetServer(&applesilicon.GetServerRequest{
@@ -119,7 +121,7 @@ func resourceScalewayAppleSiliconServerRead(ctx context.Context, d *schema.Resou
 			d.SetId("")
 			return nil
 		}
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	_ = d.Set("name", res.Name)
@@ -140,7 +142,7 @@ func resourceScalewayAppleSiliconServerRead(ctx context.Context, d *schema.Resou
 func resourceScalewayAppleSiliconServerUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	asAPI, zone, ID, err := asAPIWithZoneAndID(meta, d.Id())
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	req := &applesilicon.UpdateServerRequest{
@@ -154,7 +156,7 @@ func resourceScalewayAppleSiliconServerUpdate(ctx context.Context, d *schema.Res
 
 	_, err = asAPI.UpdateServer(req, scw.WithContext(ctx))
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	return resourceScalewayAppleSiliconServerRead(ctx, d, meta)
@@ -163,7 +165,7 @@ func resourceScalewayAppleSiliconServerUpdate(ctx context.Context, d *schema.Res
 func resourceScalewayAppleSiliconServerDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	asAPI, zone, ID, err := asAPIWithZoneAndID(meta, d.Id())
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	err = asAPI.DeleteServer(&applesilicon.DeleteServerRequest{
@@ -172,7 +174,7 @@ func resourceScalewayAppleSiliconServerDelete(ctx context.Context, d *schema.Res
 	}, scw.WithContext(ctx))
 
 	if err != nil && !is404Error(err) {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	return nil
@@ -18,20 +18,24 @@ func init() {
 }
 
 func testSweepAppleSiliconServer(_ string) error {
-	return sweepZones(scw.AllZones, func(scwClient *scw.Client, zone scw.Zone) error {
+	return sweepAllZones(func(scwClient *scw.Client, zone scw.Zone) error {
 		asAPI := applesilicon.NewAPI(scwClient)
 		l.Debugf("sweeper: destroying the apple silicon instance in (%s)", zone)
-		listServers, err := asAPI.ListServers(&applesilicon.ListServersRequest{}, scw.WithAllPages())
+		listServers, err := asAPI.ListServers(&applesilicon.ListServersRequest{
+			Zone: zone,
+		}, scw.WithAllPages())
 		if err != nil {
 			return fmt.Errorf("error listing apple silicon servers in (%s) in sweeper: %s", zone, err)
 		}
 
 		for _, server := range listServers.Servers {
-			errDelete := asAPI.DeleteServer(&applesilicon.DeleteServerRequest{
-				ServerID: server.ID,
-				Zone:     zone,
+			err := sweep(&sweptResource{kind: "apple silicon server", id: server.ID, name: server.Name, createdAt: server.CreatedAt}, func() error {
+				return asAPI.DeleteServer(&applesilicon.DeleteServerRequest{
+					ServerID: server.ID,
+					Zone:     zone,
+				})
 			})
-			if errDelete != nil {
+			if err != nil {
 				return fmt.Errorf("error deleting apple silicon server in sweeper: %s", err)
 			}
 		}
@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/scaleway/scaleway-sdk-go/api/baremetal/v1"
@@ -12,7 +13,7 @@ import (
 )
 
 func resourceScalewayBaremetalServer() *schema.Resource {
-	return &schema.Resource{
+	return withLocalizedIDsStateUpgrade(&schema.Resource{
 		CreateContext: resourceScalewayBaremetalServerCreate,
 		ReadContext:   resourceScalewayBaremetalServerRead,
 		UpdateContext: resourceScalewayBaremetalServerUpdate,
@@ -20,6 +21,10 @@ func resourceScalewayBaremetalServer() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
+		CustomizeDiff: customdiff.All(
+			customizeDiffLocalityCheck("zone", "offer_id", "os_id"),
+			customizeDiffTagsAll,
+		),
 		SchemaVersion: 0,
 		Timeouts: &schema.ResourceTimeout{
 			Default: schema.DefaultTimeout(defaultBaremetalServerTimeout),
@@ -81,6 +86,7 @@ func resourceScalewayBaremetalServer() *schema.Resource {
 				Optional:    true,
 				Description: "Array of tags to associate with the server",
 			},
+			"tags_all":        tagsAllSchema(),
 			"zone":            zoneSchema(),
 			"organization_id": organizationIDSchema(),
 			"project_id":      projectIDSchema(),
@@ -117,23 +123,24 @@ func resourceScalewayBaremetalServer() *schema.Resource {
 				Computed: true,
 			},
 		},
-	}
+	}, map[string]string{
+		"id":       "zone",
+		"offer_id": "zone",
+		"os_id":    "zone",
+	})
 }
 
 func resourceScalewayBaremetalServerCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	baremetalAPI, zone, err := baremetalAPIWithZone(d, meta)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	offerID := expandZonedID(d.Get("offer"))
 	if !sdkValidation.IsUUID(offerID.ID) {
-		o, err := baremetalAPI.GetOfferByName(&baremetal.GetOfferByNameRequest{
-			OfferName: offerID.ID,
-			Zone:      zone,
-		})
+		o, err := baremetalOfferByName(ctx, meta, zone, offerID.ID)
 		if err != nil {
-			return diag.FromErr(err)
+			return diagFromErr(err)
 		}
 		offerID = newZonedID(zone, o.ID)
 	}
@@ -144,10 +151,10 @@ func resourceScalewayBaremetalServerCreate(ctx context.Context, d *schema.Resour
 		ProjectID:   expandStringPtr(d.Get("project_id")),
 		Description: d.Get("description").(string),
 		OfferID:     offerID.ID,
-		Tags:        expandStrings(d.Get("tags")),
+		Tags:        expandTags(meta, d.Get("tags")),
 	}, scw.WithContext(ctx))
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	d.SetId(newZonedID(server.Zone, server.ID).String())
@@ -155,10 +162,10 @@ func resourceScalewayBaremetalServerCreate(ctx context.Context, d *schema.Resour
 	_, err = baremetalAPI.WaitForServer(&baremetal.WaitForServerRequest{
 		Zone:     server.Zone,
 		ServerID: server.ID,
-		Timeout:  scw.TimeDurationPtr(baremetalServerWaitForTimeout),
+		Timeout:  scw.TimeDurationPtr(d.Timeout(schema.TimeoutCreate)),
 	})
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	_, err = baremetalAPI.InstallServer(&baremetal.InstallServerRequest{
@@ -169,16 +176,16 @@ func resourceScalewayBaremetalServerCreate(ctx context.Context, d *schema.Resour
 		SSHKeyIDs: expandStrings(d.Get("ssh_key_ids")),
 	}, scw.WithContext(ctx))
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	_, err = baremetalAPI.WaitForServerInstall(&baremetal.WaitForServerInstallRequest{
 		Zone:     server.Zone,
 		ServerID: server.ID,
-		Timeout:  scw.TimeDurationPtr(baremetalServerWaitForTimeout),
+		Timeout:  scw.TimeDurationPtr(d.Timeout(schema.TimeoutCreate)),
 	})
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	return resourceScalewayBaremetalServerRead(ctx, d, meta)
@@ -187,7 +194,7 @@ func resourceScalewayBaremetalServerCreate(ctx context.Context, d *schema.Resour
 func resourceScalewayBaremetalServerRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	baremetalAPI, zonedID, err := baremetalAPIWithZoneAndID(meta, d.Id())
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	server, err := baremetalAPI.GetServer(&baremetal.GetServerRequest{
@@ -199,7 +206,7 @@ func resourceScalewayBaremetalServerRead(ctx context.Context, d *schema.Resource
 			d.SetId("")
 			return nil
 		}
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	offer, err := baremetalAPI.GetOffer(&baremetal.GetOfferRequest{
@@ -207,7 +214,7 @@ func resourceScalewayBaremetalServerRead(ctx context.Context, d *schema.Resource
 		OfferID: server.OfferID,
 	}, scw.WithContext(ctx))
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	_ = d.Set("name", server.Name)
@@ -215,7 +222,8 @@ func resourceScalewayBaremetalServerRead(ctx context.Context, d *schema.Resource
 	_ = d.Set("organization_id", server.OrganizationID)
 	_ = d.Set("project_id", server.ProjectID)
 	_ = d.Set("offer_id", newZonedID(server.Zone, offer.ID).String())
-	_ = d.Set("tags", server.Tags)
+	_ = d.Set("tags", flattenTags(meta, server.Tags, d.Get("tags")))
+	_ = d.Set("tags_all", server.Tags)
 	_ = d.Set("domain", server.Domain)
 	_ = d.Set("ips", flattenBaremetalIPs(server.IPs))
 	if server.Install != nil {
@@ -230,7 +238,7 @@ func resourceScalewayBaremetalServerRead(ctx context.Context, d *schema.Resource
 func resourceScalewayBaremetalServerUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	baremetalAPI, zonedID, err := baremetalAPIWithZoneAndID(meta, d.Id())
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	_, err = baremetalAPI.UpdateServer(&baremetal.UpdateServerRequest{
@@ -238,10 +246,10 @@ func resourceScalewayBaremetalServerUpdate(ctx context.Context, d *schema.Resour
 		ServerID:    zonedID.ID,
 		Name:        expandStringPtr(d.Get("name")),
 		Description: expandStringPtr(d.Get("description")),
-		Tags:        scw.StringsPtr(expandStrings(d.Get("tags"))),
+		Tags:        scw.StringsPtr(expandTags(meta, d.Get("tags"))),
 	}, scw.WithContext(ctx))
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	if d.HasChanges("os", "ssh_key_ids") {
@@ -255,16 +263,16 @@ func resourceScalewayBaremetalServerUpdate(ctx context.Context, d *schema.Resour
 
 		server, err := baremetalAPI.InstallServer(installReq, scw.WithContext(ctx))
 		if err != nil {
-			return diag.FromErr(err)
+			return diagFromErr(err)
 		}
 
 		_, err = baremetalAPI.WaitForServerInstall(&baremetal.WaitForServerInstallRequest{
 			Zone:     server.Zone,
 			ServerID: server.ID,
-			Timeout:  scw.TimeDurationPtr(baremetalServerWaitForTimeout),
+			Timeout:  scw.TimeDurationPtr(d.Timeout(schema.TimeoutUpdate)),
 		})
 		if err != nil {
-			return diag.FromErr(err)
+			return diagFromErr(err)
 		}
 	}
 
@@ -274,7 +282,7 @@ func resourceScalewayBaremetalServerUpdate(ctx context.Context, d *schema.Resour
 func resourceScalewayBaremetalServerDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	baremetalAPI, zonedID, err := baremetalAPIWithZoneAndID(meta, d.Id())
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	server, err := baremetalAPI.DeleteServer(&baremetal.DeleteServerRequest{
@@ -286,17 +294,17 @@ func resourceScalewayBaremetalServerDelete(ctx context.Context, d *schema.Resour
 		if is404Error(err) {
 			return nil
 		}
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	_, err = baremetalAPI.WaitForServer(&baremetal.WaitForServerRequest{
 		Zone:     server.Zone,
 		ServerID: server.ID,
-		Timeout:  scw.TimeDurationPtr(baremetalServerWaitForTimeout),
+		Timeout:  scw.TimeDurationPtr(d.Timeout(schema.TimeoutDelete)),
 	})
 
 	if err != nil && !is404Error(err) {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	return nil
@@ -21,15 +21,21 @@ func testSweepBaremetalServer(_ string) error {
 	return sweepZones([]scw.Zone{scw.ZoneFrPar2}, func(scwClient *scw.Client, zone scw.Zone) error {
 		baremetalAPI := baremetal.NewAPI(scwClient)
 		l.Debugf("sweeper: destroying the baremetal server in (%s)", zone)
-		listServers, err := baremetalAPI.ListServers(&baremetal.ListServersRequest{}, scw.WithAllPages())
+		listServers, err := baremetalAPI.ListServers(&baremetal.ListServersRequest{
+			Zone: zone,
+		}, scw.WithAllPages())
 		if err != nil {
 			l.Warningf("error listing servers in (%s) in sweeper: %s", zone, err)
 			return nil
 		}
 
 		for _, server := range listServers.Servers {
-			_, err := baremetalAPI.DeleteServer(&baremetal.DeleteServerRequest{
-				ServerID: server.ID,
+			err := sweep(&sweptResource{kind: "baremetal server", id: server.ID, name: server.Name, tags: server.Tags, createdAt: server.CreatedAt}, func() error {
+				_, err := baremetalAPI.DeleteServer(&baremetal.DeleteServerRequest{
+					Zone:     zone,
+					ServerID: server.ID,
+				})
+				return err
 			})
 			if err != nil {
 				return fmt.Errorf("error deleting server in sweeper: %s", err)
@@ -0,0 +1,214 @@
+package scaleway
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/scaleway/scaleway-sdk-go/api/instance/v1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+)
+
+func resourceScalewayInstanceImage() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceScalewayInstanceImageCreate,
+		ReadContext:   resourceScalewayInstanceImageRead,
+		DeleteContext: resourceScalewayInstanceImageDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: importStateByName("instance image", instanceImageIDsByName),
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Default: schema.DefaultTimeout(defaultInstanceImageWaitTimeout),
+		},
+		CustomizeDiff: customizeDiffLocalityCheck("zone", "root_volume_id", "additional_volume_ids"),
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "The name of the image",
+			},
+			"root_volume_id": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				Description:      "The ID of the snapshot used as root volume of the image",
+				ValidateFunc:     validationUUIDorUUIDWithLocality(),
+				DiffSuppressFunc: diffSuppressFuncLocality,
+			},
+			"additional_volume_ids": {
+				Type:     schema.TypeList,
+				Optional: true,
+				ForceNew: true,
+				Elem: &schema.Schema{
+					Type:             schema.TypeString,
+					ValidateFunc:     validationUUIDorUUIDWithLocality(),
+					DiffSuppressFunc: diffSuppressFuncLocality,
+				},
+				Description: "The IDs of the snapshots used as additional volumes of the image",
+			},
+			"architecture": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Default:     instance.ArchX86_64.String(),
+				Description: "The architecture of the image",
+				ValidateFunc: validation.StringInSlice([]string{
+					instance.ArchX86_64.String(),
+					instance.ArchArm.String(),
+				}, false),
+			},
+			"default_bootscript_id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ForceNew:     true,
+				Description:  "The ID of the default bootscript of the image",
+				ValidateFunc: validationUUID(),
+			},
+			"public": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				ForceNew:    true,
+				Default:     false,
+				Description: "Whether the image is public",
+			},
+			"from_server_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "ID of the server the image is originated from",
+			},
+			"creation_date": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Date when the image was created",
+			},
+			"modification_date": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Date when the image was updated",
+			},
+			"state": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "State of the image",
+			},
+			"organization_id": organizationIDSchema(),
+			"project_id":      projectIDSchema(),
+			"zone":            zoneSchema(),
+		},
+	}
+}
+
+func resourceScalewayInstanceImageCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	instanceAPI, zone, err := instanceAPIWithZone(d, meta)
+	if err != nil {
+		return diagFromErr(err)
+	}
+
+	req := &instance.CreateImageRequest{
+		Zone:              zone,
+		Name:              expandOrGenerateString(d.Get("name"), "img"),
+		RootVolume:        expandZonedID(d.Get("root_volume_id")).ID,
+		Arch:              instance.Arch(d.Get("architecture").(string)),
+		DefaultBootscript: d.Get("default_bootscript_id").(string),
+		Project:           expandStringPtr(d.Get("project_id")),
+		Public:            d.Get("public").(bool),
+	}
+
+	// Extra volumes are indexed from 1, the root volume being 0.
+	for i, snapshotID := range d.Get("additional_volume_ids").([]interface{}) {
+		if req.ExtraVolumes == nil {
+			req.ExtraVolumes = map[string]*instance.VolumeTemplate{}
+		}
+		req.ExtraVolumes[strconv.Itoa(i+1)] = &instance.VolumeTemplate{
+			ID: expandZonedID(snapshotID).ID,
+		}
+	}
+
+	res, err := instanceAPI.CreateImage(req, scw.WithContext(ctx))
+	if err != nil {
+		return diagFromErr(fmt.Errorf("couldn't create image: %w", err))
+	}
+
+	d.SetId(newZonedIDString(zone, res.Image.ID))
+
+	image, err := waitForInstanceImage(ctx, instanceAPI, zone, res.Image.ID, d.Timeout(schema.TimeoutCreate))
+	if err != nil {
+		return diagFromErr(err)
+	}
+	if image.State == instance.ImageStateError {
+		return diagFromErr(fmt.Errorf("image %s is in error state", image.ID))
+	}
+
+	return resourceScalewayInstanceImageRead(ctx, d, meta)
+}
+
+func resourceScalewayInstanceImageRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	instanceAPI, zone, id, err := instanceAPIWithZoneAndID(meta, d.Id())
+	if err != nil {
+		return diagFromErr(err)
+	}
+
+	res, err := instanceAPI.GetImage(&instance.GetImageRequest{
+		ImageID: id,
+		Zone:    zone,
+	}, scw.WithContext(ctx))
+	if err != nil {
+		if is404Error(err) {
+			d.SetId("")
+			return nil
+		}
+		return diagFromErr(fmt.Errorf("couldn't read image: %w", err))
+	}
+
+	_ = d.Set("name", res.Image.Name)
+	_ = d.Set("organization_id", res.Image.Organization)
+	_ = d.Set("project_id", res.Image.Project)
+	_ = d.Set("zone", string(zone))
+	_ = d.Set("architecture", res.Image.Arch.String())
+	_ = d.Set("public", res.Image.Public)
+	_ = d.Set("from_server_id", res.Image.FromServer)
+	_ = d.Set("creation_date", flattenTime(res.Image.CreationDate))
+	_ = d.Set("modification_date", flattenTime(res.Image.ModificationDate))
+	_ = d.Set("state", res.Image.State.String())
+
+	if res.Image.DefaultBootscript != nil {
+		_ = d.Set("default_bootscript_id", res.Image.DefaultBootscript.ID)
+	} else {
+		_ = d.Set("default_bootscript_id", "")
+	}
+
+	if res.Image.RootVolume != nil {
+		_ = d.Set("root_volume_id", newZonedIDString(zone, res.Image.RootVolume.ID))
+	}
+
+	additionalVolumeIDs := []string(nil)
+	for _, volume := range orderVolumes(res.Image.ExtraVolumes) {
+		additionalVolumeIDs = append(additionalVolumeIDs, newZonedIDString(zone, volume.ID))
+	}
+	_ = d.Set("additional_volume_ids", additionalVolumeIDs)
+
+	return nil
+}
+
+func resourceScalewayInstanceImageDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	instanceAPI, zone, id, err := instanceAPIWithZoneAndID(meta, d.Id())
+	if err != nil {
+		return diagFromErr(err)
+	}
+
+	err = instanceAPI.DeleteImage(&instance.DeleteImageRequest{
+		ImageID: id,
+		Zone:    zone,
+	}, scw.WithContext(ctx))
+	if err != nil && !is404Error(err) {
+		return diagFromErr(err)
+	}
+
+	return nil
+}
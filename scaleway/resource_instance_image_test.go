@@ -0,0 +1,232 @@
+package scaleway
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/scaleway/scaleway-sdk-go/api/instance/v1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func init() {
+	resource.AddTestSweepers("scaleway_instance_image", &resource.Sweeper{
+		Name:         "scaleway_instance_image",
+		F:            testSweepInstanceImage,
+		Dependencies: []string{"scaleway_instance_server"},
+	})
+}
+
+func testSweepInstanceImage(_ string) error {
+	return sweepAllZones(func(scwClient *scw.Client, zone scw.Zone) error {
+		instanceAPI := instance.NewAPI(scwClient)
+		l.Debugf("sweeper: destroying the images in (%s)", zone)
+
+		// Public images include the marketplace ones, which cannot be deleted.
+		listImagesResponse, err := instanceAPI.ListImages(&instance.ListImagesRequest{
+			Zone:   zone,
+			Public: scw.BoolPtr(false),
+		}, scw.WithAllPages())
+		if err != nil {
+			return fmt.Errorf("error listing images in sweeper: %s", err)
+		}
+
+		for _, image := range listImagesResponse.Images {
+			err := sweep(&sweptResource{kind: "instance image", id: image.ID, name: image.Name, createdAt: image.CreationDate}, func() error {
+				return instanceAPI.DeleteImage(&instance.DeleteImageRequest{
+					Zone:    zone,
+					ImageID: image.ID,
+				})
+			})
+			if err != nil {
+				return fmt.Errorf("error deleting image in sweeper: %s", err)
+			}
+		}
+		return nil
+	})
+}
+
+func TestAccScalewayInstanceImage_Basic(t *testing.T) {
+	tt := NewTestTools(t)
+	defer tt.Cleanup()
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: tt.ProviderFactories,
+		CheckDestroy: resource.ComposeTestCheckFunc(
+			testAccCheckScalewayInstanceImageDestroy(tt),
+			testAccCheckScalewayInstanceSnapshotDestroy(tt),
+		),
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					resource "scaleway_instance_server" "main" {
+						image = "ubuntu_focal"
+						type  = "DEV1-S"
+						state = "stopped"
+					}
+
+					resource "scaleway_instance_snapshot" "root" {
+						volume_id = scaleway_instance_server.main.root_volume.0.volume_id
+					}
+
+					resource "scaleway_instance_image" "main" {
+						name           = "terraform-test"
+						root_volume_id = scaleway_instance_snapshot.root.id
+					}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckScalewayInstanceImageExists(tt, "scaleway_instance_image.main"),
+					resource.TestCheckResourceAttr("scaleway_instance_image.main", "name", "terraform-test"),
+					resource.TestCheckResourceAttr("scaleway_instance_image.main", "architecture", "x86_64"),
+					resource.TestCheckResourceAttr("scaleway_instance_image.main", "state", "available"),
+					resource.TestCheckResourceAttrPair("scaleway_instance_image.main", "root_volume_id", "scaleway_instance_snapshot.root", "id"),
+					resource.TestCheckResourceAttr("scaleway_instance_image.main", "additional_volume_ids.#", "0"),
+				),
+			},
+			{
+				Config: `
+					resource "scaleway_instance_server" "main" {
+						image = "ubuntu_focal"
+						type  = "DEV1-S"
+						state = "stopped"
+					}
+
+					resource "scaleway_instance_volume" "data" {
+						type       = "b_ssd"
+						size_in_gb = 10
+					}
+
+					resource "scaleway_instance_snapshot" "root" {
+						volume_id = scaleway_instance_server.main.root_volume.0.volume_id
+					}
+
+					resource "scaleway_instance_snapshot" "data" {
+						volume_id = scaleway_instance_volume.data.id
+					}
+
+					resource "scaleway_instance_image" "main" {
+						name                  = "terraform-test"
+						root_volume_id        = scaleway_instance_snapshot.root.id
+						additional_volume_ids = [scaleway_instance_snapshot.data.id]
+					}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckScalewayInstanceImageExists(tt, "scaleway_instance_image.main"),
+					resource.TestCheckResourceAttr("scaleway_instance_image.main", "additional_volume_ids.#", "1"),
+					resource.TestCheckResourceAttrPair("scaleway_instance_image.main", "additional_volume_ids.0", "scaleway_instance_snapshot.data", "id"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckScalewayInstanceImageDestroy(tt *TestTools) resource.TestCheckFunc {
+	return func(state *terraform.State) error {
+		instanceAPI := instance.NewAPI(tt.Meta.scwClient)
+		for _, rs := range state.RootModule().Resources {
+			if rs.Type != "scaleway_instance_image" {
+				continue
+			}
+
+			zone, id, err := parseZonedID(rs.Primary.ID)
+			if err != nil {
+				return err
+			}
+
+			_, err = instanceAPI.GetImage(&instance.GetImageRequest{
+				ImageID: id,
+				Zone:    zone,
+			})
+
+			// If no error resource still exist
+			if err == nil {
+				return fmt.Errorf("image (%s) still exists", rs.Primary.ID)
+			}
+
+			// Unexpected api error we return it
+			if !is404Error(err) {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+// TestScalewayInstanceImage_FakeAPIVolumes checks that the snapshots of an image keep their order
+// and that the values completed by the API do not plan a replacement of the image.
+func TestScalewayInstanceImage_FakeAPIVolumes(t *testing.T) {
+	f := newFakeAPI(t)
+	defer f.close()
+	meta := f.meta()
+	ctx := context.Background()
+
+	snapshotIDs := []string(nil)
+	for _, name := range []string{"root", "data", "logs"} {
+		snapshot := f.seed("instance", "fr-par-1", "snapshots", map[string]interface{}{"name": name})
+		snapshotIDs = append(snapshotIDs, snapshot["id"].(string))
+	}
+	config := map[string]interface{}{
+		"name":                  "golden",
+		"root_volume_id":        snapshotIDs[0],
+		"additional_volume_ids": []interface{}{snapshotIDs[1], snapshotIDs[2]},
+	}
+
+	d := f.resourceData(resourceScalewayInstanceImage(), "", config)
+	require.False(t, resourceScalewayInstanceImageCreate(ctx, d, meta).HasError())
+	assert.Equal(t, "available", d.Get("state"))
+	assert.Equal(t, "x86_64", d.Get("architecture"))
+	assert.Equal(t, newZonedIDString("fr-par-1", snapshotIDs[0]), d.Get("root_volume_id"))
+	assert.Equal(t, []interface{}{
+		newZonedIDString("fr-par-1", snapshotIDs[1]),
+		newZonedIDString("fr-par-1", snapshotIDs[2]),
+	}, d.Get("additional_volume_ids"))
+
+	imageID := expandZonedID(d.Id()).ID
+	image := f.lookup("instance", "fr-par-1", "images", imageID)
+	require.NotNil(t, image)
+	assert.Equal(t, map[string]interface{}{
+		"1": map[string]interface{}{"id": snapshotIDs[1]},
+		"2": map[string]interface{}{"id": snapshotIDs[2]},
+	}, image["extra_volumes"])
+
+	// The API may attach a bootscript to an image created without one.
+	f.update("instance", "fr-par-1", "images", imageID, map[string]interface{}{
+		"default_bootscript": map[string]interface{}{"id": "55555555-5555-5555-5555-555555555555"},
+	})
+	require.False(t, resourceScalewayInstanceImageRead(ctx, d, meta).HasError())
+	assert.Equal(t, "55555555-5555-5555-5555-555555555555", d.Get("default_bootscript_id"))
+
+	diff, err := f.planUpdate(meta, resourceScalewayInstanceImage(), d, config)
+	require.NoError(t, err)
+	assert.True(t, diff.Empty())
+
+	// Reordering the additional volumes creates another image.
+	config["additional_volume_ids"] = []interface{}{snapshotIDs[2], snapshotIDs[1]}
+	diff, err = f.planUpdate(meta, resourceScalewayInstanceImage(), d, config)
+	require.NoError(t, err)
+	assert.True(t, diff.RequiresNew())
+
+	require.False(t, resourceScalewayInstanceImageDelete(ctx, d, meta).HasError())
+	assert.Nil(t, f.lookup("instance", "fr-par-1", "images", imageID))
+}
+
+func TestScalewayInstanceImage_FakeAPIError(t *testing.T) {
+	f := newFakeAPI(t)
+	defer f.close()
+
+	image := f.seed("instance", "fr-par-1", "images", map[string]interface{}{"name": "broken"})
+	f.update("instance", "fr-par-1", "images", image["id"].(string), map[string]interface{}{"state": "error"})
+	f.respond("POST", "/instance/v1/zones/fr-par-1/images", 201, map[string]interface{}{"image": image}, true)
+
+	d := f.resourceData(resourceScalewayInstanceImage(), "", map[string]interface{}{
+		"root_volume_id": "fr-par-1/11111111-1111-1111-1111-111111111111",
+	})
+	diags := resourceScalewayInstanceImageCreate(context.Background(), d, f.meta())
+	require.True(t, diags.HasError())
+	assert.Contains(t, diags[0].Summary, "is in error state")
+	assert.Equal(t, newZonedIDString("fr-par-1", image["id"].(string)), d.Id())
+}
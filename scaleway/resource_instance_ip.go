@@ -10,7 +10,7 @@ import (
 )
 
 func resourceScalewayInstanceIP() *schema.Resource {
-	return &schema.Resource{
+	return withLocalizedIDsStateUpgrade(&schema.Resource{
 		CreateContext: resourceScalewayInstanceIPCreate,
 		ReadContext:   resourceScalewayInstanceIPRead,
 		DeleteContext: resourceScalewayInstanceIPDelete,
@@ -41,13 +41,16 @@ func resourceScalewayInstanceIP() *schema.Resource {
 			"organization_id": organizationIDSchema(),
 			"project_id":      projectIDSchema(),
 		},
-	}
+	}, map[string]string{
+		"id":        "zone",
+		"server_id": "zone",
+	})
 }
 
 func resourceScalewayInstanceIPCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	instanceAPI, zone, err := instanceAPIWithZone(d, meta)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	res, err := instanceAPI.CreateIP(&instance.CreateIPRequest{
@@ -55,7 +58,7 @@ func resourceScalewayInstanceIPCreate(ctx context.Context, d *schema.ResourceDat
 		Project: expandStringPtr(d.Get("project_id")),
 	}, scw.WithContext(ctx))
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	d.SetId(newZonedIDString(zone, res.IP.ID))
@@ -65,7 +68,7 @@ func resourceScalewayInstanceIPCreate(ctx context.Context, d *schema.ResourceDat
 func resourceScalewayInstanceIPRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	instanceAPI, zone, ID, err := instanceAPIWithZoneAndID(meta, d.Id())
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	res, err := instanceAPI.GetIP(&instance.GetIPRequest{
@@ -79,7 +82,7 @@ func resourceScalewayInstanceIPRead(ctx context.Context, d *schema.ResourceData,
 			d.SetId("")
 			return nil
 		}
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	_ = d.Set("address", res.IP.Address.String())
@@ -100,7 +103,7 @@ func resourceScalewayInstanceIPRead(ctx context.Context, d *schema.ResourceData,
 func resourceScalewayInstanceIPDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	instanceAPI, zone, ID, err := instanceAPIWithZoneAndID(meta, d.Id())
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	err = instanceAPI.DeleteIP(&instance.DeleteIPRequest{
@@ -109,7 +112,7 @@ func resourceScalewayInstanceIPDelete(ctx context.Context, d *schema.ResourceDat
 	}, scw.WithContext(ctx))
 
 	if err != nil && !is404Error(err) && !is403Error(err) {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	return nil
@@ -10,7 +10,7 @@ import (
 )
 
 func resourceScalewayInstanceIPReverseDNS() *schema.Resource {
-	return &schema.Resource{
+	return withLocalizedIDsStateUpgrade(&schema.Resource{
 		CreateContext: resourceScalewayInstanceIPReverseDNSCreate,
 		ReadContext:   resourceScalewayInstanceIPReverseDNSRead,
 		UpdateContext: resourceScalewayInstanceIPReverseDNSUpdate,
@@ -21,6 +21,7 @@ func resourceScalewayInstanceIPReverseDNS() *schema.Resource {
 		Timeouts: &schema.ResourceTimeout{
 			Default: schema.DefaultTimeout(defaultInstanceIPTimeout),
 		},
+		CustomizeDiff: customizeDiffLocalityCheck("zone", "ip_id"),
 		SchemaVersion: 0,
 		Schema: map[string]*schema.Schema{
 			"ip_id": {
@@ -35,13 +36,16 @@ func resourceScalewayInstanceIPReverseDNS() *schema.Resource {
 			},
 			"zone": zoneSchema(),
 		},
-	}
+	}, map[string]string{
+		"id":    "zone",
+		"ip_id": "zone",
+	})
 }
 
 func resourceScalewayInstanceIPReverseDNSCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	instanceAPI, zone, err := instanceAPIWithZone(d, meta)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	res, err := instanceAPI.GetIP(&instance.GetIPRequest{
@@ -49,7 +53,7 @@ func resourceScalewayInstanceIPReverseDNSCreate(ctx context.Context, d *schema.R
 		Zone: zone,
 	}, scw.WithContext(ctx))
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 	d.SetId(newZonedIDString(zone, res.IP.ID))
 
@@ -60,7 +64,7 @@ func resourceScalewayInstanceIPReverseDNSCreate(ctx context.Context, d *schema.R
 func resourceScalewayInstanceIPReverseDNSRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	instanceAPI, zone, ID, err := instanceAPIWithZoneAndID(meta, d.Id())
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	res, err := instanceAPI.GetIP(&instance.GetIPRequest{
@@ -74,7 +78,7 @@ func resourceScalewayInstanceIPReverseDNSRead(ctx context.Context, d *schema.Res
 			d.SetId("")
 			return nil
 		}
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	_ = d.Set("zone", string(zone))
@@ -85,7 +89,7 @@ func resourceScalewayInstanceIPReverseDNSRead(ctx context.Context, d *schema.Res
 func resourceScalewayInstanceIPReverseDNSUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	instanceAPI, zone, ID, err := instanceAPIWithZoneAndID(meta, d.Id())
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	if d.HasChange("reverse") {
@@ -104,7 +108,7 @@ func resourceScalewayInstanceIPReverseDNSUpdate(ctx context.Context, d *schema.R
 		}
 		_, err = instanceAPI.UpdateIP(updateReverseReq, scw.WithContext(ctx))
 		if err != nil {
-			return diag.FromErr(err)
+			return diagFromErr(err)
 		}
 	}
 
@@ -114,7 +118,7 @@ func resourceScalewayInstanceIPReverseDNSUpdate(ctx context.Context, d *schema.R
 func resourceScalewayInstanceIPReverseDNSDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	instanceAPI, zone, ID, err := instanceAPIWithZoneAndID(meta, d.Id())
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	// Unset the reverse dns on the IP
@@ -125,7 +129,7 @@ func resourceScalewayInstanceIPReverseDNSDelete(ctx context.Context, d *schema.R
 	}
 	_, err = instanceAPI.UpdateIP(updateReverseReq, scw.WithContext(ctx))
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	d.SetId("")
@@ -19,18 +19,24 @@ func init() {
 }
 
 func testSweepInstanceIP(_ string) error {
-	return sweepZones(scw.AllZones, func(scwClient *scw.Client, zone scw.Zone) error {
+	return sweepAllZones(func(scwClient *scw.Client, zone scw.Zone) error {
 		instanceAPI := instance.NewAPI(scwClient)
+		l.Debugf("sweeper: destroying the instance ips in (%s)", zone)
 
-		listIPs, err := instanceAPI.ListIPs(&instance.ListIPsRequest{}, scw.WithAllPages())
+		listIPs, err := instanceAPI.ListIPs(&instance.ListIPsRequest{
+			Zone: zone,
+		}, scw.WithAllPages())
 		if err != nil {
 			l.Warningf("error listing ips in (%s) in sweeper: %s", zone, err)
 			return nil
 		}
 
 		for _, ip := range listIPs.IPs {
-			err := instanceAPI.DeleteIP(&instance.DeleteIPRequest{
-				IP: ip.ID,
+			err := sweep(&sweptResource{kind: "instance ip", id: ip.ID, tags: ip.Tags}, func() error {
+				return instanceAPI.DeleteIP(&instance.DeleteIPRequest{
+					Zone: zone,
+					IP:   ip.ID,
+				})
 			})
 			if err != nil {
 				return fmt.Errorf("error deleting ip in sweeper: %s", err)
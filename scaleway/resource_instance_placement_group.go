@@ -11,7 +11,7 @@ import (
 )
 
 func resourceScalewayInstancePlacementGroup() *schema.Resource {
-	return &schema.Resource{
+	return withLocalizedIDsStateUpgrade(&schema.Resource{
 		CreateContext: resourceScalewayInstancePlacementGroupCreate,
 		ReadContext:   resourceScalewayInstancePlacementGroupRead,
 		UpdateContext: resourceScalewayInstancePlacementGroupUpdate,
@@ -59,13 +59,15 @@ func resourceScalewayInstancePlacementGroup() *schema.Resource {
 			"organization_id": organizationIDSchema(),
 			"project_id":      projectIDSchema(),
 		},
-	}
+	}, map[string]string{
+		"id": "zone",
+	})
 }
 
 func resourceScalewayInstancePlacementGroupCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	instanceAPI, zone, err := instanceAPIWithZone(d, meta)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	res, err := instanceAPI.CreatePlacementGroup(&instance.CreatePlacementGroupRequest{
@@ -76,7 +78,7 @@ func resourceScalewayInstancePlacementGroupCreate(ctx context.Context, d *schema
 		PolicyType: instance.PlacementGroupPolicyType(d.Get("policy_type").(string)),
 	}, scw.WithContext(ctx))
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	d.SetId(newZonedIDString(zone, res.PlacementGroup.ID))
@@ -86,7 +88,7 @@ func resourceScalewayInstancePlacementGroupCreate(ctx context.Context, d *schema
 func resourceScalewayInstancePlacementGroupRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	instanceAPI, zone, ID, err := instanceAPIWithZoneAndID(meta, d.Id())
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	res, err := instanceAPI.GetPlacementGroup(&instance.GetPlacementGroupRequest{
@@ -99,7 +101,7 @@ func resourceScalewayInstancePlacementGroupRead(ctx context.Context, d *schema.R
 			d.SetId("")
 			return nil
 		}
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	_ = d.Set("name", res.PlacementGroup.Name)
@@ -116,7 +118,7 @@ func resourceScalewayInstancePlacementGroupRead(ctx context.Context, d *schema.R
 func resourceScalewayInstancePlacementGroupUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	instanceAPI, zone, ID, err := instanceAPIWithZoneAndID(meta, d.Id())
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 	req := &instance.UpdatePlacementGroupRequest{
 		Zone:             zone,
@@ -145,7 +147,7 @@ func resourceScalewayInstancePlacementGroupUpdate(ctx context.Context, d *schema
 	if hasChanged {
 		_, err = instanceAPI.UpdatePlacementGroup(req, scw.WithContext(ctx))
 		if err != nil {
-			return diag.FromErr(err)
+			return diagFromErr(err)
 		}
 	}
 
@@ -155,7 +157,7 @@ func resourceScalewayInstancePlacementGroupUpdate(ctx context.Context, d *schema
 func resourceScalewayInstancePlacementGroupDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	instanceAPI, zone, ID, err := instanceAPIWithZoneAndID(meta, d.Id())
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	err = instanceAPI.DeletePlacementGroup(&instance.DeletePlacementGroupRequest{
@@ -164,7 +166,7 @@ func resourceScalewayInstancePlacementGroupDelete(ctx context.Context, d *schema
 	}, scw.WithContext(ctx))
 
 	if err != nil && !is404Error(err) {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	return nil
@@ -12,24 +12,30 @@ import (
 
 func init() {
 	resource.AddTestSweepers("scaleway_instance_placement_group", &resource.Sweeper{
-		Name: "scaleway_instance_placement_group",
-		F:    testSweepInstancePlacementGroup,
+		Name:         "scaleway_instance_placement_group",
+		F:            testSweepInstancePlacementGroup,
+		Dependencies: []string{"scaleway_instance_server"},
 	})
 }
 
 func testSweepInstancePlacementGroup(_ string) error {
-	return sweepZones(scw.AllZones, func(scwClient *scw.Client, zone scw.Zone) error {
+	return sweepAllZones(func(scwClient *scw.Client, zone scw.Zone) error {
 		instanceAPI := instance.NewAPI(scwClient)
 		l.Debugf("sweeper: destroying the instance placement group in (%s)", zone)
-		listPlacementGroups, err := instanceAPI.ListPlacementGroups(&instance.ListPlacementGroupsRequest{}, scw.WithAllPages())
+		listPlacementGroups, err := instanceAPI.ListPlacementGroups(&instance.ListPlacementGroupsRequest{
+			Zone: zone,
+		}, scw.WithAllPages())
 		if err != nil {
 			l.Warningf("error listing placement groups in (%s) in sweeper: %s", zone, err)
 			return nil
 		}
 
 		for _, pg := range listPlacementGroups.PlacementGroups {
-			err := instanceAPI.DeletePlacementGroup(&instance.DeletePlacementGroupRequest{
-				PlacementGroupID: pg.ID,
+			err := sweep(&sweptResource{kind: "instance placement group", id: pg.ID, name: pg.Name}, func() error {
+				return instanceAPI.DeletePlacementGroup(&instance.DeletePlacementGroupRequest{
+					Zone:             zone,
+					PlacementGroupID: pg.ID,
+				})
 			})
 			if err != nil {
 				return fmt.Errorf("error deleting placement group in sweeper: %s", err)
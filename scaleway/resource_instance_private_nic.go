@@ -10,7 +10,7 @@ import (
 )
 
 func resourceScalewayInstancePrivateNIC() *schema.Resource {
-	return &schema.Resource{
+	return withLocalizedIDsStateUpgrade(&schema.Resource{
 		CreateContext: resourceScalewayInstancePrivateNICCreate,
 		ReadContext:   resourceScalewayInstancePrivateNICRead,
 		UpdateContext: resourceScalewayInstancePrivateNICUpdate,
@@ -18,7 +18,11 @@ func resourceScalewayInstancePrivateNIC() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
+		Timeouts: &schema.ResourceTimeout{
+			Default: schema.DefaultTimeout(defaultInstancePrivateNICTimeout),
+		},
 
+		CustomizeDiff: customizeDiffLocalityCheck("zone", "server_id", "private_network_id"),
 		Schema: map[string]*schema.Schema{
 			"server_id": {
 				Type:        schema.TypeString,
@@ -37,13 +41,16 @@ func resourceScalewayInstancePrivateNIC() *schema.Resource {
 			},
 			"zone": zoneSchema(),
 		},
-	}
+	}, map[string]string{
+		"server_id":          "zone",
+		"private_network_id": "zone",
+	})
 }
 
 func resourceScalewayInstancePrivateNICCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	instanceAPI, zone, err := instanceAPIWithZone(d, meta)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	createPrivateNICRequest := &instance.CreatePrivateNICRequest{
@@ -57,7 +64,7 @@ func resourceScalewayInstancePrivateNICCreate(ctx context.Context, d *schema.Res
 		scw.WithContext(ctx),
 	)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	d.SetId(
@@ -74,11 +81,11 @@ func resourceScalewayInstancePrivateNICCreate(ctx context.Context, d *schema.Res
 func resourceScalewayInstancePrivateNICRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	instanceAPI, _, err := instanceAPIWithZone(d, meta)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 	zone, innerID, outerID, err := parseZonedNestedID(d.Id())
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	res, err := instanceAPI.GetPrivateNIC(&instance.GetPrivateNICRequest{
@@ -91,7 +98,7 @@ func resourceScalewayInstancePrivateNICRead(ctx context.Context, d *schema.Resou
 			d.SetId("")
 			return nil
 		}
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	_ = d.Set("zone", zone)
@@ -105,12 +112,12 @@ func resourceScalewayInstancePrivateNICRead(ctx context.Context, d *schema.Resou
 func resourceScalewayInstancePrivateNICUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	instanceAPI, _, err := instanceAPIWithZone(d, meta)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	zone, innerID, outerID, err := parseZonedNestedID(d.Id())
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	if d.HasChanges("private_network_id", "server_id") {
@@ -122,7 +129,7 @@ func resourceScalewayInstancePrivateNICUpdate(ctx context.Context, d *schema.Res
 		}, scw.WithContext(ctx))
 
 		if err != nil && !is404Error(err) {
-			return diag.FromErr(err)
+			return diagFromErr(err)
 		}
 		// create the new one
 		createPrivateNICRequest := &instance.CreatePrivateNICRequest{
@@ -136,7 +143,7 @@ func resourceScalewayInstancePrivateNICUpdate(ctx context.Context, d *schema.Res
 			scw.WithContext(ctx),
 		)
 		if err != nil {
-			return diag.FromErr(err)
+			return diagFromErr(err)
 		}
 
 		d.SetId(
@@ -154,11 +161,11 @@ func resourceScalewayInstancePrivateNICUpdate(ctx context.Context, d *schema.Res
 func resourceScalewayInstancePrivateNICDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	instanceAPI, _, err := instanceAPIWithZone(d, meta)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 	zone, innerID, outerID, err := parseZonedNestedID(d.Id())
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	err = instanceAPI.DeletePrivateNIC(&instance.DeletePrivateNICRequest{
@@ -168,7 +175,7 @@ func resourceScalewayInstancePrivateNICDelete(ctx context.Context, d *schema.Res
 	}, scw.WithContext(ctx))
 
 	if err != nil && !is404Error(err) {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	return nil
@@ -13,13 +13,13 @@ import (
 )
 
 func resourceScalewayInstanceSecurityGroup() *schema.Resource {
-	return &schema.Resource{
+	return withLocalizedIDsStateUpgrade(&schema.Resource{
 		CreateContext: resourceScalewayInstanceSecurityGroupCreate,
 		ReadContext:   resourceScalewayInstanceSecurityGroupRead,
 		UpdateContext: resourceScalewayInstanceSecurityGroupUpdate,
 		DeleteContext: resourceScalewayInstanceSecurityGroupDelete,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importStateByName("instance security group", instanceSecurityGroupIDsByName),
 		},
 		Timeouts: &schema.ResourceTimeout{
 			Default: schema.DefaultTimeout(defaultInstanceSecurityGroupTimeout),
@@ -92,13 +92,15 @@ func resourceScalewayInstanceSecurityGroup() *schema.Resource {
 			"organization_id": organizationIDSchema(),
 			"project_id":      projectIDSchema(),
 		},
-	}
+	}, map[string]string{
+		"id": "zone",
+	})
 }
 
 func resourceScalewayInstanceSecurityGroupCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	instanceAPI, zone, err := instanceAPIWithZone(d, meta)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	res, err := instanceAPI.CreateSecurityGroup(&instance.CreateSecurityGroupRequest{
@@ -112,7 +114,7 @@ func resourceScalewayInstanceSecurityGroupCreate(ctx context.Context, d *schema.
 		EnableDefaultSecurity: expandBoolPtr(d.Get("enable_default_security")),
 	}, scw.WithContext(ctx))
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	d.SetId(newZonedIDString(zone, res.SecurityGroup.ID))
@@ -127,7 +129,7 @@ func resourceScalewayInstanceSecurityGroupCreate(ctx context.Context, d *schema.
 func resourceScalewayInstanceSecurityGroupRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	instanceAPI, zone, ID, err := instanceAPIWithZoneAndID(meta, d.Id())
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	res, err := instanceAPI.GetSecurityGroup(&instance.GetSecurityGroupRequest{
@@ -139,7 +141,7 @@ func resourceScalewayInstanceSecurityGroupRead(ctx context.Context, d *schema.Re
 			d.SetId("")
 			return nil
 		}
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	_ = d.Set("zone", zone)
@@ -155,7 +157,7 @@ func resourceScalewayInstanceSecurityGroupRead(ctx context.Context, d *schema.Re
 	if !d.Get("external_rules").(bool) {
 		inboundRules, outboundRules, err := getSecurityGroupRules(ctx, instanceAPI, zone, ID, d)
 		if err != nil {
-			return diag.FromErr(err)
+			return diagFromErr(err)
 		}
 		_ = d.Set("inbound_rule", inboundRules)
 		_ = d.Set("outbound_rule", outboundRules)
@@ -211,11 +213,11 @@ func getSecurityGroupRules(ctx context.Context, instanceAPI *instance.API, zone
 func resourceScalewayInstanceSecurityGroupUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	instanceAPI, _, err := instanceAPIWithZone(d, meta)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 	zone, ID, err := parseZonedID(d.Id())
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	inboundDefaultPolicy := instance.SecurityGroupPolicy("")
@@ -251,13 +253,13 @@ func resourceScalewayInstanceSecurityGroupUpdate(ctx context.Context, d *schema.
 
 	_, err = instanceAPI.UpdateSecurityGroup(updateReq, scw.WithContext(ctx))
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	if !d.Get("external_rules").(bool) {
 		err = updateSecurityGroupeRules(ctx, d, zone, ID, instanceAPI)
 		if err != nil {
-			return diag.FromErr(err)
+			return diagFromErr(err)
 		}
 	}
 
@@ -373,11 +375,11 @@ func updateSecurityGroupeRules(ctx context.Context, d *schema.ResourceData, zone
 func resourceScalewayInstanceSecurityGroupDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	instanceAPI, _, err := instanceAPIWithZone(d, meta)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 	zone, ID, err := parseZonedID(d.Id())
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	err = instanceAPI.DeleteSecurityGroup(&instance.DeleteSecurityGroupRequest{
@@ -386,7 +388,7 @@ func resourceScalewayInstanceSecurityGroupDelete(ctx context.Context, d *schema.
 	}, scw.WithContext(ctx))
 
 	if err != nil && !is404Error(err) {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	return nil
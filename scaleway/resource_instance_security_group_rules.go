@@ -8,7 +8,7 @@ import (
 )
 
 func resourceScalewayInstanceSecurityGroupRules() *schema.Resource {
-	return &schema.Resource{
+	return withLocalizedIDsStateUpgrade(&schema.Resource{
 		CreateContext: resourceScalewayInstanceSecurityGroupRulesCreate,
 		ReadContext:   resourceScalewayInstanceSecurityGroupRulesRead,
 		UpdateContext: resourceScalewayInstanceSecurityGroupRulesUpdate,
@@ -41,7 +41,10 @@ func resourceScalewayInstanceSecurityGroupRules() *schema.Resource {
 				Elem:        securityGroupRuleSchema(),
 			},
 		},
-	}
+	}, map[string]string{
+		"id":                "zone",
+		"security_group_id": "zone",
+	})
 }
 
 func resourceScalewayInstanceSecurityGroupRulesCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
@@ -56,14 +59,14 @@ func resourceScalewayInstanceSecurityGroupRulesRead(ctx context.Context, d *sche
 
 	instanceAPI, zone, securityGroupID, err := instanceAPIWithZoneAndID(meta, securityGroupZonedID)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	_ = d.Set("security_group_id", securityGroupZonedID)
 
 	inboundRules, outboundRules, err := getSecurityGroupRules(ctx, instanceAPI, zone, securityGroupID, d)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	_ = d.Set("inbound_rule", inboundRules)
@@ -76,12 +79,12 @@ func resourceScalewayInstanceSecurityGroupRulesUpdate(ctx context.Context, d *sc
 	securityGroupZonedID := d.Id()
 	instanceAPI, zone, securityGroupID, err := instanceAPIWithZoneAndID(meta, securityGroupZonedID)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	err = updateSecurityGroupeRules(ctx, d, zone, securityGroupID, instanceAPI)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	return resourceScalewayInstanceSecurityGroupRulesRead(ctx, d, meta)
@@ -91,7 +94,7 @@ func resourceScalewayInstanceSecurityGroupRulesDelete(ctx context.Context, d *sc
 	securityGroupZonedID := d.Id()
 	instanceAPI, zone, securityGroupID, err := instanceAPIWithZoneAndID(meta, securityGroupZonedID)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	_ = d.Set("inbound_rule", nil)
@@ -99,7 +102,7 @@ func resourceScalewayInstanceSecurityGroupRulesDelete(ctx context.Context, d *sc
 
 	err = updateSecurityGroupeRules(ctx, d, zone, securityGroupID, instanceAPI)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	return nil
@@ -13,8 +13,9 @@ import (
 
 func init() {
 	resource.AddTestSweepers("scaleway_instance_security_group", &resource.Sweeper{
-		Name: "scaleway_instance_security_group",
-		F:    testSweepComputeInstanceSecurityGroup,
+		Name:         "scaleway_instance_security_group",
+		F:            testSweepComputeInstanceSecurityGroup,
+		Dependencies: []string{"scaleway_instance_server"},
 	})
 }
 func TestAccScalewayInstanceSecurityGroup_Basic(t *testing.T) {
@@ -512,11 +513,13 @@ func testAccCheckScalewayInstanceSecurityGroupDestroy(tt *TestTools) resource.Te
 }
 
 func testSweepComputeInstanceSecurityGroup(_ string) error {
-	return sweepZones(scw.AllZones, func(scwClient *scw.Client, zone scw.Zone) error {
+	return sweepAllZones(func(scwClient *scw.Client, zone scw.Zone) error {
 		instanceAPI := instance.NewAPI(scwClient)
 		l.Debugf("sweeper: destroying the security groups in (%s)", zone)
 
-		listResp, err := instanceAPI.ListSecurityGroups(&instance.ListSecurityGroupsRequest{}, scw.WithAllPages())
+		listResp, err := instanceAPI.ListSecurityGroups(&instance.ListSecurityGroupsRequest{
+			Zone: zone,
+		}, scw.WithAllPages())
 		if err != nil {
 			l.Warningf("error listing security groups in sweeper: %s", err)
 			return nil
@@ -527,8 +530,11 @@ func testSweepComputeInstanceSecurityGroup(_ string) error {
 			if securityGroup.ProjectDefault {
 				continue
 			}
-			err = instanceAPI.DeleteSecurityGroup(&instance.DeleteSecurityGroupRequest{
-				SecurityGroupID: securityGroup.ID,
+			err = sweep(&sweptResource{kind: "instance security group", id: securityGroup.ID, name: securityGroup.Name, createdAt: securityGroup.CreationDate}, func() error {
+				return instanceAPI.DeleteSecurityGroup(&instance.DeleteSecurityGroupRequest{
+					Zone:            zone,
+					SecurityGroupID: securityGroup.ID,
+				})
 			})
 			if err != nil {
 				return fmt.Errorf("error deleting security groups in sweeper: %s", err)
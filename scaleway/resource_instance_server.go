@@ -7,28 +7,36 @@ import (
 	"io"
 	"io/ioutil"
 	"strconv"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/scaleway/scaleway-sdk-go/api/instance/v1"
-	"github.com/scaleway/scaleway-sdk-go/api/marketplace/v1"
 	"github.com/scaleway/scaleway-sdk-go/scw"
 	scwvalidation "github.com/scaleway/scaleway-sdk-go/validation"
 )
 
 func resourceScalewayInstanceServer() *schema.Resource {
-	return &schema.Resource{
+	return withLocalizedIDsStateUpgrade(&schema.Resource{
 		CreateContext: resourceScalewayInstanceServerCreate,
 		ReadContext:   resourceScalewayInstanceServerRead,
 		UpdateContext: resourceScalewayInstanceServerUpdate,
 		DeleteContext: resourceScalewayInstanceServerDelete,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importStateByName("instance server", instanceServerIDsByName),
 		},
 		Timeouts: &schema.ResourceTimeout{
 			Default: schema.DefaultTimeout(defaultInstanceServerWaitTimeout),
 		},
+		CustomizeDiff: customdiff.All(
+			customizeDiffCatalogType("type", "server type", strings.ToUpper, instanceServerTypeNames),
+			customizeDiffLocalityCheck("zone", "placement_group_id", "additional_volume_ids", "security_group_id", "ip_id", "private_network.#.private_network_id"),
+			customizeDiffInstanceServerType,
+			customizeDiffInstanceServerRootVolumeSize,
+			customizeDiffTagsAll,
+		),
 		SchemaVersion: 0,
 		Schema: map[string]*schema.Schema{
 			"name": {
@@ -47,7 +55,6 @@ func resourceScalewayInstanceServer() *schema.Resource {
 			"type": {
 				Type:             schema.TypeString,
 				Required:         true,
-				ForceNew:         true,
 				Description:      "The instance type of the server", // TODO: link to scaleway pricing in the doc
 				DiffSuppressFunc: diffSuppressFuncIgnoreCase,
 			},
@@ -59,6 +66,7 @@ func resourceScalewayInstanceServer() *schema.Resource {
 				Optional:    true,
 				Description: "The tags associated with the server",
 			},
+			"tags_all": tagsAllSchema(),
 			"security_group_id": {
 				Type:             schema.TypeString,
 				Optional:         true,
@@ -89,7 +97,6 @@ func resourceScalewayInstanceServer() *schema.Resource {
 							Type:        schema.TypeInt,
 							Optional:    true,
 							Computed:    true,
-							ForceNew:    true, // todo: don't force new but stop server and create new volume instead
 							Description: "Size of the root volume in gigabytes",
 						},
 						"delete_on_termination": {
@@ -202,17 +209,45 @@ func resourceScalewayInstanceServer() *schema.Resource {
 					Type: schema.TypeString,
 				},
 			},
+			"private_network": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    8,
+				Description: "The private networks the server is attached to",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"private_network_id": {
+							Type:             schema.TypeString,
+							Required:         true,
+							ValidateFunc:     validationUUIDorUUIDWithLocality(),
+							DiffSuppressFunc: diffSuppressFuncLocality,
+							Description:      "The private network ID",
+						},
+						"mac_address": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "MAC address of the private NIC",
+						},
+					},
+				},
+			},
 			"zone":            zoneSchema(),
 			"organization_id": organizationIDSchema(),
 			"project_id":      projectIDSchema(),
 		},
-	}
+	}, map[string]string{
+		"id":                    "zone",
+		"security_group_id":     "zone",
+		"placement_group_id":    "zone",
+		"ip_id":                 "zone",
+		"additional_volume_ids": "zone",
+	})
 }
 
 func resourceScalewayInstanceServerCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	instanceAPI, zone, err := instanceAPIWithZone(d, meta)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	////
@@ -223,14 +258,9 @@ func resourceScalewayInstanceServerCreate(ctx context.Context, d *schema.Resourc
 
 	imageUUID := expandZonedID(d.Get("image")).ID
 	if !scwvalidation.IsUUID(imageUUID) {
-		marketPlaceAPI := marketplace.NewAPI(meta.(*Meta).scwClient)
-		imageUUID, err = marketPlaceAPI.GetLocalImageIDByLabel(&marketplace.GetLocalImageIDByLabelRequest{
-			CommercialType: commercialType,
-			Zone:           zone,
-			ImageLabel:     imageUUID,
-		})
+		imageUUID, err = marketplaceLocalImageIDByLabel(ctx, meta, zone, commercialType, imageUUID)
 		if err != nil {
-			return diag.FromErr(fmt.Errorf("could not get image '%s': %s", newZonedID(zone, imageUUID), err))
+			return diagFromErr(fmt.Errorf("could not get image '%s': %w", newZonedID(zone, imageUUID), err))
 		}
 	}
 
@@ -243,7 +273,7 @@ func resourceScalewayInstanceServerCreate(ctx context.Context, d *schema.Resourc
 		EnableIPv6:        d.Get("enable_ipv6").(bool),
 		SecurityGroup:     expandStringPtr(expandZonedID(d.Get("security_group_id")).ID),
 		DynamicIPRequired: scw.BoolPtr(d.Get("enable_dynamic_ip").(bool)),
-		Tags:              expandStrings(d.Get("tags")),
+		Tags:              expandTags(meta, d.Get("tags")),
 	}
 
 	if bootScriptID, ok := d.GetOk("bootscript_id"); ok {
@@ -263,9 +293,9 @@ func resourceScalewayInstanceServerCreate(ctx context.Context, d *schema.Resourc
 		req.PlacementGroup = expandStringPtr(expandZonedID(placementGroupID).ID)
 	}
 
-	serverType := getServerType(instanceAPI, req.Zone, req.CommercialType)
+	serverType := getServerType(ctx, meta, req.Zone, req.CommercialType)
 	if serverType == nil {
-		return diag.FromErr(fmt.Errorf("could not find a server type associated with %s", req.CommercialType))
+		return diagFromErr(fmt.Errorf("could not find a server type associated with %s", req.CommercialType))
 	}
 
 	req.Volumes = make(map[string]*instance.VolumeTemplate)
@@ -300,7 +330,7 @@ func resourceScalewayInstanceServerCreate(ctx context.Context, d *schema.Resourc
 				VolumeID: expandZonedID(volumeID).ID,
 			})
 			if err != nil {
-				return diag.FromErr(err)
+				return diagFromErr(err)
 			}
 			req.Volumes[strconv.Itoa(i+1)] = &instance.VolumeTemplate{
 				ID:         vol.Volume.ID,
@@ -313,7 +343,7 @@ func resourceScalewayInstanceServerCreate(ctx context.Context, d *schema.Resourc
 
 	// Validate total local volume sizes.
 	if err = validateLocalVolumeSizes(req.Volumes, serverType, req.CommercialType); err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	// Sanitize the volume map to respect API schemas
@@ -321,11 +351,22 @@ func resourceScalewayInstanceServerCreate(ctx context.Context, d *schema.Resourc
 
 	res, err := instanceAPI.CreateServer(req, scw.WithContext(ctx))
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	d.SetId(newZonedID(zone, res.Server.ID).String())
 
+	////
+	// Attach private networks
+	////
+	// NICs are created before the server is started so that they are available to cloud-init.
+	if privateNetworkIDs := expandInstanceServerPrivateNetworkIDs(d.Get("private_network")); len(privateNetworkIDs) > 0 {
+		err = updateInstanceServerPrivateNICs(ctx, instanceAPI, zone, res.Server.ID, nil, privateNetworkIDs)
+		if err != nil {
+			return diagFromErr(err)
+		}
+	}
+
 	////
 	// Set user data
 	////
@@ -349,17 +390,17 @@ func resourceScalewayInstanceServerCreate(ctx context.Context, d *schema.Resourc
 	if len(userDataRequests.UserData) > 0 {
 		err = instanceAPI.SetAllServerUserData(userDataRequests)
 		if err != nil {
-			return diag.FromErr(err)
+			return diagFromErr(err)
 		}
 	}
 
 	targetState, err := serverStateExpand(d.Get("state").(string))
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
-	err = reachState(ctx, instanceAPI, zone, res.Server.ID, targetState)
+	err = reachState(ctx, instanceAPI, zone, res.Server.ID, targetState, d.Timeout(schema.TimeoutCreate))
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	return resourceScalewayInstanceServerRead(ctx, d, meta)
@@ -368,7 +409,7 @@ func resourceScalewayInstanceServerCreate(ctx context.Context, d *schema.Resourc
 func resourceScalewayInstanceServerRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	instanceAPI, zone, ID, err := instanceAPIWithZoneAndID(meta, d.Id())
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	////
@@ -383,11 +424,11 @@ func resourceScalewayInstanceServerRead(ctx context.Context, d *schema.ResourceD
 			d.SetId("")
 			return nil
 		}
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 	state, err := serverStateFlatten(response.Server.State)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	_ = d.Set("state", state)
@@ -396,7 +437,8 @@ func resourceScalewayInstanceServerRead(ctx context.Context, d *schema.ResourceD
 	_ = d.Set("boot_type", response.Server.BootType)
 	_ = d.Set("bootscript_id", response.Server.Bootscript.ID)
 	_ = d.Set("type", response.Server.CommercialType)
-	_ = d.Set("tags", response.Server.Tags)
+	_ = d.Set("tags", flattenTags(meta, response.Server.Tags, d.Get("tags")))
+	_ = d.Set("tags_all", response.Server.Tags)
 	_ = d.Set("security_group_id", newZonedID(zone, response.Server.SecurityGroup.ID).String())
 	_ = d.Set("enable_ipv6", response.Server.EnableIPv6)
 	_ = d.Set("enable_dynamic_ip", response.Server.DynamicIPRequired)
@@ -442,7 +484,7 @@ func resourceScalewayInstanceServerRead(ctx context.Context, d *schema.ResourceD
 		_ = d.Set("ipv6_gateway", response.Server.IPv6.Gateway.String())
 		prefixLength, err := strconv.Atoi(response.Server.IPv6.Netmask)
 		if err != nil {
-			return diag.FromErr(err)
+			return diagFromErr(err)
 		}
 		_ = d.Set("ipv6_prefix_length", prefixLength)
 	} else {
@@ -473,6 +515,21 @@ func resourceScalewayInstanceServerRead(ctx context.Context, d *schema.ResourceD
 	}
 	_ = d.Set("additional_volume_ids", additionalVolumesIDs)
 
+	////
+	// Read private networks
+	////
+	// Only the private networks of the state are read, so that the NICs of scaleway_instance_private_nic do not show in the diff.
+	if _, privateNetworksSet := d.GetOk("private_network"); privateNetworksSet {
+		privateNICs, err := instanceAPI.ListPrivateNICs(&instance.ListPrivateNICsRequest{
+			Zone:     zone,
+			ServerID: ID,
+		}, scw.WithContext(ctx))
+		if err != nil {
+			return diagFromErr(err)
+		}
+		_ = d.Set("private_network", flattenInstanceServerPrivateNICs(zone, privateNICs.PrivateNics, d.Get("private_network")))
+	}
+
 	////
 	// Read server user data
 	////
@@ -485,7 +542,7 @@ func resourceScalewayInstanceServerRead(ctx context.Context, d *schema.ResourceD
 	for key, value := range allUserData.UserData {
 		userDataValue, err := ioutil.ReadAll(value)
 		if err != nil {
-			return diag.FromErr(err)
+			return diagFromErr(err)
 		}
 		//if key != "cloud-init" {
 		userData[key] = string(userDataValue)
@@ -503,7 +560,7 @@ func resourceScalewayInstanceServerRead(ctx context.Context, d *schema.ResourceD
 func resourceScalewayInstanceServerUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	instanceAPI, zone, ID, err := instanceAPIWithZoneAndID(meta, d.Id())
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	wantedState := d.Get("state").(string)
@@ -523,8 +580,8 @@ func resourceScalewayInstanceServerUpdate(ctx context.Context, d *schema.Resourc
 		updateRequest.Name = expandStringPtr(d.Get("name"))
 	}
 
-	if d.HasChange("tags") {
-		updateRequest.Tags = scw.StringsPtr(expandStrings(d.Get("tags")))
+	if d.HasChanges("tags", "tags_all") {
+		updateRequest.Tags = scw.StringsPtr(expandTags(meta, d.Get("tags")))
 	}
 
 	if d.HasChange("security_group_id") {
@@ -559,13 +616,13 @@ func resourceScalewayInstanceServerUpdate(ctx context.Context, d *schema.Resourc
 					VolumeID: expandZonedID(volumeID).ID,
 				})
 				if err != nil {
-					return diag.FromErr(err)
+					return diagFromErr(err)
 				}
 
 				// We must be able to tell whether a volume is already present in the server or not
 				if volumeResp.Volume.Server != nil {
 					if volumeResp.Volume.VolumeType == instance.VolumeVolumeTypeLSSD && volumeResp.Volume.Server.ID != "" {
-						return diag.FromErr(fmt.Errorf("instance must be stopped to change local volumes"))
+						return diagFromErr(fmt.Errorf("instance must be stopped to change local volumes"))
 					}
 				}
 			}
@@ -584,7 +641,7 @@ func resourceScalewayInstanceServerUpdate(ctx context.Context, d *schema.Resourc
 			updateRequest.PlacementGroup = &instance.NullableStringValue{Null: true}
 		} else {
 			if !isStopped {
-				return diag.FromErr(fmt.Errorf("instance must be stopped to change placement group"))
+				return diagFromErr(fmt.Errorf("instance must be stopped to change placement group"))
 			}
 			updateRequest.PlacementGroup = &instance.NullableStringValue{Value: placementGroupID}
 		}
@@ -599,7 +656,7 @@ func resourceScalewayInstanceServerUpdate(ctx context.Context, d *schema.Resourc
 			ServerID: ID,
 		})
 		if err != nil {
-			return diag.FromErr(err)
+			return diagFromErr(err)
 		}
 		newIPID := expandZonedID(d.Get("ip_id")).ID
 
@@ -611,7 +668,7 @@ func resourceScalewayInstanceServerUpdate(ctx context.Context, d *schema.Resourc
 				Server: &instance.NullableStringValue{Null: true},
 			})
 			if err != nil {
-				return diag.FromErr(err)
+				return diagFromErr(err)
 			}
 		}
 
@@ -623,7 +680,7 @@ func resourceScalewayInstanceServerUpdate(ctx context.Context, d *schema.Resourc
 				Server: &instance.NullableStringValue{Value: ID},
 			}, scw.WithContext(ctx))
 			if err != nil {
-				return diag.FromErr(err)
+				return diagFromErr(err)
 			}
 		}
 	}
@@ -649,6 +706,21 @@ func resourceScalewayInstanceServerUpdate(ctx context.Context, d *schema.Resourc
 		}
 	}
 
+	////
+	// Resize root volume
+	////
+	if d.HasChange("root_volume.0.size_in_gb") {
+		// Only block root volumes reach this point, the plan recreates servers booting on a local volume.
+		err = resizeInstanceVolume(ctx, instanceAPI, zone, expandZonedID(d.Get("root_volume.0.volume_id")).ID, d.Get("root_volume.0.size_in_gb").(int), d.Timeout(schema.TimeoutUpdate))
+		if err != nil {
+			return diagFromErr(err)
+		}
+		warnings = append(warnings, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  "the root partition of the instance may need to be extended to use the new volume size",
+		})
+	}
+
 	////
 	// Update server user data
 	////
@@ -674,7 +746,28 @@ func resourceScalewayInstanceServerUpdate(ctx context.Context, d *schema.Resourc
 
 		err := instanceAPI.SetAllServerUserData(userDataRequests)
 		if err != nil {
-			return diag.FromErr(err)
+			return diagFromErr(err)
+		}
+	}
+
+	////
+	// Update private networks
+	////
+	if d.HasChange("private_network") {
+		oldPrivateNetworks, newPrivateNetworks := d.GetChange("private_network")
+		err = updateInstanceServerPrivateNICs(ctx, instanceAPI, zone, ID, expandInstanceServerPrivateNetworkIDs(oldPrivateNetworks), expandInstanceServerPrivateNetworkIDs(newPrivateNetworks))
+		if err != nil {
+			return diagFromErr(err)
+		}
+	}
+
+	////
+	// Change server type
+	////
+	if d.HasChange("type") {
+		err = updateInstanceServerCommercialType(ctx, d, meta, instanceAPI, zone, ID)
+		if err != nil {
+			return diagFromErr(err)
 		}
 	}
 
@@ -684,18 +777,18 @@ func resourceScalewayInstanceServerUpdate(ctx context.Context, d *schema.Resourc
 
 	targetState, err := serverStateExpand(d.Get("state").(string))
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	// reach expected state
-	err = reachState(ctx, instanceAPI, zone, ID, targetState)
+	err = reachState(ctx, instanceAPI, zone, ID, targetState, d.Timeout(schema.TimeoutUpdate))
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	_, err = instanceAPI.UpdateServer(updateRequest)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	return append(warnings, resourceScalewayInstanceServerRead(ctx, d, meta)...)
@@ -704,16 +797,16 @@ func resourceScalewayInstanceServerUpdate(ctx context.Context, d *schema.Resourc
 func resourceScalewayInstanceServerDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	instanceAPI, zone, ID, err := instanceAPIWithZoneAndID(meta, d.Id())
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	// reach stopped state
-	err = reachState(ctx, instanceAPI, zone, ID, instance.ServerStateStopped)
+	err = reachState(ctx, instanceAPI, zone, ID, instance.ServerStateStopped, d.Timeout(schema.TimeoutDelete))
 	if is404Error(err) {
 		return nil
 	}
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	err = instanceAPI.DeleteServer(&instance.DeleteServerRequest{
@@ -722,7 +815,7 @@ func resourceScalewayInstanceServerDelete(ctx context.Context, d *schema.Resourc
 	}, scw.WithContext(ctx))
 
 	if err != nil && !is404Error(err) {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	// Related to https://github.com/hashicorp/terraform-plugin-sdk/issues/142
@@ -733,9 +826,132 @@ func resourceScalewayInstanceServerDelete(ctx context.Context, d *schema.Resourc
 			VolumeID: expandZonedID(d.Get("root_volume.0.volume_id")).ID,
 		})
 		if err != nil && !is404Error(err) {
-			return diag.FromErr(err)
+			return diagFromErr(err)
+		}
+	}
+
+	return nil
+}
+
+// updateInstanceServerCommercialType stops the server to change its commercial type.
+// The requested state is restored with the other changes of the update.
+func updateInstanceServerCommercialType(ctx context.Context, d *schema.ResourceData, meta interface{}, instanceAPI *instance.API, zone scw.Zone, id string) error {
+	oldType, newType := d.GetChange("type")
+	fromServerType, serverType, err := getInstanceServerTypeChange(ctx, meta, zone, oldType.(string), newType.(string))
+	if err != nil {
+		return err
+	}
+	// The catalog is keyed by uppercase types, the configuration may not be.
+	commercialType := strings.ToUpper(newType.(string))
+	// The catalog may have changed since the plan.
+	if !instanceServerTypesCompatible(fromServerType, serverType) {
+		return fmt.Errorf("server type %s is not compatible with %s, the server must be recreated", commercialType, oldType)
+	}
+
+	server, err := instanceAPI.GetServer(&instance.GetServerRequest{
+		Zone:     zone,
+		ServerID: id,
+	}, scw.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+
+	// Validate the local volumes of the server against the new type before stopping it.
+	volumes := make(map[string]*instance.VolumeTemplate)
+	for index, volume := range server.Server.Volumes {
+		volumes[index] = &instance.VolumeTemplate{
+			ID:         volume.ID,
+			VolumeType: volume.VolumeType,
+			Size:       volume.Size,
 		}
 	}
+	if err = validateLocalVolumeSizes(volumes, serverType, commercialType); err != nil {
+		return err
+	}
+
+	err = reachState(ctx, instanceAPI, zone, id, instance.ServerStateStopped, d.Timeout(schema.TimeoutUpdate))
+	if err != nil {
+		return err
+	}
+
+	return updateInstanceServerType(ctx, meta, zone, id, commercialType)
+}
+
+// getInstanceServerTypeChange returns the catalog entries of the current and new types of a server.
+func getInstanceServerTypeChange(ctx context.Context, meta interface{}, zone scw.Zone, oldType string, newType string) (*instance.ServerType, *instance.ServerType, error) {
+	serverTypes, err := instanceServerTypes(ctx, meta, zone)
+	if err != nil {
+		return nil, nil, fmt.Errorf("cannot get server types: %w", err)
+	}
+	oldType, newType = strings.ToUpper(oldType), strings.ToUpper(newType)
+	for _, commercialType := range []string{oldType, newType} {
+		if _, exist := serverTypes[commercialType]; !exist {
+			return nil, nil, fmt.Errorf("type: server type %s is not in the catalog of zone %s, cannot change the type of the server from %s to %s", commercialType, zone, oldType, newType)
+		}
+	}
+	return serverTypes[oldType], serverTypes[newType], nil
+}
+
+// customizeDiffInstanceServerType plans the change of the type of an existing server.
+// The type is changed in place, unless the new type is not compatible with the current one.
+// Both types must be in the catalog.
+func customizeDiffInstanceServerType(ctx context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+	if diff.Id() == "" || !diff.NewValueKnown("type") || !diff.HasChange("type") {
+		return nil
+	}
+
+	zone, err := extractZone(diff, meta.(*Meta))
+	if err != nil {
+		return err
+	}
+	oldType, newType := diff.GetChange("type")
+	fromServerType, toServerType, err := getInstanceServerTypeChange(ctx, meta, zone, oldType.(string), newType.(string))
+	if err != nil {
+		return err
+	}
+
+	if !instanceServerTypesCompatible(fromServerType, toServerType) {
+		return diff.ForceNew("type")
+	}
+
+	return nil
+}
+
+// customizeDiffInstanceServerRootVolumeSize plans the resize of the root volume of an existing server.
+// Servers booting on a block volume grow it in place, it cannot be shrunk.
+// Local root volumes cannot be resized: the server is recreated, which validates the new local volume sizes.
+func customizeDiffInstanceServerRootVolumeSize(ctx context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+	if diff.Id() == "" || !diff.NewValueKnown("root_volume.0.size_in_gb") || !diff.HasChange("root_volume.0.size_in_gb") {
+		return nil
+	}
+
+	zone, err := extractZone(diff, meta.(*Meta))
+	if err != nil {
+		return err
+	}
+	// A type unknown at plan can only change to a compatible type, booting on the same kind of volume.
+	commercialType := diff.Get("type").(string)
+	if !diff.NewValueKnown("type") {
+		oldType, _ := diff.GetChange("type")
+		commercialType = oldType.(string)
+	}
+	commercialType = strings.ToUpper(commercialType)
+	serverTypes, err := instanceServerTypes(ctx, meta, zone)
+	if err != nil {
+		return fmt.Errorf("cannot get server types: %w", err)
+	}
+	serverType, exist := serverTypes[commercialType]
+	if !exist {
+		return fmt.Errorf("root_volume.0.size_in_gb: server type %s is not in the catalog of zone %s, the kind of its root volume is unknown", commercialType, zone)
+	}
+	// Local root volumes cannot be resized, the server must be recreated.
+	if !isInstanceServerTypeBootOnBlock(serverType) {
+		return diff.ForceNew("root_volume.0.size_in_gb")
+	}
+
+	if oldSize, newSize := diff.GetChange("root_volume.0.size_in_gb"); newSize.(int) < oldSize.(int) {
+		return fmt.Errorf("root_volume.0.size_in_gb: block root volume cannot be resized down from %d GB to %d GB", oldSize, newSize)
+	}
 
 	return nil
 }
@@ -1,48 +1,59 @@
 package scaleway
 
 import (
+	"context"
 	"fmt"
+	"net/http"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	"github.com/scaleway/scaleway-sdk-go/api/instance/v1"
 	"github.com/scaleway/scaleway-sdk-go/scw"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func init() {
 	resource.AddTestSweepers("scaleway_instance_server", &resource.Sweeper{
-		Name: "scaleway_instance_server",
-		F:    testSweepInstanceServer,
+		Name:         "scaleway_instance_server",
+		F:            testSweepInstanceServer,
+		Dependencies: []string{"scaleway_k8s_cluster"},
 	})
 }
 
 func testSweepInstanceServer(_ string) error {
-	return sweepZones(scw.AllZones, func(scwClient *scw.Client, zone scw.Zone) error {
+	return sweepAllZones(func(scwClient *scw.Client, zone scw.Zone) error {
 		instanceAPI := instance.NewAPI(scwClient)
 		l.Debugf("sweeper: destroying the instance server in (%s)", zone)
-		listServers, err := instanceAPI.ListServers(&instance.ListServersRequest{}, scw.WithAllPages())
+		listServers, err := instanceAPI.ListServers(&instance.ListServersRequest{
+			Zone: zone,
+		}, scw.WithAllPages())
 		if err != nil {
 			l.Warningf("error listing servers in (%s) in sweeper: %s", zone, err)
 			return nil
 		}
 
 		for _, srv := range listServers.Servers {
-			if srv.State == instance.ServerStateStopped || srv.State == instance.ServerStateStoppedInPlace {
-				err := instanceAPI.DeleteServer(&instance.DeleteServerRequest{
-					ServerID: srv.ID,
-				})
-				if err != nil {
-					return fmt.Errorf("error deleting server in sweeper: %s", err)
-				}
-			} else if srv.State == instance.ServerStateRunning {
-				_, err := instanceAPI.ServerAction(&instance.ServerActionRequest{
-					ServerID: srv.ID,
-					Action:   instance.ServerActionTerminate,
-				})
-				if err != nil {
-					return fmt.Errorf("error terminating server in sweeper: %s", err)
+			err := sweep(&sweptResource{kind: "instance server", id: srv.ID, name: srv.Name, tags: srv.Tags, createdAt: srv.CreationDate}, func() error {
+				if srv.State == instance.ServerStateStopped || srv.State == instance.ServerStateStoppedInPlace {
+					return instanceAPI.DeleteServer(&instance.DeleteServerRequest{
+						Zone:     zone,
+						ServerID: srv.ID,
+					})
+				} else if srv.State == instance.ServerStateRunning {
+					_, err := instanceAPI.ServerAction(&instance.ServerActionRequest{
+						Zone:     zone,
+						ServerID: srv.ID,
+						Action:   instance.ServerActionTerminate,
+					})
+					return err
 				}
+				return nil
+			})
+			if err != nil {
+				return fmt.Errorf("error deleting server in sweeper: %s", err)
 			}
 		}
 
@@ -882,3 +893,430 @@ func TestAccScalewayInstanceServer_Enterprise(t *testing.T) {
 		},
 	})
 }
+
+func testFakeAPISeedInstanceServer(f *fakeAPI, state string) (map[string]interface{}, *schema.ResourceData) {
+	volume := f.seed("instance", "fr-par-1", "volumes", map[string]interface{}{
+		"name":        "root",
+		"size":        20000000000,
+		"volume_type": "l_ssd",
+	})
+	server := f.seed("instance", "fr-par-1", "servers", map[string]interface{}{
+		"name":            "server",
+		"commercial_type": "DEV1-S",
+		"volumes": map[string]interface{}{
+			"0": volume,
+		},
+	})
+	f.update("instance", "fr-par-1", "servers", server["id"].(string), map[string]interface{}{"state": state})
+
+	d := f.resourceData(resourceScalewayInstanceServer(), newZonedIDString("fr-par-1", server["id"].(string)), map[string]interface{}{})
+	return server, d
+}
+
+func TestScalewayInstanceServer_FakeAPIDeleteRunning(t *testing.T) {
+	f := newFakeAPI(t)
+	defer f.close()
+	meta := f.meta()
+	ctx := context.Background()
+
+	server, d := testFakeAPISeedInstanceServer(f, "running")
+	serverID := server["id"].(string)
+	volumeID := server["volumes"].(map[string]interface{})["0"].(map[string]interface{})["id"].(string)
+
+	require.False(t, resourceScalewayInstanceServerRead(ctx, d, meta).HasError())
+	assert.Equal(t, InstanceServerStateStarted, d.Get("state"))
+	assert.Equal(t, newZonedIDString("fr-par-1", volumeID), d.Get("root_volume.0.volume_id"))
+
+	// A running server must be stopped before being deleted along with its root volume.
+	require.False(t, resourceScalewayInstanceServerDelete(ctx, d, meta).HasError())
+	assert.Nil(t, f.lookup("instance", "fr-par-1", "servers", serverID))
+	assert.Nil(t, f.lookup("instance", "fr-par-1", "volumes", volumeID))
+	assert.Contains(t, f.receivedRequests(), "POST /instance/v1/zones/fr-par-1/servers/"+serverID+"/action")
+}
+
+func TestScalewayInstanceServer_FakeAPILocked(t *testing.T) {
+	f := newFakeAPI(t)
+	defer f.close()
+	meta := f.meta()
+	ctx := context.Background()
+
+	server, d := testFakeAPISeedInstanceServer(f, "locked")
+
+	diags := resourceScalewayInstanceServerRead(ctx, d, meta)
+	require.True(t, diags.HasError())
+	assert.Contains(t, diags[0].Summary, "server is locked")
+
+	require.True(t, resourceScalewayInstanceServerDelete(ctx, d, meta).HasError())
+	assert.NotNil(t, f.lookup("instance", "fr-par-1", "servers", server["id"].(string)))
+}
+
+func TestScalewayInstanceServer_FakeAPINotFound(t *testing.T) {
+	f := newFakeAPI(t)
+	defer f.close()
+	meta := f.meta()
+	ctx := context.Background()
+
+	server, d := testFakeAPISeedInstanceServer(f, "stopped")
+	f.respond(http.MethodGet, "/instance/v1/zones/fr-par-1/servers/"+server["id"].(string), http.StatusNotFound, fakeAPIError("not_found", "server not found"), true)
+
+	require.False(t, resourceScalewayInstanceServerRead(ctx, d, meta).HasError())
+	assert.Empty(t, d.Id())
+}
+
+func TestScalewayInstanceServer_FakeAPIPlanType(t *testing.T) {
+	f := newFakeAPI(t)
+	defer f.close()
+	meta := f.meta()
+
+	f.respond(http.MethodGet, "/instance/v1/zones/fr-par-1/products/servers", http.StatusOK, map[string]interface{}{
+		"servers": map[string]interface{}{
+			"DEV1-S":    map[string]interface{}{},
+			"DEV1-M":    map[string]interface{}{},
+			"GP1-XS":    map[string]interface{}{},
+			"STARDUST1": map[string]interface{}{},
+		},
+	}, false)
+
+	// Types are compared case-insensitively.
+	require.NoError(t, f.plan(meta, resourceScalewayInstanceServer(), map[string]interface{}{"type": "dev1-s", "image": "ubuntu_focal"}))
+
+	err := f.plan(meta, resourceScalewayInstanceServer(), map[string]interface{}{"type": "DEV1-SS", "image": "ubuntu_focal"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `unknown server type "DEV1-SS", did you mean DEV1-S, DEV1-M?`)
+
+	err = f.plan(meta, resourceScalewayInstanceServer(), map[string]interface{}{"type": "ENT1-XXL", "image": "ubuntu_focal"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `unknown server type "ENT1-XXL"`)
+	assert.NotContains(t, err.Error(), "did you mean")
+
+	// Another zone has its own catalog, which the fake API does not serve: the check is skipped.
+	require.NoError(t, f.plan(meta, resourceScalewayInstanceServer(), map[string]interface{}{"type": "DEV1-SS", "image": "ubuntu_focal", "zone": "nl-ams-1"}))
+
+	// The catalog of a zone is fetched once for all the servers planned with the same meta.
+	catalogRequests := 0
+	for _, request := range f.receivedRequests() {
+		if request == "GET /instance/v1/zones/fr-par-1/products/servers" {
+			catalogRequests++
+		}
+	}
+	assert.Equal(t, 1, catalogRequests)
+}
+
+// testFakeAPIInstanceServerTypes serves a catalog with server types booting on block and local volumes of both architectures.
+func testFakeAPIInstanceServerTypes(f *fakeAPI) {
+	f.respond(http.MethodGet, "/instance/v1/zones/fr-par-1/products/servers", http.StatusOK, map[string]interface{}{
+		"servers": map[string]interface{}{
+			"DEV1-S": map[string]interface{}{
+				"arch":               "x86_64",
+				"volumes_constraint": map[string]interface{}{"min_size": 20000000000, "max_size": 20000000000},
+			},
+			"GP1-XS": map[string]interface{}{
+				"arch":               "x86_64",
+				"volumes_constraint": map[string]interface{}{"min_size": 0, "max_size": 0},
+			},
+			"DEV1-M": map[string]interface{}{
+				"arch":               "x86_64",
+				"volumes_constraint": map[string]interface{}{"min_size": 20000000000, "max_size": 40000000000},
+			},
+			"DEV1-L": map[string]interface{}{
+				"arch":               "x86_64",
+				"volumes_constraint": map[string]interface{}{"min_size": 80000000000, "max_size": 80000000000},
+			},
+			"ARM64-2GB": map[string]interface{}{
+				"arch":               "arm",
+				"volumes_constraint": map[string]interface{}{"min_size": 20000000000, "max_size": 20000000000},
+			},
+		},
+	}, false)
+}
+
+func testFakeAPISeedInstanceServerWithRootVolume(f *fakeAPI, commercialType string, volumeType string) (map[string]interface{}, *schema.ResourceData) {
+	volume := f.seed("instance", "fr-par-1", "volumes", map[string]interface{}{
+		"name":        "root",
+		"size":        20000000000,
+		"volume_type": volumeType,
+	})
+	server := f.seed("instance", "fr-par-1", "servers", map[string]interface{}{
+		"name":            "server",
+		"commercial_type": commercialType,
+		"image":           map[string]interface{}{"id": "44444444-4444-4444-4444-444444444444"},
+		"volumes": map[string]interface{}{
+			"0": volume,
+		},
+	})
+	f.update("instance", "fr-par-1", "servers", server["id"].(string), map[string]interface{}{"state": "running"})
+
+	d := f.resourceData(resourceScalewayInstanceServer(), newZonedIDString("fr-par-1", server["id"].(string)), map[string]interface{}{})
+	return server, d
+}
+
+func TestScalewayInstanceServer_FakeAPIRootVolumeResize(t *testing.T) {
+	f := newFakeAPI(t)
+	defer f.close()
+	meta := f.meta()
+	ctx := context.Background()
+	testFakeAPIInstanceServerTypes(f)
+
+	server, d := testFakeAPISeedInstanceServerWithRootVolume(f, "GP1-XS", "b_ssd")
+	volumeID := server["volumes"].(map[string]interface{})["0"].(map[string]interface{})["id"].(string)
+	require.False(t, resourceScalewayInstanceServerRead(ctx, d, meta).HasError())
+	assert.Equal(t, 20, d.Get("root_volume.0.size_in_gb"))
+
+	config := func(sizeInGB int) map[string]interface{} {
+		return map[string]interface{}{
+			"type":        "GP1-XS",
+			"image":       "fr-par-1/44444444-4444-4444-4444-444444444444",
+			"root_volume": []interface{}{map[string]interface{}{"size_in_gb": sizeInGB}},
+		}
+	}
+
+	// Block root volumes cannot shrink.
+	_, err := f.planUpdate(meta, resourceScalewayInstanceServer(), d, config(10))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "block root volume cannot be resized down from 20 GB to 10 GB")
+
+	// Block root volumes grow in place.
+	diff, err := f.planUpdate(meta, resourceScalewayInstanceServer(), d, config(30))
+	require.NoError(t, err)
+	assert.False(t, diff.RequiresNew())
+
+	_, err = f.applyUpdate(meta, resourceScalewayInstanceServer(), d, config(30))
+	require.NoError(t, err)
+	assert.NotNil(t, f.lookup("instance", "fr-par-1", "servers", server["id"].(string)))
+	assert.EqualValues(t, 30000000000, f.lookup("instance", "fr-par-1", "volumes", volumeID)["size"])
+	assert.Contains(t, f.receivedRequests(), "PATCH /instance/v1/zones/fr-par-1/volumes/"+volumeID)
+}
+
+func TestScalewayInstanceServer_FakeAPIRootVolumeResizeLocal(t *testing.T) {
+	f := newFakeAPI(t)
+	defer f.close()
+	meta := f.meta()
+	ctx := context.Background()
+	f.respond(http.MethodGet, "/instance/v1/zones/fr-par-1/products/servers", http.StatusInternalServerError, fakeAPIError("internal_error", "catalog unavailable"), true)
+	testFakeAPIInstanceServerTypes(f)
+
+	_, d := testFakeAPISeedInstanceServerWithRootVolume(f, "DEV1-S", "l_ssd")
+	require.False(t, resourceScalewayInstanceServerRead(ctx, d, meta).HasError())
+	config := map[string]interface{}{
+		"type":        "DEV1-S",
+		"image":       "fr-par-1/44444444-4444-4444-4444-444444444444",
+		"root_volume": []interface{}{map[string]interface{}{"size_in_gb": 30}},
+	}
+
+	// The server is not recreated when the catalog cannot tell the kind of its root volume.
+	diff, err := f.planUpdate(meta, resourceScalewayInstanceServer(), d, config)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "cannot get server types")
+	assert.False(t, diff.RequiresNew())
+
+	// Local root volumes cannot be resized, the server is recreated.
+	diff, err = f.planUpdate(meta, resourceScalewayInstanceServer(), d, config)
+	require.NoError(t, err)
+	assert.True(t, diff.RequiresNew())
+
+	// The type is not case sensitive.
+	config["type"] = "dev1-m"
+	diff, err = f.planUpdate(meta, resourceScalewayInstanceServer(), d, config)
+	require.NoError(t, err)
+	assert.True(t, diff.RequiresNew())
+}
+
+func TestScalewayInstanceServer_FakeAPIChangeType(t *testing.T) {
+	f := newFakeAPI(t)
+	defer f.close()
+	meta := f.meta()
+	ctx := context.Background()
+	// The catalog fails for both the validation of the type and the compatibility check of the first plan.
+	for i := 0; i < 2; i++ {
+		f.respond(http.MethodGet, "/instance/v1/zones/fr-par-1/products/servers", http.StatusInternalServerError, fakeAPIError("internal_error", "catalog unavailable"), true)
+	}
+	testFakeAPIInstanceServerTypes(f)
+
+	server, d := testFakeAPISeedInstanceServerWithRootVolume(f, "DEV1-S", "l_ssd")
+	serverID := server["id"].(string)
+	require.False(t, resourceScalewayInstanceServerRead(ctx, d, meta).HasError())
+
+	config := func(commercialType string) map[string]interface{} {
+		return map[string]interface{}{
+			"type":  commercialType,
+			"image": "fr-par-1/44444444-4444-4444-4444-444444444444",
+		}
+	}
+
+	// The change is not planned in place when the catalog cannot tell whether the types are compatible.
+	_, err := f.planUpdate(meta, resourceScalewayInstanceServer(), d, config("DEV1-M"))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "cannot get server types")
+
+	// Types of another architecture or booting on another kind of volume are not compatible.
+	for _, commercialType := range []string{"ARM64-2GB", "GP1-XS"} {
+		diff, err := f.planUpdate(meta, resourceScalewayInstanceServer(), d, config(commercialType))
+		require.NoError(t, err)
+		assert.True(t, diff.RequiresNew(), commercialType)
+	}
+
+	// The compatibility of the types is checked again before the server is stopped.
+	diff, err := f.planUpdate(meta, resourceScalewayInstanceServer(), d, config("DEV1-M"))
+	require.NoError(t, err)
+	diff.Attributes["type"].New = "ARM64-2GB"
+	_, diags := resourceScalewayInstanceServer().Apply(ctx, d.State(), diff, meta)
+	require.True(t, diags.HasError())
+	assert.Contains(t, diags[0].Summary, "server type ARM64-2GB is not compatible with DEV1-S")
+	assert.Equal(t, "running", f.lookup("instance", "fr-par-1", "servers", serverID)["state"])
+	assert.NotContains(t, f.receivedRequests(), "POST /instance/v1/zones/fr-par-1/servers/"+serverID+"/action")
+
+	// The local volumes of the server must fit the new type.
+	_, err = f.applyUpdate(meta, resourceScalewayInstanceServer(), d, config("DEV1-L"))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "DEV1-L total local volume size must be equal to 80 GB")
+	assert.Equal(t, "running", f.lookup("instance", "fr-par-1", "servers", serverID)["state"])
+
+	// The server is stopped to change its type then started again, the type is not case sensitive.
+	diff, err = f.planUpdate(meta, resourceScalewayInstanceServer(), d, config("dev1-m"))
+	require.NoError(t, err)
+	assert.False(t, diff.RequiresNew())
+
+	state, err := f.applyUpdate(meta, resourceScalewayInstanceServer(), d, config("dev1-m"))
+	require.NoError(t, err)
+	assert.Equal(t, d.Id(), state.ID)
+	assert.Equal(t, "DEV1-M", state.Attributes["type"])
+	assert.Equal(t, InstanceServerStateStarted, state.Attributes["state"])
+	assert.Equal(t, "DEV1-M", f.lookup("instance", "fr-par-1", "servers", serverID)["commercial_type"])
+
+	requests := f.receivedRequests()
+	patchIndex := -1
+	for i, request := range requests {
+		if request == "PATCH /instance/v1/zones/fr-par-1/servers/"+serverID {
+			patchIndex = i
+			break
+		}
+	}
+	require.NotEqual(t, -1, patchIndex)
+	assert.Contains(t, requests[:patchIndex], "POST /instance/v1/zones/fr-par-1/servers/"+serverID+"/action")
+	assert.Contains(t, requests[patchIndex:], "POST /instance/v1/zones/fr-par-1/servers/"+serverID+"/action")
+}
+
+func TestScalewayInstanceServer_FakeAPIPrivateNetwork(t *testing.T) {
+	f := newFakeAPI(t)
+	defer f.close()
+	meta := f.meta()
+	ctx := context.Background()
+	testFakeAPIInstanceServerTypes(f)
+
+	const (
+		pn1 = "fr-par-1/11111111-1111-1111-1111-111111111111"
+		pn2 = "fr-par-1/22222222-2222-2222-2222-222222222222"
+		pn3 = "fr-par-1/33333333-3333-3333-3333-333333333333"
+		pn4 = "fr-par-1/55555555-5555-5555-5555-555555555555"
+	)
+	server, d := testFakeAPISeedInstanceServerWithRootVolume(f, "DEV1-S", "l_ssd")
+	serverID := server["id"].(string)
+	instanceAPI := instance.NewAPI(meta.scwClient)
+	privateNICs := func() map[string]string {
+		res, err := instanceAPI.ListPrivateNICs(&instance.ListPrivateNICsRequest{Zone: scw.ZoneFrPar1, ServerID: serverID})
+		require.NoError(t, err)
+		nics := map[string]string{}
+		for _, nic := range res.PrivateNics {
+			nics[newZonedIDString(scw.ZoneFrPar1, nic.PrivateNetworkID)] = nic.ID
+		}
+		return nics
+	}
+	config := func(privateNetworkIDs ...string) map[string]interface{} {
+		privateNetworks := []interface{}(nil)
+		for _, privateNetworkID := range privateNetworkIDs {
+			privateNetworks = append(privateNetworks, map[string]interface{}{"private_network_id": privateNetworkID})
+		}
+		return map[string]interface{}{
+			"type":            "DEV1-S",
+			"image":           "fr-par-1/44444444-4444-4444-4444-444444444444",
+			"private_network": privateNetworks,
+		}
+	}
+
+	// NICs managed with scaleway_instance_private_nic are never read nor deleted by the server.
+	_, err := instanceAPI.CreatePrivateNIC(&instance.CreatePrivateNICRequest{Zone: scw.ZoneFrPar1, ServerID: serverID, PrivateNetworkID: expandID(pn1)})
+	require.NoError(t, err)
+	separateNICID := privateNICs()[pn1]
+	require.False(t, resourceScalewayInstanceServerRead(ctx, d, meta).HasError())
+	assert.Equal(t, 0, d.Get("private_network.#"))
+
+	state, err := f.applyUpdate(meta, resourceScalewayInstanceServer(), d, config(pn2, pn3))
+	require.NoError(t, err)
+	d = resourceScalewayInstanceServer().Data(state)
+	nics := privateNICs()
+	require.Len(t, nics, 3)
+	assert.Equal(t, separateNICID, nics[pn1])
+	assert.Equal(t, 2, d.Get("private_network.#"))
+	assert.Equal(t, pn2, d.Get("private_network.0.private_network_id"))
+	assert.Equal(t, pn3, d.Get("private_network.1.private_network_id"))
+	assert.NotEmpty(t, d.Get("private_network.1.mac_address"))
+
+	require.False(t, resourceScalewayInstanceServerRead(ctx, d, meta).HasError())
+	diff, err := f.planUpdate(meta, resourceScalewayInstanceServer(), d, config(pn2, pn3))
+	require.NoError(t, err)
+	assert.True(t, diff.Empty())
+
+	// Only the NICs of the removed networks are deleted.
+	state, err = f.applyUpdate(meta, resourceScalewayInstanceServer(), d, config(pn3, pn4))
+	require.NoError(t, err)
+	d = resourceScalewayInstanceServer().Data(state)
+	updatedNICs := privateNICs()
+	require.Len(t, updatedNICs, 3)
+	assert.NotContains(t, updatedNICs, pn2)
+	assert.Equal(t, separateNICID, updatedNICs[pn1])
+	assert.Equal(t, nics[pn3], updatedNICs[pn3])
+	assert.Equal(t, pn3, d.Get("private_network.0.private_network_id"))
+	assert.Equal(t, pn4, d.Get("private_network.1.private_network_id"))
+
+	// A NIC deleted outside of terraform is removed from the state.
+	require.NoError(t, instanceAPI.DeletePrivateNIC(&instance.DeletePrivateNICRequest{Zone: scw.ZoneFrPar1, ServerID: serverID, PrivateNicID: updatedNICs[pn3]}))
+	require.False(t, resourceScalewayInstanceServerRead(ctx, d, meta).HasError())
+	assert.Equal(t, 1, d.Get("private_network.#"))
+	assert.Equal(t, pn4, d.Get("private_network.0.private_network_id"))
+
+	// Removing the last private_network block only deletes the NICs it created.
+	_, err = f.applyUpdate(meta, resourceScalewayInstanceServer(), d, config())
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{pn1: separateNICID}, privateNICs())
+
+	planned := config("nl-ams-1/11111111-1111-1111-1111-111111111111")
+	planned["zone"] = "fr-par-1"
+	err = f.plan(meta, resourceScalewayInstanceServer(), planned)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "private_network.#.private_network_id: nl-ams-1/11111111-1111-1111-1111-111111111111 is in nl-ams-1 but the resource is in zone fr-par-1")
+}
+
+func TestScalewayInstanceServer_FakeAPIPrivateNetworkCreate(t *testing.T) {
+	f := newFakeAPI(t)
+	defer f.close()
+	meta := f.meta()
+	ctx := context.Background()
+	testFakeAPIInstanceServerTypes(f)
+
+	d := f.resourceData(resourceScalewayInstanceServer(), "", map[string]interface{}{
+		"type":  "DEV1-S",
+		"image": "fr-par-1/44444444-4444-4444-4444-444444444444",
+		"private_network": []interface{}{
+			map[string]interface{}{"private_network_id": "fr-par-1/11111111-1111-1111-1111-111111111111"},
+		},
+	})
+	diags := resourceScalewayInstanceServerCreate(ctx, d, meta)
+	require.False(t, diags.HasError(), diags)
+	assert.Equal(t, "fr-par-1/11111111-1111-1111-1111-111111111111", d.Get("private_network.0.private_network_id"))
+	assert.NotEmpty(t, d.Get("private_network.0.mac_address"))
+
+	// The server is attached to its private networks before being started.
+	serverID := expandID(d.Id())
+	nicIndex, actionIndex := -1, -1
+	for i, request := range f.receivedRequests() {
+		switch request {
+		case "POST /instance/v1/zones/fr-par-1/servers/" + serverID + "/private_nics":
+			nicIndex = i
+		case "POST /instance/v1/zones/fr-par-1/servers/" + serverID + "/action":
+			actionIndex = i
+		}
+	}
+	require.NotEqual(t, -1, nicIndex)
+	require.NotEqual(t, -1, actionIndex)
+	assert.Less(t, nicIndex, actionIndex)
+}
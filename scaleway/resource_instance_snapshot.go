@@ -0,0 +1,156 @@
+package scaleway
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/scaleway/scaleway-sdk-go/api/instance/v1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+)
+
+func resourceScalewayInstanceSnapshot() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceScalewayInstanceSnapshotCreate,
+		ReadContext:   resourceScalewayInstanceSnapshotRead,
+		DeleteContext: resourceScalewayInstanceSnapshotDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: importStateByName("instance snapshot", instanceSnapshotIDsByName),
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Default: schema.DefaultTimeout(defaultInstanceSnapshotWaitTimeout),
+		},
+		CustomizeDiff: customizeDiffLocalityCheck("zone", "volume_id"),
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "The name of the snapshot",
+			},
+			"volume_id": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				Description:      "The ID of the volume to take a snapshot from",
+				ValidateFunc:     validationUUIDorUUIDWithLocality(),
+				DiffSuppressFunc: diffSuppressFuncLocality,
+			},
+			"type": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The volume type of the snapshot",
+			},
+			"size_in_gb": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The size of the snapshot in gigabyte",
+			},
+			"state": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The state of the snapshot",
+			},
+			"created_at": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The date and time of the creation of the snapshot",
+			},
+			"organization_id": organizationIDSchema(),
+			"project_id":      projectIDSchema(),
+			"zone":            zoneSchema(),
+		},
+	}
+}
+
+func resourceScalewayInstanceSnapshotCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	instanceAPI, zone, err := instanceAPIWithZone(d, meta)
+	if err != nil {
+		return diagFromErr(err)
+	}
+
+	res, err := instanceAPI.CreateSnapshot(&instance.CreateSnapshotRequest{
+		Zone:     zone,
+		Name:     expandOrGenerateString(d.Get("name"), "snp"),
+		VolumeID: expandID(d.Get("volume_id")),
+		Project:  expandStringPtr(d.Get("project_id")),
+	}, scw.WithContext(ctx))
+	if err != nil {
+		return diagFromErr(fmt.Errorf("couldn't create snapshot: %w", err))
+	}
+
+	d.SetId(newZonedIDString(zone, res.Snapshot.ID))
+
+	snapshot, err := waitForInstanceSnapshot(ctx, instanceAPI, zone, res.Snapshot.ID, d.Timeout(schema.TimeoutCreate))
+	if err != nil {
+		return diagFromErr(err)
+	}
+	if snapshot.State == instance.SnapshotStateError {
+		return diagFromErr(fmt.Errorf("snapshot %s is in error state", snapshot.ID))
+	}
+
+	return resourceScalewayInstanceSnapshotRead(ctx, d, meta)
+}
+
+func resourceScalewayInstanceSnapshotRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	instanceAPI, zone, id, err := instanceAPIWithZoneAndID(meta, d.Id())
+	if err != nil {
+		return diagFromErr(err)
+	}
+
+	res, err := instanceAPI.GetSnapshot(&instance.GetSnapshotRequest{
+		SnapshotID: id,
+		Zone:       zone,
+	}, scw.WithContext(ctx))
+	if err != nil {
+		if is404Error(err) {
+			d.SetId("")
+			return nil
+		}
+		return diagFromErr(fmt.Errorf("couldn't read snapshot: %w", err))
+	}
+
+	_ = d.Set("name", res.Snapshot.Name)
+	_ = d.Set("organization_id", res.Snapshot.Organization)
+	_ = d.Set("project_id", res.Snapshot.Project)
+	_ = d.Set("zone", string(zone))
+	_ = d.Set("type", res.Snapshot.VolumeType.String())
+	_ = d.Set("size_in_gb", int(res.Snapshot.Size/scw.GB))
+	_ = d.Set("state", res.Snapshot.State.String())
+	_ = d.Set("created_at", flattenTime(res.Snapshot.CreationDate))
+
+	// The base volume is not returned anymore once it is deleted, keep the volume the snapshot was taken from.
+	if res.Snapshot.BaseVolume != nil {
+		_ = d.Set("volume_id", newZonedIDString(zone, res.Snapshot.BaseVolume.ID))
+	}
+
+	return nil
+}
+
+func resourceScalewayInstanceSnapshotDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	instanceAPI, zone, id, err := instanceAPIWithZoneAndID(meta, d.Id())
+	if err != nil {
+		return diagFromErr(err)
+	}
+
+	// A snapshot cannot be deleted while it is being taken.
+	_, err = waitForInstanceSnapshot(ctx, instanceAPI, zone, id, d.Timeout(schema.TimeoutDelete))
+	if err != nil {
+		if is404Error(err) {
+			return nil
+		}
+		return diagFromErr(err)
+	}
+
+	err = instanceAPI.DeleteSnapshot(&instance.DeleteSnapshotRequest{
+		SnapshotID: id,
+		Zone:       zone,
+	}, scw.WithContext(ctx))
+	if err != nil && !is404Error(err) {
+		return diagFromErr(err)
+	}
+
+	return nil
+}
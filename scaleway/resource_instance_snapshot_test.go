@@ -0,0 +1,221 @@
+package scaleway
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/scaleway/scaleway-sdk-go/api/instance/v1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func init() {
+	resource.AddTestSweepers("scaleway_instance_snapshot", &resource.Sweeper{
+		Name:         "scaleway_instance_snapshot",
+		F:            testSweepInstanceSnapshot,
+		Dependencies: []string{"scaleway_instance_image"},
+	})
+}
+
+func testSweepInstanceSnapshot(_ string) error {
+	return sweepAllZones(func(scwClient *scw.Client, zone scw.Zone) error {
+		instanceAPI := instance.NewAPI(scwClient)
+		l.Debugf("sweeper: destroying the snapshots in (%s)", zone)
+
+		listSnapshotsResponse, err := instanceAPI.ListSnapshots(&instance.ListSnapshotsRequest{
+			Zone: zone,
+		}, scw.WithAllPages())
+		if err != nil {
+			return fmt.Errorf("error listing snapshots in sweeper: %s", err)
+		}
+
+		for _, snapshot := range listSnapshotsResponse.Snapshots {
+			err := sweep(&sweptResource{kind: "instance snapshot", id: snapshot.ID, name: snapshot.Name, createdAt: snapshot.CreationDate}, func() error {
+				return instanceAPI.DeleteSnapshot(&instance.DeleteSnapshotRequest{
+					Zone:       zone,
+					SnapshotID: snapshot.ID,
+				})
+			})
+			if err != nil {
+				return fmt.Errorf("error deleting snapshot in sweeper: %s", err)
+			}
+		}
+		return nil
+	})
+}
+
+func TestAccScalewayInstanceSnapshot_Basic(t *testing.T) {
+	tt := NewTestTools(t)
+	defer tt.Cleanup()
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: tt.ProviderFactories,
+		CheckDestroy:      testAccCheckScalewayInstanceSnapshotDestroy(tt),
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					resource "scaleway_instance_volume" "main" {
+						type       = "b_ssd"
+						size_in_gb = 20
+					}
+
+					resource "scaleway_instance_snapshot" "main" {
+						volume_id = scaleway_instance_volume.main.id
+					}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckScalewayInstanceSnapshotExists(tt, "scaleway_instance_snapshot.main"),
+					resource.TestCheckResourceAttrPair("scaleway_instance_snapshot.main", "volume_id", "scaleway_instance_volume.main", "id"),
+					resource.TestCheckResourceAttr("scaleway_instance_snapshot.main", "type", "b_ssd"),
+					resource.TestCheckResourceAttr("scaleway_instance_snapshot.main", "size_in_gb", "20"),
+					resource.TestCheckResourceAttr("scaleway_instance_snapshot.main", "state", "available"),
+				),
+			},
+			{
+				Config: `
+					resource "scaleway_instance_volume" "main" {
+						type       = "b_ssd"
+						size_in_gb = 20
+					}
+
+					resource "scaleway_instance_snapshot" "main" {
+						name      = "terraform-test"
+						volume_id = scaleway_instance_volume.main.id
+					}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckScalewayInstanceSnapshotExists(tt, "scaleway_instance_snapshot.main"),
+					resource.TestCheckResourceAttr("scaleway_instance_snapshot.main", "name", "terraform-test"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckScalewayInstanceSnapshotExists(tt *TestTools, n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("not found: %s", n)
+		}
+
+		zone, id, err := parseZonedID(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		instanceAPI := instance.NewAPI(tt.Meta.scwClient)
+		_, err = instanceAPI.GetSnapshot(&instance.GetSnapshotRequest{
+			SnapshotID: id,
+			Zone:       zone,
+		})
+		return err
+	}
+}
+
+func testAccCheckScalewayInstanceSnapshotDestroy(tt *TestTools) resource.TestCheckFunc {
+	return func(state *terraform.State) error {
+		instanceAPI := instance.NewAPI(tt.Meta.scwClient)
+		for _, rs := range state.RootModule().Resources {
+			if rs.Type != "scaleway_instance_snapshot" {
+				continue
+			}
+
+			zone, id, err := parseZonedID(rs.Primary.ID)
+			if err != nil {
+				return err
+			}
+
+			_, err = instanceAPI.GetSnapshot(&instance.GetSnapshotRequest{
+				SnapshotID: id,
+				Zone:       zone,
+			})
+
+			// If no error resource still exist
+			if err == nil {
+				return fmt.Errorf("snapshot (%s) still exists", rs.Primary.ID)
+			}
+
+			// Unexpected api error we return it
+			if !is404Error(err) {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+func TestScalewayInstanceSnapshot_FakeAPI(t *testing.T) {
+	f := newFakeAPI(t)
+	defer f.close()
+	meta := f.meta()
+	ctx := context.Background()
+
+	volume := f.seed("instance", "fr-par-1", "volumes", map[string]interface{}{
+		"name":        "data",
+		"volume_type": "b_ssd",
+		"size":        20000000000,
+		"state":       "available",
+	})
+
+	d := f.resourceData(resourceScalewayInstanceSnapshot(), "", map[string]interface{}{
+		"name":      "pre-upgrade",
+		"volume_id": newZonedIDString("fr-par-1", volume["id"].(string)),
+	})
+	require.False(t, resourceScalewayInstanceSnapshotCreate(ctx, d, meta).HasError())
+	assert.Equal(t, "pre-upgrade", d.Get("name"))
+	assert.Equal(t, "b_ssd", d.Get("type"))
+	assert.Equal(t, 20, d.Get("size_in_gb"))
+	assert.Equal(t, "available", d.Get("state"))
+	assert.Equal(t, "fr-par-1", d.Get("zone"))
+	assert.Equal(t, fakeAPIProjectID, d.Get("project_id"))
+	assert.Equal(t, newZonedIDString("fr-par-1", volume["id"].(string)), d.Get("volume_id"))
+
+	// A volume ID without zone does not replace the snapshot once read with its zone.
+	diff, err := f.planUpdate(meta, resourceScalewayInstanceSnapshot(), d, map[string]interface{}{
+		"name":      "pre-upgrade",
+		"volume_id": volume["id"],
+	})
+	require.NoError(t, err)
+	assert.True(t, diff.Empty())
+
+	zonedID := expandZonedID(d.Id())
+	assert.NotNil(t, f.lookup("instance", "fr-par-1", "snapshots", zonedID.ID))
+
+	// Snapshots can be imported by name.
+	imported, err := resourceScalewayInstanceSnapshot().Importer.StateContext(ctx, f.resourceData(resourceScalewayInstanceSnapshot(), "fr-par-1/pre-upgrade", nil), meta)
+	require.NoError(t, err)
+	require.Len(t, imported, 1)
+	assert.Equal(t, d.Id(), imported[0].Id())
+
+	require.False(t, resourceScalewayInstanceSnapshotDelete(ctx, d, meta).HasError())
+	assert.Nil(t, f.lookup("instance", "fr-par-1", "snapshots", zonedID.ID))
+
+	// A snapshot deleted outside of terraform is removed from the state.
+	require.False(t, resourceScalewayInstanceSnapshotRead(ctx, d, meta).HasError())
+	assert.Equal(t, "", d.Id())
+}
+
+func TestScalewayInstanceSnapshot_FakeAPIError(t *testing.T) {
+	f := newFakeAPI(t)
+	defer f.close()
+	meta := f.meta()
+	ctx := context.Background()
+
+	snapshot := f.seed("instance", "fr-par-1", "snapshots", map[string]interface{}{"name": "broken"})
+	f.update("instance", "fr-par-1", "snapshots", snapshot["id"].(string), map[string]interface{}{"state": "error"})
+	f.respond("POST", "/instance/v1/zones/fr-par-1/snapshots", 201, map[string]interface{}{"snapshot": snapshot}, true)
+
+	d := f.resourceData(resourceScalewayInstanceSnapshot(), "", map[string]interface{}{
+		"volume_id": "fr-par-1/11111111-1111-1111-1111-111111111111",
+	})
+	diags := resourceScalewayInstanceSnapshotCreate(ctx, d, meta)
+	require.True(t, diags.HasError())
+	assert.Contains(t, diags[0].Summary, "is in error state")
+	// The snapshot is kept in the state so that it is deleted on the next apply.
+	assert.Equal(t, newZonedIDString("fr-par-1", snapshot["id"].(string)), d.Id())
+}
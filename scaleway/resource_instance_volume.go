@@ -13,17 +13,18 @@ import (
 )
 
 func resourceScalewayInstanceVolume() *schema.Resource {
-	return &schema.Resource{
+	return withLocalizedIDsStateUpgrade(&schema.Resource{
 		CreateContext: resourceScalewayInstanceVolumeCreate,
 		ReadContext:   resourceScalewayInstanceVolumeRead,
 		UpdateContext: resourceScalewayInstanceVolumeUpdate,
 		DeleteContext: resourceScalewayInstanceVolumeDelete,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importStateByName("instance volume", instanceVolumeIDsByName),
 		},
 		Timeouts: &schema.ResourceTimeout{
 			Default: schema.DefaultTimeout(defaultInstanceVolumeDeleteTimeout),
 		},
+		CustomizeDiff: customizeDiffLocalityCheck("zone", "from_volume_id", "from_snapshot_id"),
 		Schema: map[string]*schema.Schema{
 			"name": {
 				Type:        schema.TypeString,
@@ -72,13 +73,15 @@ func resourceScalewayInstanceVolume() *schema.Resource {
 			"project_id":      projectIDSchema(),
 			"zone":            zoneSchema(),
 		},
-	}
+	}, map[string]string{
+		"id": "zone",
+	})
 }
 
 func resourceScalewayInstanceVolumeCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	instanceAPI, zone, err := instanceAPIWithZone(d, meta)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	createVolumeRequest := &instance.CreateVolumeRequest{
@@ -103,7 +106,7 @@ func resourceScalewayInstanceVolumeCreate(ctx context.Context, d *schema.Resourc
 
 	res, err := instanceAPI.CreateVolume(createVolumeRequest, scw.WithContext(ctx))
 	if err != nil {
-		return diag.FromErr(fmt.Errorf("couldn't create volume: %s", err))
+		return diagFromErr(fmt.Errorf("couldn't create volume: %w", err))
 	}
 
 	d.SetId(newZonedIDString(zone, res.Volume.ID))
@@ -114,7 +117,7 @@ func resourceScalewayInstanceVolumeCreate(ctx context.Context, d *schema.Resourc
 func resourceScalewayInstanceVolumeRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	instanceAPI, zone, id, err := instanceAPIWithZoneAndID(meta, d.Id())
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	res, err := instanceAPI.GetVolume(&instance.GetVolumeRequest{
@@ -126,7 +129,7 @@ func resourceScalewayInstanceVolumeRead(ctx context.Context, d *schema.ResourceD
 			d.SetId("")
 			return nil
 		}
-		return diag.FromErr(fmt.Errorf("couldn't read volume: %v", err))
+		return diagFromErr(fmt.Errorf("couldn't read volume: %w", err))
 	}
 
 	_ = d.Set("name", res.Volume.Name)
@@ -148,7 +151,7 @@ func resourceScalewayInstanceVolumeRead(ctx context.Context, d *schema.ResourceD
 func resourceScalewayInstanceVolumeUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	instanceAPI, zone, id, err := instanceAPIWithZoneAndID(meta, d.Id())
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	if d.HasChange("name") {
@@ -160,32 +163,20 @@ func resourceScalewayInstanceVolumeUpdate(ctx context.Context, d *schema.Resourc
 			Name:     &newName,
 		}, scw.WithContext(ctx))
 		if err != nil {
-			return diag.FromErr(fmt.Errorf("couldn't update volume: %s", err))
+			return diagFromErr(fmt.Errorf("couldn't update volume: %w", err))
 		}
 	}
 
 	if d.HasChange("size_in_gb") {
 		if d.Get("type") != instance.VolumeVolumeTypeBSSD.String() {
-			return diag.FromErr(fmt.Errorf("only block volume can be resized"))
+			return diagFromErr(fmt.Errorf("only block volume can be resized"))
 		}
 		if oldSize, newSize := d.GetChange("size_in_gb"); oldSize.(int) > newSize.(int) {
-			return diag.FromErr(fmt.Errorf("block volumes cannot be resized down"))
-		}
-		_, err := instanceAPI.WaitForVolume(&instance.WaitForVolumeRequest{
-			VolumeID: id,
-			Zone:     zone}, scw.WithContext(ctx))
-		if err != nil {
-			return diag.FromErr(err)
+			return diagFromErr(fmt.Errorf("block volumes cannot be resized down"))
 		}
-
-		volumeSizeInBytes := scw.Size(uint64(d.Get("size_in_gb").(int)) * gb)
-		_, err = instanceAPI.UpdateVolume(&instance.UpdateVolumeRequest{
-			VolumeID: id,
-			Zone:     zone,
-			Size:     &volumeSizeInBytes,
-		}, scw.WithContext(ctx))
+		err = resizeInstanceVolume(ctx, instanceAPI, zone, id, d.Get("size_in_gb").(int), d.Timeout(schema.TimeoutUpdate))
 		if err != nil {
-			return diag.FromErr(fmt.Errorf("couldn't resize volume: %s", err))
+			return diagFromErr(err)
 		}
 	}
 
@@ -195,7 +186,7 @@ func resourceScalewayInstanceVolumeUpdate(ctx context.Context, d *schema.Resourc
 func resourceScalewayInstanceVolumeDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	instanceAPI, zone, id, err := instanceAPIWithZoneAndID(meta, d.Id())
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	err = resource.Retry(d.Timeout(schema.TimeoutDelete), func() *resource.RetryError {
@@ -226,7 +217,7 @@ func resourceScalewayInstanceVolumeDelete(ctx context.Context, d *schema.Resourc
 		return nil
 	})
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 	return nil
 }
@@ -13,13 +13,14 @@ import (
 
 func init() {
 	resource.AddTestSweepers("scaleway_instance_volume", &resource.Sweeper{
-		Name: "scaleway_instance_volume",
-		F:    testSweepComputeInstanceVolume,
+		Name:         "scaleway_instance_volume",
+		F:            testSweepComputeInstanceVolume,
+		Dependencies: []string{"scaleway_instance_server"},
 	})
 }
 
 func testSweepComputeInstanceVolume(_ string) error {
-	return sweepZones(scw.AllZones, func(scwClient *scw.Client, zone scw.Zone) error {
+	return sweepAllZones(func(scwClient *scw.Client, zone scw.Zone) error {
 		instanceAPI := instance.NewAPI(scwClient)
 		l.Debugf("sweeper: destroying the volumes in (%s)", zone)
 
@@ -31,14 +32,17 @@ func testSweepComputeInstanceVolume(_ string) error {
 		}
 
 		for _, volume := range listVolumesResponse.Volumes {
-			if volume.Server == nil {
-				err := instanceAPI.DeleteVolume(&instance.DeleteVolumeRequest{
+			if volume.Server != nil {
+				continue
+			}
+			err := sweep(&sweptResource{kind: "instance volume", id: volume.ID, name: volume.Name, createdAt: volume.CreationDate}, func() error {
+				return instanceAPI.DeleteVolume(&instance.DeleteVolumeRequest{
 					Zone:     zone,
 					VolumeID: volume.ID,
 				})
-				if err != nil {
-					return fmt.Errorf("error deleting volume in sweeper: %s", err)
-				}
+			})
+			if err != nil {
+				return fmt.Errorf("error deleting volume in sweeper: %s", err)
 			}
 		}
 		return nil
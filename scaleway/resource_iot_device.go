@@ -12,7 +12,7 @@ import (
 )
 
 func resourceScalewayIotDevice() *schema.Resource {
-	return &schema.Resource{
+	return withLocalizedIDsStateUpgrade(&schema.Resource{
 		CreateContext: resourceScalewayIotDeviceCreate,
 		ReadContext:   resourceScalewayIotDeviceRead,
 		UpdateContext: resourceScalewayIotDeviceUpdate,
@@ -20,6 +20,10 @@ func resourceScalewayIotDevice() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
+		Timeouts: &schema.ResourceTimeout{
+			Default: schema.DefaultTimeout(defaultIotDeviceTimeout),
+		},
+		CustomizeDiff: customizeDiffLocalityCheck("region", "hub_id"),
 		SchemaVersion: 0,
 		Schema: map[string]*schema.Schema{
 			"hub_id": {
@@ -169,13 +173,16 @@ func resourceScalewayIotDevice() *schema.Resource {
 				Description: "The MQTT connection status of the device",
 			},
 		},
-	}
+	}, map[string]string{
+		"id":     "region",
+		"hub_id": "region",
+	})
 }
 
 func resourceScalewayIotDeviceCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	iotAPI, region, err := iotAPIWithRegion(d, meta)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	////
@@ -237,7 +244,7 @@ func resourceScalewayIotDeviceCreate(ctx context.Context, d *schema.ResourceData
 
 	res, err := iotAPI.CreateDevice(req, scw.WithContext(ctx))
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	d.SetId(newRegionalIDString(region, res.Device.ID))
@@ -255,7 +262,7 @@ func resourceScalewayIotDeviceCreate(ctx context.Context, d *schema.ResourceData
 func resourceScalewayIotDeviceRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	iotAPI, region, deviceID, err := iotAPIWithRegionAndID(meta, d.Id())
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	////
@@ -270,7 +277,7 @@ func resourceScalewayIotDeviceRead(ctx context.Context, d *schema.ResourceData,
 			d.SetId("")
 			return nil
 		}
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	_ = d.Set("name", device.Name)
@@ -325,7 +332,7 @@ func resourceScalewayIotDeviceRead(ctx context.Context, d *schema.ResourceData,
 func resourceScalewayIotDeviceUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	iotAPI, region, hubID, err := iotAPIWithRegionAndID(meta, d.Id())
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	////
@@ -384,7 +391,7 @@ func resourceScalewayIotDeviceUpdate(ctx context.Context, d *schema.ResourceData
 
 	_, err = iotAPI.UpdateDevice(updateRequest, scw.WithContext(ctx))
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	return resourceScalewayIotDeviceRead(ctx, d, meta)
@@ -393,7 +400,7 @@ func resourceScalewayIotDeviceUpdate(ctx context.Context, d *schema.ResourceData
 func resourceScalewayIotDeviceDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	iotAPI, region, deviceID, err := iotAPIWithRegionAndID(meta, d.Id())
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	////
@@ -405,7 +412,7 @@ func resourceScalewayIotDeviceDelete(ctx context.Context, d *schema.ResourceData
 	}, scw.WithContext(ctx))
 	if err != nil {
 		if !is404Error(err) {
-			return diag.FromErr(err)
+			return diagFromErr(err)
 		}
 	}
 
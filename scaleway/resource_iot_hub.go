@@ -11,7 +11,7 @@ import (
 )
 
 func resourceScalewayIotHub() *schema.Resource {
-	return &schema.Resource{
+	return withLocalizedIDsStateUpgrade(&schema.Resource{
 		CreateContext: resourceScalewayIotHubCreate,
 		ReadContext:   resourceScalewayIotHubRead,
 		UpdateContext: resourceScalewayIotHubUpdate,
@@ -19,6 +19,9 @@ func resourceScalewayIotHub() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
+		Timeouts: &schema.ResourceTimeout{
+			Default: schema.DefaultTimeout(defaultIotHubTimeout),
+		},
 		SchemaVersion: 0,
 		Schema: map[string]*schema.Schema{
 			"enabled": {
@@ -106,13 +109,15 @@ func resourceScalewayIotHub() *schema.Resource {
 				Description: "The current number of connected devices in the Hub",
 			},
 		},
-	}
+	}, map[string]string{
+		"id": "region",
+	})
 }
 
 func resourceScalewayIotHubCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	iotAPI, region, err := iotAPIWithRegion(d, meta)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	////
@@ -139,12 +144,12 @@ func resourceScalewayIotHubCreate(ctx context.Context, d *schema.ResourceData, m
 
 	res, err := iotAPI.CreateHub(req, scw.WithContext(ctx))
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
-	err = waitIotHub(iotAPI, region, res.ID, iot.HubStatusReady)
+	err = waitIotHub(ctx, iotAPI, region, res.ID, d.Timeout(schema.TimeoutCreate), iot.HubStatusReady)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	// Set user CA if needed. It cannot currently be added in the create hub request.
@@ -156,7 +161,7 @@ func resourceScalewayIotHubCreate(ctx context.Context, d *schema.ResourceData, m
 			ChallengeCertPem: d.Get("hub_ca_challenge").(string),
 		}, scw.WithContext(ctx))
 		if err != nil {
-			return diag.FromErr(err)
+			return diagFromErr(err)
 		}
 	}
 
@@ -166,7 +171,7 @@ func resourceScalewayIotHubCreate(ctx context.Context, d *schema.ResourceData, m
 			EnableDeviceAutoProvisioning: scw.BoolPtr(devProv.(bool)),
 		}, scw.WithContext(ctx))
 		if err != nil {
-			return diag.FromErr(err)
+			return diagFromErr(err)
 		}
 	}
 
@@ -180,12 +185,12 @@ func resourceScalewayIotHubCreate(ctx context.Context, d *schema.ResourceData, m
 			HubID:  res.ID,
 		}, scw.WithContext(ctx))
 		if err != nil {
-			return diag.FromErr(err)
+			return diagFromErr(err)
 		}
 
-		err = waitIotHub(iotAPI, region, res.ID, iot.HubStatusDisabled)
+		err = waitIotHub(ctx, iotAPI, region, res.ID, d.Timeout(schema.TimeoutCreate), iot.HubStatusDisabled)
 		if err != nil {
-			return diag.FromErr(err)
+			return diagFromErr(err)
 		}
 	}
 
@@ -197,7 +202,7 @@ func resourceScalewayIotHubCreate(ctx context.Context, d *schema.ResourceData, m
 func resourceScalewayIotHubRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	iotAPI, region, hubID, err := iotAPIWithRegionAndID(meta, d.Id())
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	////
@@ -212,7 +217,7 @@ func resourceScalewayIotHubRead(ctx context.Context, d *schema.ResourceData, met
 			d.SetId("")
 			return nil
 		}
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	_ = d.Set("region", string(region))
@@ -237,7 +242,7 @@ func resourceScalewayIotHubRead(ctx context.Context, d *schema.ResourceData, met
 func resourceScalewayIotHubUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	iotAPI, region, hubID, err := iotAPIWithRegionAndID(meta, d.Id())
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	////
@@ -259,12 +264,12 @@ func resourceScalewayIotHubUpdate(ctx context.Context, d *schema.ResourceData, m
 			}, scw.WithContext(ctx))
 		}
 		if err != nil {
-			return diag.FromErr(err)
+			return diagFromErr(err)
 		}
 
-		err = waitIotHub(iotAPI, region, hubID, iot.HubStatusReady, iot.HubStatusDisabled)
+		err = waitIotHub(ctx, iotAPI, region, hubID, d.Timeout(schema.TimeoutUpdate), iot.HubStatusReady, iot.HubStatusDisabled)
 		if err != nil {
-			return diag.FromErr(err)
+			return diagFromErr(err)
 		}
 	}
 
@@ -279,7 +284,7 @@ func resourceScalewayIotHubUpdate(ctx context.Context, d *schema.ResourceData, m
 			ChallengeCertPem: d.Get("hub_ca_challenge").(string),
 		}, scw.WithContext(ctx))
 		if err != nil {
-			return diag.FromErr(err)
+			return diagFromErr(err)
 		}
 	}
 
@@ -316,7 +321,7 @@ func resourceScalewayIotHubUpdate(ctx context.Context, d *schema.ResourceData, m
 	////
 	_, err = iotAPI.UpdateHub(updateRequest, scw.WithContext(ctx))
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	return resourceScalewayIotHubRead(ctx, d, meta)
@@ -325,7 +330,7 @@ func resourceScalewayIotHubUpdate(ctx context.Context, d *schema.ResourceData, m
 func resourceScalewayIotHubDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	iotAPI, region, hubID, err := iotAPIWithRegionAndID(meta, d.Id())
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	////
@@ -340,7 +345,7 @@ func resourceScalewayIotHubDelete(ctx context.Context, d *schema.ResourceData, m
 		if is404Error(err) {
 			return nil
 		}
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	return nil
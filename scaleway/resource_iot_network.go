@@ -11,13 +11,16 @@ import (
 )
 
 func resourceScalewayIotNetwork() *schema.Resource {
-	return &schema.Resource{
+	return withLocalizedIDsStateUpgrade(&schema.Resource{
 		CreateContext: resourceScalewayIotNetworkCreate,
 		ReadContext:   resourceScalewayIotNetworkRead,
 		DeleteContext: resourceScalewayIotNetworkDelete,
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
+		Timeouts: &schema.ResourceTimeout{
+			Default: schema.DefaultTimeout(defaultIotNetworkTimeout),
+		},
 		SchemaVersion: 0,
 		Schema: map[string]*schema.Schema{
 			"hub_id": {
@@ -67,13 +70,16 @@ func resourceScalewayIotNetwork() *schema.Resource {
 				Sensitive:   true,
 			},
 		},
-	}
+	}, map[string]string{
+		"id":     "region",
+		"hub_id": "region",
+	})
 }
 
 func resourceScalewayIotNetworkCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	iotAPI, region, err := iotAPIWithRegion(d, meta)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	////
@@ -93,7 +99,7 @@ func resourceScalewayIotNetworkCreate(ctx context.Context, d *schema.ResourceDat
 
 	res, err := iotAPI.CreateNetwork(req, scw.WithContext(ctx))
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	d.SetId(newRegionalIDString(region, res.Network.ID))
@@ -107,7 +113,7 @@ func resourceScalewayIotNetworkCreate(ctx context.Context, d *schema.ResourceDat
 func resourceScalewayIotNetworkRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	iotAPI, region, networkID, err := iotAPIWithRegionAndID(meta, d.Id())
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	////
@@ -122,7 +128,7 @@ func resourceScalewayIotNetworkRead(ctx context.Context, d *schema.ResourceData,
 			d.SetId("")
 			return nil
 		}
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	_ = d.Set("name", network.Name)
@@ -138,7 +144,7 @@ func resourceScalewayIotNetworkRead(ctx context.Context, d *schema.ResourceData,
 func resourceScalewayIotNetworkDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	iotAPI, region, networkID, err := iotAPIWithRegionAndID(meta, d.Id())
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	////
@@ -150,7 +156,7 @@ func resourceScalewayIotNetworkDelete(ctx context.Context, d *schema.ResourceDat
 	}, scw.WithContext(ctx))
 	if err != nil {
 		if !is404Error(err) {
-			return diag.FromErr(err)
+			return diagFromErr(err)
 		}
 	}
 
@@ -12,13 +12,16 @@ import (
 )
 
 func resourceScalewayIotRoute() *schema.Resource {
-	return &schema.Resource{
+	return withLocalizedIDsStateUpgrade(&schema.Resource{
 		CreateContext: resourceScalewayIotRouteCreate,
 		ReadContext:   resourceScalewayIotRouteRead,
 		DeleteContext: resourceScalewayIotRouteDelete,
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
+		Timeouts: &schema.ResourceTimeout{
+			Default: schema.DefaultTimeout(defaultIotRouteTimeout),
+		},
 		SchemaVersion: 0,
 		Schema: map[string]*schema.Schema{
 			"name": {
@@ -183,13 +186,16 @@ func resourceScalewayIotRoute() *schema.Resource {
 				Description: "The date and time of the creation of the IoT Route",
 			},
 		},
-	}
+	}, map[string]string{
+		"id":     "region",
+		"hub_id": "region",
+	})
 }
 
 func resourceScalewayIotRouteCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	iotAPI, region, err := iotAPIWithRegion(d, meta)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	////
@@ -229,12 +235,12 @@ func resourceScalewayIotRouteCreate(ctx context.Context, d *schema.ResourceData,
 			Query:    d.Get(fmt.Sprintf("%s.query", prefixKey)).(string),
 		}
 	} else {
-		return diag.FromErr(fmt.Errorf("no route type have been chosen"))
+		return diagFromErr(fmt.Errorf("no route type have been chosen"))
 	}
 
 	res, err := iotAPI.CreateRoute(req, scw.WithContext(ctx))
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	d.SetId(newRegionalIDString(region, res.ID))
@@ -245,7 +251,7 @@ func resourceScalewayIotRouteCreate(ctx context.Context, d *schema.ResourceData,
 func resourceScalewayIotRouteRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	iotAPI, region, routeID, err := iotAPIWithRegionAndID(meta, d.Id())
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	////
@@ -260,12 +266,12 @@ func resourceScalewayIotRouteRead(ctx context.Context, d *schema.ResourceData, m
 			d.SetId("")
 			return nil
 		}
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	_ = d.Set("region", string(region))
 	_ = d.Set("name", response.Name)
-	_ = d.Set("hub_id", response.HubID)
+	_ = d.Set("hub_id", newRegionalID(region, response.HubID).String())
 	_ = d.Set("topic", response.Topic)
 	_ = d.Set("created_at", response.CreatedAt.String())
 
@@ -304,7 +310,7 @@ func resourceScalewayIotRouteRead(ctx context.Context, d *schema.ResourceData, m
 func resourceScalewayIotRouteDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	iotAPI, region, routeID, err := iotAPIWithRegionAndID(meta, d.Id())
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	////
@@ -318,7 +324,7 @@ func resourceScalewayIotRouteDelete(ctx context.Context, d *schema.ResourceData,
 		if is404Error(err) {
 			return nil
 		}
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	return nil
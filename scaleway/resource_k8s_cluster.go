@@ -14,17 +14,18 @@ import (
 )
 
 func resourceScalewayK8SCluster() *schema.Resource {
-	return &schema.Resource{
+	return withLocalizedIDsStateUpgrade(&schema.Resource{
 		CreateContext: resourceScalewayK8SClusterCreate,
 		ReadContext:   resourceScalewayK8SClusterRead,
 		UpdateContext: resourceScalewayK8SClusterUpdate,
 		DeleteContext: resourceScalewayK8SClusterDelete,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importStateByName("k8s cluster", k8sClusterIDsByName),
 		},
 		Timeouts: &schema.ResourceTimeout{
 			Default: schema.DefaultTimeout(defaultK8SClusterTimeout),
 		},
+		CustomizeDiff: customizeDiffTagsAll,
 		SchemaVersion: 0,
 		Schema: map[string]*schema.Schema{
 			"name": {
@@ -63,6 +64,7 @@ func resourceScalewayK8SCluster() *schema.Resource {
 				Optional:    true,
 				Description: "The tags associated with the cluster",
 			},
+			"tags_all": tagsAllSchema(),
 			"autoscaler_config": {
 				Type:        schema.TypeList,
 				MaxItems:    1,
@@ -210,13 +212,15 @@ func resourceScalewayK8SCluster() *schema.Resource {
 				Description: "The status of the cluster",
 			},
 		},
-	}
+	}, map[string]string{
+		"id": "region",
+	})
 }
 
 func resourceScalewayK8SClusterCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	k8sAPI, region, err := k8sAPIWithRegion(d, meta)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	////
@@ -234,7 +238,7 @@ func resourceScalewayK8SClusterCreate(ctx context.Context, d *schema.ResourceDat
 		Name:              expandOrGenerateString(d.Get("name"), "cluster"),
 		Description:       description.(string),
 		Cni:               k8s.CNI(d.Get("cni").(string)),
-		Tags:              expandStrings(d.Get("tags")),
+		Tags:              expandTags(meta, d.Get("tags")),
 		FeatureGates:      expandStrings(d.Get("feature_gates")),
 		AdmissionPlugins:  expandStrings(d.Get("admission_plugins")),
 		ApiserverCertSans: expandStrings(d.Get("apiserver_cert_sans")),
@@ -327,7 +331,7 @@ func resourceScalewayK8SClusterCreate(ctx context.Context, d *schema.ResourceDat
 		// if one auto upgrade attribute is set, they all must be set.
 		// if none is set, auto upgrade attributes will be computed.
 		if !(okAutoUpgradeDay && okAutoUpgradeStartHour) {
-			return diag.FromErr(fmt.Errorf("all field or zero field of auto_upgrade must be set"))
+			return diagFromErr(fmt.Errorf("all field or zero field of auto_upgrade must be set"))
 		}
 	}
 
@@ -348,13 +352,13 @@ func resourceScalewayK8SClusterCreate(ctx context.Context, d *schema.ResourceDat
 	versionIsOnlyMinor := len(strings.Split(version, ".")) == 2
 
 	if versionIsOnlyMinor != clusterAutoUpgradeEnabled {
-		return diag.FromErr(fmt.Errorf("minor version x.y must be used with auto upgrade enabled"))
+		return diagFromErr(fmt.Errorf("minor version x.y must be used with auto upgrade enabled"))
 	}
 
 	if versionIsOnlyMinor {
-		version, err = k8sGetLatestVersionFromMinor(ctx, k8sAPI, region, version)
+		version, err = k8sGetLatestVersionFromMinor(ctx, meta, region, version)
 		if err != nil {
-			return diag.FromErr(fmt.Errorf("minor version x.y must be used with auto upgrade enabled"))
+			return diagFromErr(fmt.Errorf("minor version x.y must be used with auto upgrade enabled"))
 		}
 	}
 
@@ -362,12 +366,12 @@ func resourceScalewayK8SClusterCreate(ctx context.Context, d *schema.ResourceDat
 
 	res, err := k8sAPI.CreateCluster(req, scw.WithContext(ctx))
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
-	err = waitK8SCluster(ctx, k8sAPI, region, res.ID, k8s.ClusterStatusPoolRequired)
+	err = waitK8SCluster(ctx, k8sAPI, region, res.ID, d.Timeout(schema.TimeoutCreate), k8s.ClusterStatusPoolRequired)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	d.SetId(newRegionalIDString(region, res.ID))
@@ -378,7 +382,7 @@ func resourceScalewayK8SClusterCreate(ctx context.Context, d *schema.ResourceDat
 func resourceScalewayK8SClusterRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	k8sAPI, region, clusterID, err := k8sAPIWithRegionAndID(meta, d.Id())
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	////
@@ -393,7 +397,7 @@ func resourceScalewayK8SClusterRead(ctx context.Context, d *schema.ResourceData,
 			d.SetId("")
 			return nil
 		}
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	_ = d.Set("region", string(region))
@@ -402,7 +406,8 @@ func resourceScalewayK8SClusterRead(ctx context.Context, d *schema.ResourceData,
 	_ = d.Set("project_id", response.ProjectID)
 	_ = d.Set("description", response.Description)
 	_ = d.Set("cni", response.Cni)
-	_ = d.Set("tags", response.Tags)
+	_ = d.Set("tags", flattenTags(meta, response.Tags, d.Get("tags")))
+	_ = d.Set("tags_all", response.Tags)
 	_ = d.Set("apiserver_cert_sans", response.ApiserverCertSans)
 	_ = d.Set("created_at", response.CreatedAt.Format(time.RFC3339))
 	_ = d.Set("updated_at", response.UpdatedAt.Format(time.RFC3339))
@@ -418,7 +423,7 @@ func resourceScalewayK8SClusterRead(ctx context.Context, d *schema.ResourceData,
 	if response.AutoUpgrade != nil && response.AutoUpgrade.Enabled {
 		version, err = k8sGetMinorVersionFromFull(version)
 		if err != nil {
-			return diag.FromErr(err)
+			return diagFromErr(err)
 		}
 	}
 	_ = d.Set("version", version)
@@ -436,22 +441,22 @@ func resourceScalewayK8SClusterRead(ctx context.Context, d *schema.ResourceData,
 		ClusterID: clusterID,
 	}, scw.WithContext(ctx))
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	kubeconfigServer, err := kubeconfig.GetServer()
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	kubeconfigCa, err := kubeconfig.GetCertificateAuthorityData()
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	kubeconfigToken, err := kubeconfig.GetToken()
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	kubeconf := map[string]interface{}{}
@@ -468,7 +473,7 @@ func resourceScalewayK8SClusterRead(ctx context.Context, d *schema.ResourceData,
 func resourceScalewayK8SClusterUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	k8sAPI, region, clusterID, err := k8sAPIWithRegionAndID(meta, d.Id())
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	canUpgrade := false
@@ -489,8 +494,8 @@ func resourceScalewayK8SClusterUpdate(ctx context.Context, d *schema.ResourceDat
 		updateRequest.Description = expandStringPtr(d.Get("description"))
 	}
 
-	if d.HasChange("tags") {
-		tags := expandStrings(d.Get("tags"))
+	if d.HasChanges("tags", "tags_all") {
+		tags := expandTags(meta, d.Get("tags"))
 		updateRequest.Tags = scw.StringsPtr(tags)
 	}
 
@@ -523,13 +528,13 @@ func resourceScalewayK8SClusterUpdate(ctx context.Context, d *schema.ResourceDat
 	versionIsOnlyMinor := len(strings.Split(version, ".")) == 2
 
 	if versionIsOnlyMinor != autoupgradeEnabled {
-		return diag.FromErr(fmt.Errorf("minor version x.y must be used with auto upgrades enabled"))
+		return diagFromErr(fmt.Errorf("minor version x.y must be used with auto upgrades enabled"))
 	}
 
 	if versionIsOnlyMinor {
-		version, err = k8sGetLatestVersionFromMinor(ctx, k8sAPI, region, version)
+		version, err = k8sGetLatestVersionFromMinor(ctx, meta, region, version)
 		if err != nil {
-			return diag.FromErr(err)
+			return diagFromErr(err)
 		}
 	}
 
@@ -542,7 +547,7 @@ func resourceScalewayK8SClusterUpdate(ctx context.Context, d *schema.ResourceDat
 			Region:    region,
 		}, scw.WithContext(ctx))
 		if err != nil {
-			return diag.FromErr(err)
+			return diagFromErr(err)
 		}
 
 		if clusterResp.Version == version {
@@ -635,12 +640,12 @@ func resourceScalewayK8SClusterUpdate(ctx context.Context, d *schema.ResourceDat
 	////
 	_, err = k8sAPI.UpdateCluster(updateRequest, scw.WithContext(ctx))
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
-	err = waitK8SCluster(ctx, k8sAPI, region, clusterID, k8s.ClusterStatusReady, k8s.ClusterStatusPoolRequired)
+	err = waitK8SCluster(ctx, k8sAPI, region, clusterID, d.Timeout(schema.TimeoutUpdate), k8s.ClusterStatusReady, k8s.ClusterStatusPoolRequired)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	////
@@ -655,12 +660,12 @@ func resourceScalewayK8SClusterUpdate(ctx context.Context, d *schema.ResourceDat
 		}
 		_, err = k8sAPI.UpgradeCluster(upgradeRequest)
 		if err != nil {
-			return diag.FromErr(err)
+			return diagFromErr(err)
 		}
 
-		err = waitK8SCluster(ctx, k8sAPI, region, clusterID, k8s.ClusterStatusReady, k8s.ClusterStatusPoolRequired)
+		err = waitK8SCluster(ctx, k8sAPI, region, clusterID, d.Timeout(schema.TimeoutUpdate), k8s.ClusterStatusReady, k8s.ClusterStatusPoolRequired)
 		if err != nil {
-			return diag.FromErr(err)
+			return diagFromErr(err)
 		}
 	}
 
@@ -670,7 +675,7 @@ func resourceScalewayK8SClusterUpdate(ctx context.Context, d *schema.ResourceDat
 func resourceScalewayK8SClusterDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	k8sAPI, region, clusterID, err := k8sAPIWithRegionAndID(meta, d.Id())
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	deleteAdditionalResources := d.Get("delete_additional_resources").(bool)
@@ -687,12 +692,12 @@ func resourceScalewayK8SClusterDelete(ctx context.Context, d *schema.ResourceDat
 		if is404Error(err) {
 			return nil
 		}
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
-	err = waitK8SClusterDeleted(ctx, k8sAPI, region, clusterID)
+	err = waitK8SClusterDeleted(ctx, k8sAPI, region, clusterID, d.Timeout(schema.TimeoutDelete))
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	return nil
@@ -1,6 +1,7 @@
 package scaleway
 
 import (
+	"context"
 	"fmt"
 	"testing"
 
@@ -8,6 +9,8 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	"github.com/scaleway/scaleway-sdk-go/api/k8s/v1"
 	"github.com/scaleway/scaleway-sdk-go/scw"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func init() {
@@ -75,14 +78,25 @@ func testSweepK8SCluster(_ string) error {
 		k8sAPI := k8s.NewAPI(scwClient)
 
 		l.Debugf("sweeper: destroying the k8s cluster in (%s)", region)
-		listClusters, err := k8sAPI.ListClusters(&k8s.ListClustersRequest{}, scw.WithAllPages())
+		listClusters, err := k8sAPI.ListClusters(&k8s.ListClustersRequest{
+			Region: region,
+		}, scw.WithAllPages())
 		if err != nil {
 			return fmt.Errorf("error listing clusters in (%s) in sweeper: %s", region, err)
 		}
 
 		for _, cluster := range listClusters.Clusters {
-			_, err := k8sAPI.DeleteCluster(&k8s.DeleteClusterRequest{
-				ClusterID: cluster.ID,
+			err := sweep(&sweptResource{kind: "k8s cluster", id: cluster.ID, name: cluster.Name, tags: cluster.Tags, createdAt: cluster.CreatedAt}, func() error {
+				_, err := k8sAPI.DeleteCluster(&k8s.DeleteClusterRequest{
+					Region:                  region,
+					ClusterID:               cluster.ID,
+					WithAdditionalResources: true,
+				})
+				if err != nil {
+					return err
+				}
+				// The nodes of the cluster are instance servers, the cluster must be deleted before sweeping them.
+				return waitK8SClusterDeleted(context.Background(), k8sAPI, region, cluster.ID, defaultK8SClusterTimeout)
 			})
 			if err != nil {
 				return fmt.Errorf("error deleting cluster in sweeper: %s", err)
@@ -519,3 +533,22 @@ resource "scaleway_k8s_cluster" "auto_upgrade" {
 	tags = [ "terraform-test", "scaleway_k8s_cluster", "auto_upgrade" ]
 }`, version, enable, hour, day)
 }
+
+func TestScalewayK8SCluster_FakeAPIDelete(t *testing.T) {
+	f := newFakeAPI(t)
+	defer f.close()
+	meta := f.meta()
+	ctx := context.Background()
+
+	cluster := f.seed("k8s", "fr-par", "clusters", map[string]interface{}{"name": "cluster"})
+	clusterID := cluster["id"].(string)
+	f.update("k8s", "fr-par", "clusters", clusterID, map[string]interface{}{"status": "pool_required"})
+
+	// Adding a pool to a cluster requiring one makes it ready.
+	f.seed("k8s", "fr-par", "pools", map[string]interface{}{"name": "pool", "cluster_id": clusterID})
+	assert.Equal(t, "ready", f.lookup("k8s", "fr-par", "clusters", clusterID)["status"])
+
+	d := f.resourceData(resourceScalewayK8SCluster(), newRegionalIDString("fr-par", clusterID), map[string]interface{}{})
+	require.False(t, resourceScalewayK8SClusterDelete(ctx, d, meta).HasError())
+	assert.Nil(t, f.lookup("k8s", "fr-par", "clusters", clusterID))
+}
@@ -2,9 +2,11 @@ package scaleway
 
 import (
 	"context"
+	"strings"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/scaleway/scaleway-sdk-go/api/k8s/v1"
@@ -12,17 +14,22 @@ import (
 )
 
 func resourceScalewayK8SPool() *schema.Resource {
-	return &schema.Resource{
+	return withLocalizedIDsStateUpgrade(&schema.Resource{
 		CreateContext: resourceScalewayK8SPoolCreate,
 		ReadContext:   resourceScalewayK8SPoolRead,
 		UpdateContext: resourceScalewayK8SPoolUpdate,
 		DeleteContext: resourceScalewayK8SPoolDelete,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importStateByName("k8s pool", k8sPoolIDsByName),
 		},
 		Timeouts: &schema.ResourceTimeout{
 			Default: schema.DefaultTimeout(defaultK8SPoolTimeout),
 		},
+		CustomizeDiff: customdiff.All(
+			customizeDiffCatalogType("node_type", "node type", k8sNormalizeNodeType, k8sPoolNodeTypeNames),
+			customizeDiffLocalityCheck("region", "cluster_id", "placement_group_id"),
+			customizeDiffTagsAll,
+		),
 		SchemaVersion: 0,
 		Schema: map[string]*schema.Schema{
 			"cluster_id": {
@@ -80,6 +87,7 @@ func resourceScalewayK8SPool() *schema.Resource {
 				Optional:    true,
 				Description: "The tags associated with the pool",
 			},
+			"tags_all": tagsAllSchema(),
 			"container_runtime": {
 				Type:        schema.TypeString,
 				Optional:    true,
@@ -193,13 +201,17 @@ func resourceScalewayK8SPool() *schema.Resource {
 				Description: "The status of the pool",
 			},
 		},
-	}
+	}, map[string]string{
+		"id":                 "region",
+		"cluster_id":         "region",
+		"placement_group_id": "zone",
+	})
 }
 
 func resourceScalewayK8SPoolCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	k8sAPI, region, err := k8sAPIWithRegion(d, meta)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	////
@@ -214,8 +226,8 @@ func resourceScalewayK8SPoolCreate(ctx context.Context, d *schema.ResourceData,
 		Autoscaling: d.Get("autoscaling").(bool),
 		Autohealing: d.Get("autohealing").(bool),
 		Size:        uint32(d.Get("size").(int)),
-		Tags:        expandStrings(d.Get("tags")),
-		Zone:        scw.Zone(d.Get("zone").(string)),
+		Tags:        expandTags(meta, d.Get("tags")),
+		Zone:        scw.Zone(strings.ToLower(d.Get("zone").(string))),
 		KubeletArgs: expandKubeletArgs(d.Get("kubelet_args")),
 	}
 
@@ -255,7 +267,7 @@ func resourceScalewayK8SPoolCreate(ctx context.Context, d *schema.ResourceData,
 		Region:    region,
 	}, scw.WithContext(ctx))
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	waitForCluster := false
@@ -263,30 +275,30 @@ func resourceScalewayK8SPoolCreate(ctx context.Context, d *schema.ResourceData,
 	if cluster.Status == k8s.ClusterStatusPoolRequired {
 		waitForCluster = true
 	} else if cluster.Status == k8s.ClusterStatusCreating {
-		err = waitK8SCluster(ctx, k8sAPI, region, cluster.ID, k8s.ClusterStatusReady)
+		err = waitK8SCluster(ctx, k8sAPI, region, cluster.ID, d.Timeout(schema.TimeoutCreate), k8s.ClusterStatusReady)
 		if err != nil {
-			return diag.FromErr(err)
+			return diagFromErr(err)
 		}
 	}
 
 	res, err := k8sAPI.CreatePool(req, scw.WithContext(ctx))
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	d.SetId(newRegionalIDString(region, res.ID))
 
 	if waitForCluster {
-		err = waitK8SCluster(ctx, k8sAPI, region, cluster.ID, k8s.ClusterStatusReady)
+		err = waitK8SCluster(ctx, k8sAPI, region, cluster.ID, d.Timeout(schema.TimeoutCreate), k8s.ClusterStatusReady)
 		if err != nil {
-			return diag.FromErr(err)
+			return diagFromErr(err)
 		}
 	}
 
 	if d.Get("wait_for_pool_ready").(bool) { // wait for the pool to be ready if specified (including all its nodes)
-		err = waitK8SPoolReady(ctx, k8sAPI, region, res.ID)
+		err = waitK8SPoolReady(ctx, k8sAPI, region, res.ID, d.Timeout(schema.TimeoutCreate))
 		if err != nil {
-			return diag.FromErr(err)
+			return diagFromErr(err)
 		}
 	}
 
@@ -296,7 +308,7 @@ func resourceScalewayK8SPoolCreate(ctx context.Context, d *schema.ResourceData,
 func resourceScalewayK8SPoolRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	k8sAPI, region, poolID, err := k8sAPIWithRegionAndID(meta, d.Id())
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	////
@@ -311,12 +323,12 @@ func resourceScalewayK8SPoolRead(ctx context.Context, d *schema.ResourceData, me
 			d.SetId("")
 			return nil
 		}
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	nodes, err := getNodes(ctx, k8sAPI, pool)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	_ = d.Set("cluster_id", newRegionalIDString(region, pool.ClusterID))
@@ -331,7 +343,8 @@ func resourceScalewayK8SPoolRead(ctx context.Context, d *schema.ResourceData, me
 	_ = d.Set("version", pool.Version)
 	_ = d.Set("min_size", int(pool.MinSize))
 	_ = d.Set("max_size", int(pool.MaxSize))
-	_ = d.Set("tags", pool.Tags)
+	_ = d.Set("tags", flattenTags(meta, pool.Tags, d.Get("tags")))
+	_ = d.Set("tags_all", pool.Tags)
 	_ = d.Set("container_runtime", pool.ContainerRuntime)
 	_ = d.Set("created_at", pool.CreatedAt.Format(time.RFC3339))
 	_ = d.Set("updated_at", pool.UpdatedAt.Format(time.RFC3339))
@@ -352,7 +365,7 @@ func resourceScalewayK8SPoolRead(ctx context.Context, d *schema.ResourceData, me
 func resourceScalewayK8SPoolUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	k8sAPI, region, poolID, err := k8sAPIWithRegionAndID(meta, d.Id())
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	////
@@ -383,8 +396,8 @@ func resourceScalewayK8SPoolUpdate(ctx context.Context, d *schema.ResourceData,
 		updateRequest.Size = scw.Uint32Ptr(uint32(d.Get("size").(int)))
 	}
 
-	if d.HasChange("tags") {
-		updateRequest.Tags = scw.StringsPtr(expandStrings(d.Get("tags")))
+	if d.HasChanges("tags", "tags_all") {
+		updateRequest.Tags = scw.StringsPtr(expandTags(meta, d.Get("tags")))
 	}
 
 	if d.HasChange("kubelet_args") {
@@ -406,13 +419,13 @@ func resourceScalewayK8SPoolUpdate(ctx context.Context, d *schema.ResourceData,
 
 	res, err := k8sAPI.UpdatePool(updateRequest, scw.WithContext(ctx))
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	if d.Get("wait_for_pool_ready").(bool) { // wait for the pool to be ready if specified (including all its nodes)
-		err = waitK8SPoolReady(ctx, k8sAPI, region, res.ID)
+		err = waitK8SPoolReady(ctx, k8sAPI, region, res.ID, d.Timeout(schema.TimeoutUpdate))
 		if err != nil {
-			return diag.FromErr(err)
+			return diagFromErr(err)
 		}
 	}
 
@@ -422,7 +435,7 @@ func resourceScalewayK8SPoolUpdate(ctx context.Context, d *schema.ResourceData,
 func resourceScalewayK8SPoolDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	k8sAPI, region, poolID, err := k8sAPIWithRegionAndID(meta, d.Id())
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	////
@@ -434,7 +447,7 @@ func resourceScalewayK8SPoolDelete(ctx context.Context, d *schema.ResourceData,
 	}, scw.WithContext(ctx))
 	if err != nil {
 		if !is404Error(err) {
-			return diag.FromErr(err)
+			return diagFromErr(err)
 		}
 	}
 
@@ -1,12 +1,16 @@
 package scaleway
 
 import (
+	"context"
 	"fmt"
+	"net/http"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	"github.com/scaleway/scaleway-sdk-go/api/k8s/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestAccScalewayK8SCluster_PoolBasic(t *testing.T) {
@@ -482,3 +486,57 @@ resource "scaleway_k8s_cluster" "zone" {
 	tags = [ "terraform-test", "scaleway_k8s_cluster", "zone" ]
 }`, zone, version)
 }
+
+func TestScalewayK8SPool_FakeAPIImportByName(t *testing.T) {
+	f := newFakeAPI(t)
+	defer f.close()
+	meta := f.meta()
+	ctx := context.Background()
+
+	cluster1 := f.seed("k8s", "fr-par", "clusters", map[string]interface{}{"name": "cluster-1"})
+	cluster2 := f.seed("k8s", "fr-par", "clusters", map[string]interface{}{"name": "cluster-2"})
+	pool := f.seed("k8s", "fr-par", "pools", map[string]interface{}{"name": "pool", "cluster_id": cluster1["id"]})
+	f.seed("k8s", "fr-par", "pools", map[string]interface{}{"name": "default", "cluster_id": cluster1["id"]})
+	f.seed("k8s", "fr-par", "pools", map[string]interface{}{"name": "default", "cluster_id": cluster2["id"]})
+	importer := resourceScalewayK8SPool().Importer.StateContext
+
+	d := f.resourceData(resourceScalewayK8SPool(), "fr-par/pool", map[string]interface{}{})
+	res, err := importer(ctx, d, meta)
+	require.NoError(t, err)
+	assert.Equal(t, newRegionalIDString("fr-par", pool["id"].(string)), res[0].Id())
+
+	// Pools with the same name in different clusters are ambiguous.
+	d = f.resourceData(resourceScalewayK8SPool(), "fr-par/default", map[string]interface{}{})
+	_, err = importer(ctx, d, meta)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `2 k8s pools named "default" found in fr-par`)
+}
+
+func TestScalewayK8SPool_FakeAPIPlanNodeType(t *testing.T) {
+	f := newFakeAPI(t)
+	defer f.close()
+	meta := f.meta()
+
+	f.respond(http.MethodGet, "/instance/v1/zones/nl-ams-1/products/servers", http.StatusOK, map[string]interface{}{
+		"servers": map[string]interface{}{
+			"DEV1-M": map[string]interface{}{},
+			"GP1-XS": map[string]interface{}{},
+		},
+	}, false)
+
+	pool := map[string]interface{}{
+		"cluster_id": "nl-ams/11111111-1111-1111-1111-111111111111",
+		"name":       "pool",
+		"size":       1,
+		"region":     "nl-ams",
+	}
+
+	// Without zone, the node type is looked up in the first zone of the region, ignoring case and hyphens.
+	pool["node_type"] = "gp1_xs"
+	require.NoError(t, f.plan(meta, resourceScalewayK8SPool(), pool))
+
+	pool["node_type"] = "gp1_s"
+	err := f.plan(meta, resourceScalewayK8SPool(), pool)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `unknown node type "gp1_s", did you mean GP1-XS?`)
+}
@@ -5,23 +5,29 @@ import (
 	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/scaleway/scaleway-sdk-go/api/lb/v1"
 	"github.com/scaleway/scaleway-sdk-go/scw"
 )
 
 func resourceScalewayLb() *schema.Resource {
-	return &schema.Resource{
+	return withLocalizedIDsStateUpgrade(&schema.Resource{
 		CreateContext: resourceScalewayLbCreate,
 		ReadContext:   resourceScalewayLbRead,
 		UpdateContext: resourceScalewayLbUpdate,
 		DeleteContext: resourceScalewayLbDelete,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importStateByName("load balancer", lbIDsByName),
 		},
 		Timeouts: &schema.ResourceTimeout{
 			Default: schema.DefaultTimeout(defaultLbLbTimeout),
 		},
+		CustomizeDiff: customdiff.All(
+			customizeDiffCatalogType("type", "load balancer type", strings.ToLower, lbTypeNames),
+			customizeDiffLocalityCheck("region", "ip_id"),
+			customizeDiffTagsAll,
+		),
 		SchemaVersion: 0,
 		Schema: map[string]*schema.Schema{
 			"name": {
@@ -45,6 +51,7 @@ func resourceScalewayLb() *schema.Resource {
 				},
 				Description: "Array of tags to associate with the load-balancer",
 			},
+			"tags_all": tagsAllSchema(),
 			"ip_id": {
 				Type:             schema.TypeString,
 				Required:         true,
@@ -61,13 +68,16 @@ func resourceScalewayLb() *schema.Resource {
 			"organization_id": organizationIDSchema(),
 			"project_id":      projectIDSchema(),
 		},
-	}
+	}, map[string]string{
+		"id":    "region",
+		"ip_id": "region",
+	})
 }
 
 func resourceScalewayLbCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	lbAPI, region, err := lbAPIWithRegion(d, meta)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	createReq := &lb.CreateLBRequest{
@@ -76,16 +86,12 @@ func resourceScalewayLbCreate(ctx context.Context, d *schema.ResourceData, meta
 		ProjectID: expandStringPtr(d.Get("project_id")),
 		Name:      expandOrGenerateString(d.Get("name"), "lb"),
 		Type:      d.Get("type").(string),
+		Tags:      expandTags(meta, d.Get("tags")),
 	}
 
-	if raw, ok := d.GetOk("tags"); ok {
-		for _, tag := range raw.([]interface{}) {
-			createReq.Tags = append(createReq.Tags, tag.(string))
-		}
-	}
 	res, err := lbAPI.CreateLB(createReq, scw.WithContext(ctx))
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	d.SetId(newRegionalIDString(region, res.ID))
@@ -93,10 +99,10 @@ func resourceScalewayLbCreate(ctx context.Context, d *schema.ResourceData, meta
 	_, err = lbAPI.WaitForLb(&lb.WaitForLBRequest{
 		Region:  region,
 		LBID:    res.ID,
-		Timeout: scw.TimeDurationPtr(defaultInstanceServerWaitTimeout),
+		Timeout: scw.TimeDurationPtr(d.Timeout(schema.TimeoutCreate)),
 	}, scw.WithContext(ctx))
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	return resourceScalewayLbRead(ctx, d, meta)
@@ -105,7 +111,7 @@ func resourceScalewayLbCreate(ctx context.Context, d *schema.ResourceData, meta
 func resourceScalewayLbRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	lbAPI, region, ID, err := lbAPIWithRegionAndID(meta, d.Id())
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	res, err := lbAPI.GetLB(&lb.GetLBRequest{
@@ -118,14 +124,15 @@ func resourceScalewayLbRead(ctx context.Context, d *schema.ResourceData, meta in
 			d.SetId("")
 			return nil
 		}
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	_ = d.Set("name", res.Name)
 	_ = d.Set("region", string(region))
 	_ = d.Set("organization_id", res.OrganizationID)
 	_ = d.Set("project_id", res.ProjectID)
-	_ = d.Set("tags", res.Tags)
+	_ = d.Set("tags", flattenTags(meta, res.Tags, d.Get("tags")))
+	_ = d.Set("tags_all", res.Tags)
 	// For now API return lowercase lb type. This should be fix in a near future on the API side
 	_ = d.Set("type", strings.ToUpper(res.Type))
 	_ = d.Set("ip_id", newRegionalIDString(region, res.IP[0].ID))
@@ -137,20 +144,20 @@ func resourceScalewayLbRead(ctx context.Context, d *schema.ResourceData, meta in
 func resourceScalewayLbUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	lbAPI, region, ID, err := lbAPIWithRegionAndID(meta, d.Id())
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
-	if d.HasChanges("name", "tags") {
+	if d.HasChanges("name", "tags", "tags_all") {
 		req := &lb.UpdateLBRequest{
 			Region: region,
 			LBID:   ID,
 			Name:   d.Get("name").(string),
-			Tags:   expandStrings(d.Get("tags")),
+			Tags:   expandTags(meta, d.Get("tags")),
 		}
 
 		_, err = lbAPI.UpdateLB(req, scw.WithContext(ctx))
 		if err != nil {
-			return diag.FromErr(err)
+			return diagFromErr(err)
 		}
 	}
 
@@ -160,7 +167,7 @@ func resourceScalewayLbUpdate(ctx context.Context, d *schema.ResourceData, meta
 func resourceScalewayLbDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	lbAPI, region, ID, err := lbAPIWithRegionAndID(meta, d.Id())
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	err = lbAPI.DeleteLB(&lb.DeleteLBRequest{
@@ -170,17 +177,17 @@ func resourceScalewayLbDelete(ctx context.Context, d *schema.ResourceData, meta
 	}, scw.WithContext(ctx))
 
 	if err != nil && !is404Error(err) {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	_, err = lbAPI.WaitForLb(&lb.WaitForLBRequest{
 		LBID:    ID,
 		Region:  region,
-		Timeout: scw.TimeDurationPtr(LbWaitForTimeout),
+		Timeout: scw.TimeDurationPtr(d.Timeout(schema.TimeoutDelete)),
 	}, scw.WithContext(ctx))
 
 	if err != nil && !is404Error(err) {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	return nil
@@ -11,7 +11,7 @@ import (
 )
 
 func resourceScalewayLbBackend() *schema.Resource {
-	return &schema.Resource{
+	return withLocalizedIDsStateUpgrade(&schema.Resource{
 		CreateContext: resourceScalewayLbBackendCreate,
 		ReadContext:   resourceScalewayLbBackendRead,
 		UpdateContext: resourceScalewayLbBackendUpdate,
@@ -233,7 +233,10 @@ func resourceScalewayLbBackend() *schema.Resource {
 				Description: "Modify what occurs when a backend server is marked down",
 			},
 		},
-	}
+	}, map[string]string{
+		"id":    "region",
+		"lb_id": "region",
+	})
 }
 
 func resourceScalewayLbBackendCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
@@ -241,7 +244,7 @@ func resourceScalewayLbBackendCreate(ctx context.Context, d *schema.ResourceData
 
 	region, LbID, err := parseRegionalID(d.Get("lb_id").(string))
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	healthCheckPort := d.Get("health_check_port").(int)
@@ -278,7 +281,7 @@ func resourceScalewayLbBackendCreate(ctx context.Context, d *schema.ResourceData
 
 	res, err := lbAPI.CreateBackend(createReq, scw.WithContext(ctx))
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	d.SetId(newRegionalIDString(region, res.ID))
@@ -289,7 +292,7 @@ func resourceScalewayLbBackendCreate(ctx context.Context, d *schema.ResourceData
 func resourceScalewayLbBackendRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	lbAPI, region, ID, err := lbAPIWithRegionAndID(meta, d.Id())
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	res, err := lbAPI.GetBackend(&lb.GetBackendRequest{
@@ -302,7 +305,7 @@ func resourceScalewayLbBackendRead(ctx context.Context, d *schema.ResourceData,
 			d.SetId("")
 			return nil
 		}
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	_ = d.Set("lb_id", newRegionalIDString(region, res.LB.ID))
@@ -333,7 +336,7 @@ func resourceScalewayLbBackendRead(ctx context.Context, d *schema.ResourceData,
 func resourceScalewayLbBackendUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	lbAPI, region, ID, err := lbAPIWithRegionAndID(meta, d.Id())
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	req := &lb.UpdateBackendRequest{
@@ -355,7 +358,7 @@ func resourceScalewayLbBackendUpdate(ctx context.Context, d *schema.ResourceData
 
 	_, err = lbAPI.UpdateBackend(req, scw.WithContext(ctx))
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	// Update Health Check
@@ -377,7 +380,7 @@ func resourceScalewayLbBackendUpdate(ctx context.Context, d *schema.ResourceData
 
 	_, err = lbAPI.UpdateHealthCheck(updateHCRequest, scw.WithContext(ctx))
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	// Update Backend servers
@@ -387,7 +390,7 @@ func resourceScalewayLbBackendUpdate(ctx context.Context, d *schema.ResourceData
 		ServerIP:  expandStrings(d.Get("server_ips")),
 	}, scw.WithContext(ctx))
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	return resourceScalewayLbBackendRead(ctx, d, meta)
@@ -396,7 +399,7 @@ func resourceScalewayLbBackendUpdate(ctx context.Context, d *schema.ResourceData
 func resourceScalewayLbBackendDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	lbAPI, region, ID, err := lbAPIWithRegionAndID(meta, d.Id())
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	err = lbAPI.DeleteBackend(&lb.DeleteBackendRequest{
@@ -405,7 +408,7 @@ func resourceScalewayLbBackendDelete(ctx context.Context, d *schema.ResourceData
 	}, scw.WithContext(ctx))
 
 	if err != nil && !is404Error(err) {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	return nil
@@ -11,7 +11,7 @@ import (
 )
 
 func resourceScalewayLbCertificate() *schema.Resource {
-	return &schema.Resource{
+	return withLocalizedIDsStateUpgrade(&schema.Resource{
 		CreateContext: resourceScalewayLbCertificateCreate,
 		ReadContext:   resourceScalewayLbCertificateRead,
 		UpdateContext: resourceScalewayLbCertificateUpdate,
@@ -112,13 +112,16 @@ func resourceScalewayLbCertificate() *schema.Resource {
 				Description: "The status of certificate",
 			},
 		},
-	}
+	}, map[string]string{
+		"id":    "region",
+		"lb_id": "region",
+	})
 }
 
 func resourceScalewayLbCertificateCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	region, lbID, err := parseRegionalID(d.Get("lb_id").(string))
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	createReq := &lb.CreateCertificateRequest{
@@ -129,13 +132,13 @@ func resourceScalewayLbCertificateCreate(ctx context.Context, d *schema.Resource
 		CustomCertificate: expandLbCustomCertificate(d.Get("custom_certificate")),
 	}
 	if createReq.Letsencrypt == nil && createReq.CustomCertificate == nil {
-		return diag.FromErr(errors.New("you need to define either letsencrypt or custom_certificate configuration"))
+		return diagFromErr(errors.New("you need to define either letsencrypt or custom_certificate configuration"))
 	}
 
 	lbAPI := lbAPI(meta)
 	res, err := lbAPI.CreateCertificate(createReq, scw.WithContext(ctx))
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	d.SetId(newRegionalIDString(region, res.ID))
@@ -146,7 +149,7 @@ func resourceScalewayLbCertificateCreate(ctx context.Context, d *schema.Resource
 func resourceScalewayLbCertificateRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	lbAPI, region, ID, err := lbAPIWithRegionAndID(meta, d.Id())
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	res, err := lbAPI.GetCertificate(&lb.GetCertificateRequest{
@@ -159,7 +162,7 @@ func resourceScalewayLbCertificateRead(ctx context.Context, d *schema.ResourceDa
 			d.SetId("")
 			return nil
 		}
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	_ = d.Set("name", res.Name)
@@ -175,7 +178,7 @@ func resourceScalewayLbCertificateRead(ctx context.Context, d *schema.ResourceDa
 func resourceScalewayLbCertificateUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	lbAPI, region, ID, err := lbAPIWithRegionAndID(meta, d.Id())
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	req := &lb.UpdateCertificateRequest{
@@ -186,7 +189,7 @@ func resourceScalewayLbCertificateUpdate(ctx context.Context, d *schema.Resource
 
 	_, err = lbAPI.UpdateCertificate(req, scw.WithContext(ctx))
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	return resourceScalewayLbCertificateRead(ctx, d, meta)
@@ -195,7 +198,7 @@ func resourceScalewayLbCertificateUpdate(ctx context.Context, d *schema.Resource
 func resourceScalewayLbCertificateDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	lbAPI, region, ID, err := lbAPIWithRegionAndID(meta, d.Id())
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	err = lbAPI.DeleteCertificate(&lb.DeleteCertificateRequest{
@@ -204,7 +207,7 @@ func resourceScalewayLbCertificateDelete(ctx context.Context, d *schema.Resource
 	}, scw.WithContext(ctx))
 
 	if err != nil && !is404Error(err) {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	return nil
@@ -14,7 +14,7 @@ import (
 )
 
 func resourceScalewayLbFrontend() *schema.Resource {
-	return &schema.Resource{
+	return withLocalizedIDsStateUpgrade(&schema.Resource{
 		CreateContext: resourceScalewayLbFrontendCreate,
 		ReadContext:   resourceScalewayLbFrontendRead,
 		UpdateContext: resourceScalewayLbFrontendUpdate,
@@ -25,6 +25,7 @@ func resourceScalewayLbFrontend() *schema.Resource {
 		Timeouts: &schema.ResourceTimeout{
 			Default: schema.DefaultTimeout(defaultLbLbTimeout),
 		},
+		CustomizeDiff: customizeDiffLocalityCheck("", "lb_id", "backend_id", "certificate_id"),
 		SchemaVersion: 0,
 		Schema: map[string]*schema.Schema{
 			"lb_id": {
@@ -148,7 +149,12 @@ func resourceScalewayLbFrontend() *schema.Resource {
 				},
 			},
 		},
-	}
+	}, map[string]string{
+		"id":             "region",
+		"lb_id":          "region",
+		"backend_id":     "region",
+		"certificate_id": "region",
+	})
 }
 
 func resourceScalewayLbFrontendCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
@@ -156,7 +162,7 @@ func resourceScalewayLbFrontendCreate(ctx context.Context, d *schema.ResourceDat
 
 	region, LbID, err := parseRegionalID(d.Get("lb_id").(string))
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	res, err := lbAPI.CreateFrontend(&lb.CreateFrontendRequest{
@@ -169,7 +175,7 @@ func resourceScalewayLbFrontendCreate(ctx context.Context, d *schema.ResourceDat
 		CertificateID: expandStringPtr(expandID(d.Get("certificate_id"))),
 	}, scw.WithContext(ctx))
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	d.SetId(newRegionalIDString(region, res.ID))
@@ -185,7 +191,7 @@ func resourceScalewayLbFrontendCreate(ctx context.Context, d *schema.ResourceDat
 func resourceScalewayLbFrontendRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	lbAPI, region, ID, err := lbAPIWithRegionAndID(meta, d.Id())
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	res, err := lbAPI.GetFrontend(&lb.GetFrontendRequest{
@@ -198,7 +204,7 @@ func resourceScalewayLbFrontendRead(ctx context.Context, d *schema.ResourceData,
 			d.SetId("")
 			return nil
 		}
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	_ = d.Set("lb_id", newRegionalIDString(region, res.LB.ID))
@@ -219,7 +225,7 @@ func resourceScalewayLbFrontendRead(ctx context.Context, d *schema.ResourceData,
 		FrontendID: ID,
 	}, scw.WithAllPages(), scw.WithContext(ctx))
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	_ = d.Set("acl", flattenLBACLs(resACL.ACLs))
@@ -245,7 +251,7 @@ func resourceScalewayLbFrontendUpdateACL(ctx context.Context, d *schema.Resource
 		FrontendID: frontendID,
 	}, scw.WithAllPages(), scw.WithContext(ctx))
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 	apiAcls := make(map[int32]*lb.ACL)
 	for _, acl := range resACL.ACLs {
@@ -279,7 +285,7 @@ func resourceScalewayLbFrontendUpdateACL(ctx context.Context, d *schema.Resource
 				Index:  key,
 			})
 			if err != nil {
-				return diag.FromErr(err)
+				return diagFromErr(err)
 			}
 			continue
 		}
@@ -293,7 +299,7 @@ func resourceScalewayLbFrontendUpdateACL(ctx context.Context, d *schema.Resource
 			Index:      key,
 		}, scw.WithContext(ctx))
 		if err != nil {
-			return diag.FromErr(err)
+			return diagFromErr(err)
 		}
 	}
 	//we've finished with all new acl, delete any remaining old one which were not dealt with yet
@@ -303,7 +309,7 @@ func resourceScalewayLbFrontendUpdateACL(ctx context.Context, d *schema.Resource
 			ACLID:  acl.ID,
 		}, scw.WithContext(ctx))
 		if err != nil {
-			return diag.FromErr(err)
+			return diagFromErr(err)
 		}
 	}
 	return nil
@@ -321,7 +327,7 @@ func expandsLBACLs(raw interface{}) []*lb.ACL {
 func resourceScalewayLbFrontendUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	lbAPI, region, ID, err := lbAPIWithRegionAndID(meta, d.Id())
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	req := &lb.UpdateFrontendRequest{
@@ -336,7 +342,7 @@ func resourceScalewayLbFrontendUpdate(ctx context.Context, d *schema.ResourceDat
 
 	_, err = lbAPI.UpdateFrontend(req, scw.WithContext(ctx))
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	//update acl
@@ -351,7 +357,7 @@ func resourceScalewayLbFrontendUpdate(ctx context.Context, d *schema.ResourceDat
 func resourceScalewayLbFrontendDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	lbAPI, region, ID, err := lbAPIWithRegionAndID(meta, d.Id())
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	err = lbAPI.DeleteFrontend(&lb.DeleteFrontendRequest{
@@ -360,7 +366,7 @@ func resourceScalewayLbFrontendDelete(ctx context.Context, d *schema.ResourceDat
 	}, scw.WithContext(ctx))
 
 	if err != nil && !is404Error(err) {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	return nil
@@ -10,7 +10,7 @@ import (
 )
 
 func resourceScalewayLbIP() *schema.Resource {
-	return &schema.Resource{
+	return withLocalizedIDsStateUpgrade(&schema.Resource{
 		CreateContext: resourceScalewayLbIPCreate,
 		ReadContext:   resourceScalewayLbIPRead,
 		UpdateContext: resourceScalewayLbIPUpdate,
@@ -44,13 +44,15 @@ func resourceScalewayLbIP() *schema.Resource {
 				Description: "The ID of the loadbalancer attached to this IP, if any",
 			},
 		},
-	}
+	}, map[string]string{
+		"id": "region",
+	})
 }
 
 func resourceScalewayLbIPCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	lbAPI, region, err := lbAPIWithRegion(d, meta)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	createReq := &lb.CreateIPRequest{
@@ -61,7 +63,7 @@ func resourceScalewayLbIPCreate(ctx context.Context, d *schema.ResourceData, met
 
 	res, err := lbAPI.CreateIP(createReq, scw.WithContext(ctx))
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	d.SetId(newRegionalIDString(region, res.ID))
@@ -72,7 +74,7 @@ func resourceScalewayLbIPCreate(ctx context.Context, d *schema.ResourceData, met
 func resourceScalewayLbIPRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	lbAPI, region, ID, err := lbAPIWithRegionAndID(meta, d.Id())
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	res, err := lbAPI.GetIP(&lb.GetIPRequest{
@@ -85,7 +87,7 @@ func resourceScalewayLbIPRead(ctx context.Context, d *schema.ResourceData, meta
 			d.SetId("")
 			return nil
 		}
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	_ = d.Set("region", string(region))
@@ -101,7 +103,7 @@ func resourceScalewayLbIPRead(ctx context.Context, d *schema.ResourceData, meta
 func resourceScalewayLbIPUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	lbAPI, region, ID, err := lbAPIWithRegionAndID(meta, d.Id())
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	if d.HasChange("reverse") {
@@ -113,7 +115,7 @@ func resourceScalewayLbIPUpdate(ctx context.Context, d *schema.ResourceData, met
 
 		_, err = lbAPI.UpdateIP(req, scw.WithContext(ctx))
 		if err != nil {
-			return diag.FromErr(err)
+			return diagFromErr(err)
 		}
 	}
 
@@ -123,7 +125,7 @@ func resourceScalewayLbIPUpdate(ctx context.Context, d *schema.ResourceData, met
 func resourceScalewayLbIPDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	lbAPI, region, ID, err := lbAPIWithRegionAndID(meta, d.Id())
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	err = lbAPI.ReleaseIP(&lb.ReleaseIPRequest{
@@ -132,7 +134,7 @@ func resourceScalewayLbIPDelete(ctx context.Context, d *schema.ResourceData, met
 	}, scw.WithContext(ctx))
 
 	if err != nil && !is404Error(err) {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	return nil
@@ -12,29 +12,36 @@ import (
 
 func init() {
 	resource.AddTestSweepers("scaleway_lb_ip", &resource.Sweeper{
-		Name: "scaleway_lb_ip",
-		F:    testSweepLBIP,
+		Name:         "scaleway_lb_ip",
+		F:            testSweepLBIP,
+		Dependencies: []string{"scaleway_lb"},
 	})
 }
 
 func testSweepLBIP(_ string) error {
-	return sweepRegions([]scw.Region{scw.RegionFrPar, scw.RegionNlAms, scw.RegionPlWaw}, func(scwClient *scw.Client, region scw.Region) error {
+	return sweepAllRegions(func(scwClient *scw.Client, region scw.Region) error {
 		lbAPI := lb.NewAPI(scwClient)
 
 		l.Debugf("sweeper: destroying the lb ips in (%s)", region)
-		listIPs, err := lbAPI.ListIPs(&lb.ListIPsRequest{}, scw.WithAllPages())
+		listIPs, err := lbAPI.ListIPs(&lb.ListIPsRequest{
+			Region: region,
+		}, scw.WithAllPages())
 		if err != nil {
 			return fmt.Errorf("error listing lb ips in (%s) in sweeper: %s", region, err)
 		}
 
 		for _, ip := range listIPs.IPs {
-			if ip.LBID == nil {
-				err := lbAPI.ReleaseIP(&lb.ReleaseIPRequest{
-					IPID: ip.ID,
+			if ip.LBID != nil {
+				continue
+			}
+			err := sweep(&sweptResource{kind: "lb ip", id: ip.ID}, func() error {
+				return lbAPI.ReleaseIP(&lb.ReleaseIPRequest{
+					Region: region,
+					IPID:   ip.ID,
 				})
-				if err != nil {
-					return fmt.Errorf("error deleting lb ip in sweeper: %s", err)
-				}
+			})
+			if err != nil {
+				return fmt.Errorf("error deleting lb ip in sweeper: %s", err)
 			}
 		}
 
@@ -1,24 +1,29 @@
 package scaleway
 
 import (
+	"context"
 	"fmt"
+	"net/http"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	"github.com/scaleway/scaleway-sdk-go/api/lb/v1"
 	"github.com/scaleway/scaleway-sdk-go/scw"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func init() {
 	resource.AddTestSweepers("scaleway_lb", &resource.Sweeper{
-		Name: "scaleway_lb",
-		F:    testSweepLB,
+		Name:         "scaleway_lb",
+		F:            testSweepLB,
+		Dependencies: []string{"scaleway_k8s_cluster"},
 	})
 }
 
 func testSweepLB(_ string) error {
-	return sweepRegions([]scw.Region{scw.RegionFrPar, scw.RegionNlAms, scw.RegionPlWaw}, func(scwClient *scw.Client, region scw.Region) error {
+	return sweepAllRegions(func(scwClient *scw.Client, region scw.Region) error {
 		lbAPI := lb.NewAPI(scwClient)
 
 		l.Debugf("sweeper: destroying the lbs in (%s)", region)
@@ -29,11 +34,13 @@ func testSweepLB(_ string) error {
 			return fmt.Errorf("error listing lbs in (%s) in sweeper: %s", region, err)
 		}
 
-		for _, l := range listLBs.LBs {
-			err := lbAPI.DeleteLB(&lb.DeleteLBRequest{
-				LBID:      l.ID,
-				ReleaseIP: true,
-				Region:    region,
+		for _, loadBalancer := range listLBs.LBs {
+			err := sweep(&sweptResource{kind: "lb", id: loadBalancer.ID, name: loadBalancer.Name, tags: loadBalancer.Tags, createdAt: loadBalancer.CreatedAt}, func() error {
+				return lbAPI.DeleteLB(&lb.DeleteLBRequest{
+					LBID:      loadBalancer.ID,
+					ReleaseIP: true,
+					Region:    region,
+				})
 			})
 			if err != nil {
 				return fmt.Errorf("error deleting lb in sweeper: %s", err)
@@ -141,3 +148,50 @@ func testAccCheckScalewayLbDestroy(tt *TestTools) resource.TestCheckFunc {
 		return nil
 	}
 }
+
+func TestScalewayLbLb_FakeAPI(t *testing.T) {
+	f := newFakeAPI(t)
+	defer f.close()
+	meta := f.meta()
+	ctx := context.Background()
+
+	ip := f.seed("lb", "fr-par", "ips", map[string]interface{}{})
+	d := f.resourceData(resourceScalewayLb(), "", map[string]interface{}{
+		"name":  "lb",
+		"type":  "LB-S",
+		"ip_id": newRegionalIDString("fr-par", ip["id"].(string)),
+	})
+
+	// The load balancer is pending once created, create must wait for it to be ready.
+	require.False(t, resourceScalewayLbCreate(ctx, d, meta).HasError())
+	lbID := expandRegionalID(d.Id()).ID
+	assert.Equal(t, "ready", f.lookup("lb", "fr-par", "lbs", lbID)["status"])
+	assert.Equal(t, "51.159.0.1", d.Get("ip_address"))
+	assert.Equal(t, newRegionalIDString("fr-par", ip["id"].(string)), d.Get("ip_id"))
+
+	// The load balancer goes through the to_delete status before being removed.
+	require.False(t, resourceScalewayLbDelete(ctx, d, meta).HasError())
+	assert.Nil(t, f.lookup("lb", "fr-par", "lbs", lbID))
+	assert.NotNil(t, f.lookup("lb", "fr-par", "ips", ip["id"].(string)))
+}
+
+func TestScalewayLbLb_FakeAPIPlanType(t *testing.T) {
+	f := newFakeAPI(t)
+	defer f.close()
+	meta := f.meta()
+
+	f.respond(http.MethodGet, "/lb/v1/regions/fr-par/lb-types", http.StatusOK, map[string]interface{}{
+		"lb_types": []interface{}{
+			map[string]interface{}{"name": "lb-s", "zone": "fr-par-1"},
+			map[string]interface{}{"name": "lb-s", "zone": "fr-par-2"},
+			map[string]interface{}{"name": "lb-gp-m", "zone": "fr-par-1"},
+		},
+		"total_count": 3,
+	}, false)
+
+	require.NoError(t, f.plan(meta, resourceScalewayLb(), map[string]interface{}{"type": "LB-S", "ip_id": "fr-par/11111111-1111-1111-1111-111111111111"}))
+
+	err := f.plan(meta, resourceScalewayLb(), map[string]interface{}{"type": "LB-GP-MM", "ip_id": "fr-par/11111111-1111-1111-1111-111111111111"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `unknown load balancer type "LB-GP-MM", did you mean lb-gp-m?`)
+}
@@ -21,8 +21,9 @@ func resourceScalewayObjectBucket() *schema.Resource {
 		Timeouts: &schema.ResourceTimeout{
 			Default: schema.DefaultTimeout(defaultObjectBucketTimeout),
 		},
+		CustomizeDiff: customizeDiffObjectBucketTagsAll,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importObjectBucket,
 		},
 		Schema: map[string]*schema.Schema{
 			"name": {
@@ -51,6 +52,14 @@ func resourceScalewayObjectBucket() *schema.Resource {
 				Optional:    true,
 				Description: "The tags associated with this bucket",
 			},
+			"tags_all": {
+				Type: schema.TypeMap,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Computed:    true,
+				Description: "The tags of the bucket, including the provider default tags",
+			},
 			"endpoint": {
 				Type:        schema.TypeString,
 				Description: "Endpoint of the bucket",
@@ -89,6 +98,13 @@ func resourceScalewayObjectBucket() *schema.Resource {
 				},
 			},
 			"region": regionSchema(),
+			"project_id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				Description:  "The project_id of the bucket, defaults to the default project of the object storage access key",
+				ValidateFunc: validationUUID(),
+			},
 			"versioning": {
 				Type:     schema.TypeList,
 				Optional: true,
@@ -114,7 +130,7 @@ func resourceScalewayObjectBucketCreate(ctx context.Context, d *schema.ResourceD
 
 	s3Client, region, err := s3ClientWithRegion(d, meta)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	_, err = s3Client.CreateBucketWithContext(ctx, &s3.CreateBucketInput{
@@ -122,10 +138,10 @@ func resourceScalewayObjectBucketCreate(ctx context.Context, d *schema.ResourceD
 		ACL:    scw.StringPtr(acl),
 	})
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
-	tagsSet := expandObjectBucketTags(d.Get("tags"))
+	tagsSet := expandObjectBucketTags(d.Get("tags"), meta.(*Meta).defaultTags)
 
 	if len(tagsSet) > 0 {
 		_, err = s3Client.PutBucketTaggingWithContext(ctx, &s3.PutBucketTaggingInput{
@@ -135,7 +151,7 @@ func resourceScalewayObjectBucketCreate(ctx context.Context, d *schema.ResourceD
 			},
 		})
 		if err != nil {
-			return diag.FromErr(err)
+			return diagFromErr(err)
 		}
 	}
 
@@ -145,9 +161,9 @@ func resourceScalewayObjectBucketCreate(ctx context.Context, d *schema.ResourceD
 }
 
 func resourceScalewayObjectBucketUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	s3Client, _, bucketName, err := s3ClientWithRegionAndName(meta, d.Id())
+	s3Client, _, bucketName, err := s3ClientWithRegionAndName(d, meta, d.Id())
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	if d.HasChange("acl") {
@@ -159,18 +175,18 @@ func resourceScalewayObjectBucketUpdate(ctx context.Context, d *schema.ResourceD
 		})
 		if err != nil {
 			l.Errorf("Couldn't update bucket ACL: %s", err)
-			return diag.FromErr(fmt.Errorf("couldn't update bucket ACL: %s", err))
+			return diagFromErr(fmt.Errorf("couldn't update bucket ACL: %s", err))
 		}
 	}
 
 	if d.HasChange("versioning") {
 		if err := resourceScalewayObjectBucketVersioningUpdate(ctx, s3Client, d); err != nil {
-			return diag.FromErr(err)
+			return diagFromErr(err)
 		}
 	}
 
-	if d.HasChange("tags") {
-		tagsSet := expandObjectBucketTags(d.Get("tags"))
+	if d.HasChanges("tags", "tags_all") {
+		tagsSet := expandObjectBucketTags(d.Get("tags"), meta.(*Meta).defaultTags)
 
 		if len(tagsSet) > 0 {
 			_, err = s3Client.PutBucketTaggingWithContext(ctx, &s3.PutBucketTaggingInput{
@@ -185,13 +201,13 @@ func resourceScalewayObjectBucketUpdate(ctx context.Context, d *schema.ResourceD
 			})
 		}
 		if err != nil {
-			return diag.FromErr(err)
+			return diagFromErr(err)
 		}
 	}
 
 	if d.HasChange("cors_rule") {
 		if err := resourceScalewayS3BucketCorsUpdate(ctx, s3Client, d); err != nil {
-			return diag.FromErr(err)
+			return diagFromErr(err)
 		}
 	}
 
@@ -199,9 +215,9 @@ func resourceScalewayObjectBucketUpdate(ctx context.Context, d *schema.ResourceD
 }
 
 func resourceScalewayObjectBucketRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	s3Client, region, bucketName, err := s3ClientWithRegionAndName(meta, d.Id())
+	s3Client, region, bucketName, err := s3ClientWithRegionAndName(d, meta, d.Id())
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	_ = d.Set("name", bucketName)
@@ -224,7 +240,7 @@ func resourceScalewayObjectBucketRead(ctx context.Context, d *schema.ResourceDat
 			d.SetId("")
 			return nil
 		}
-		return diag.FromErr(fmt.Errorf("couldn't read bucket: %s", err))
+		return diagFromErr(fmt.Errorf("couldn't read bucket: %s", err))
 	}
 
 	var tagsSet []*s3.Tag
@@ -234,13 +250,14 @@ func resourceScalewayObjectBucketRead(ctx context.Context, d *schema.ResourceDat
 	})
 	if err != nil {
 		if s3err, ok := err.(awserr.Error); !ok || s3err.Code() != "NoSuchTagSet" {
-			return diag.FromErr(fmt.Errorf("couldn't read tags from bucket: %s", err))
+			return diagFromErr(fmt.Errorf("couldn't read tags from bucket: %s", err))
 		}
 	} else {
 		tagsSet = tagsResponse.TagSet
 	}
 
-	_ = d.Set("tags", flattenObjectBucketTags(tagsSet))
+	_ = d.Set("tags", flattenObjectBucketTagsWithoutDefaults(tagsSet, meta.(*Meta).defaultTags, d.Get("tags")))
+	_ = d.Set("tags_all", flattenObjectBucketTags(tagsSet))
 
 	_ = d.Set("endpoint", objectBucketEndpointURL(bucketName, region))
 
@@ -250,7 +267,7 @@ func resourceScalewayObjectBucketRead(ctx context.Context, d *schema.ResourceDat
 	})
 
 	if err != nil && !isS3Err(err, "NoSuchCORSConfiguration", "") {
-		return diag.FromErr(fmt.Errorf("error getting S3 Bucket CORS configuration: %s", err))
+		return diagFromErr(fmt.Errorf("error getting S3 Bucket CORS configuration: %s", err))
 	}
 
 	_ = d.Set("cors_rule", flattenBucketCORS(corsResponse))
@@ -262,7 +279,7 @@ func resourceScalewayObjectBucketRead(ctx context.Context, d *schema.ResourceDat
 		Bucket: scw.StringPtr(bucketName),
 	})
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 	_ = d.Set("versioning", flattenObjectBucketVersioning(versioningResponse))
 
@@ -270,16 +287,16 @@ func resourceScalewayObjectBucketRead(ctx context.Context, d *schema.ResourceDat
 }
 
 func resourceScalewayObjectBucketDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	s3Client, _, bucketName, err := s3ClientWithRegionAndName(meta, d.Id())
+	s3Client, _, bucketName, err := s3ClientWithRegionAndName(d, meta, d.Id())
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	_, err = s3Client.DeleteBucketWithContext(ctx, &s3.DeleteBucketInput{
 		Bucket: scw.StringPtr(bucketName),
 	})
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	return nil
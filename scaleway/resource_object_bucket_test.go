@@ -1,7 +1,10 @@
 package scaleway
 
 import (
+	"context"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"reflect"
 	"strings"
 	"testing"
@@ -11,6 +14,8 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	"github.com/scaleway/scaleway-sdk-go/scw"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func init() {
@@ -136,7 +141,7 @@ func testAccCheckScalewayObjectBucketDestroy(tt *TestTools) resource.TestCheckFu
 }
 
 func testSweepStorageObjectBucket(_ string) error {
-	return sweepRegions([]scw.Region{scw.RegionFrPar, scw.RegionNlAms, scw.RegionPlWaw}, func(scwClient *scw.Client, region scw.Region) error {
+	return sweepAllRegions(func(scwClient *scw.Client, region scw.Region) error {
 		s3client, err := sharedS3ClientForRegion(region)
 		if err != nil {
 			return fmt.Errorf("error getting client: %s", err)
@@ -148,14 +153,29 @@ func testSweepStorageObjectBucket(_ string) error {
 		}
 
 		for _, bucket := range listBucketResponse.Buckets {
-			l.Debugf("Deleting %q bucket", *bucket.Name)
-			if strings.HasPrefix(*bucket.Name, "terraform-test") {
+			if !strings.HasPrefix(*bucket.Name, "terraform-test") {
+				continue
+			}
+
+			// Tags are formatted as key:value like the provider default tags.
+			tags := []string(nil)
+			tagging, err := s3client.GetBucketTagging(&s3.GetBucketTaggingInput{
+				Bucket: bucket.Name,
+			})
+			if err == nil {
+				for key, value := range flattenObjectBucketTags(tagging.TagSet) {
+					tags = append(tags, fmt.Sprintf("%s:%s", key, value))
+				}
+			}
+
+			err = sweep(&sweptResource{kind: "bucket", id: *bucket.Name, name: *bucket.Name, tags: tags, createdAt: bucket.CreationDate}, func() error {
 				_, err := s3client.DeleteBucket(&s3.DeleteBucketInput{
 					Bucket: bucket.Name,
 				})
-				if err != nil {
-					return fmt.Errorf("error deleting bucket in Sweeper: %s", err)
-				}
+				return err
+			})
+			if err != nil {
+				return fmt.Errorf("error deleting bucket in Sweeper: %s", err)
 			}
 		}
 
@@ -439,3 +459,65 @@ func testAccCheckScalewayObjectBucketExists(tt *TestTools, n string) resource.Te
 		return nil
 	}
 }
+
+func TestScalewayObjectBucket_FakeAPIS3Credentials(t *testing.T) {
+	defer unsetAWSCABundle(t)()
+
+	f := newFakeAPI(t)
+	defer f.close()
+	ctx := context.Background()
+
+	credentials := []string(nil)
+	s3Server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorization := r.Header.Get("Authorization")
+		credential := authorization[strings.Index(authorization, "Credential=")+len("Credential=") : strings.Index(authorization, "/")]
+		credentials = append(credentials, r.Method+" "+credential)
+	}))
+	defer s3Server.Close()
+
+	meta := f.metaWithConfig(map[string]interface{}{
+		"s3_access_key": "SCWS3XXXXXXXXXXXXXXX",
+		"s3_secret_key": "22222222-2222-2222-2222-222222222222",
+		"endpoints": []interface{}{
+			map[string]interface{}{"s3": s3Server.URL, "s3_force_path_style": true},
+		},
+	})
+
+	d := f.resourceData(resourceScalewayObjectBucket(), "", map[string]interface{}{
+		"name":       "my-bucket",
+		"project_id": "33333333-3333-3333-3333-333333333333",
+	})
+	require.False(t, resourceScalewayObjectBucketCreate(ctx, d, meta).HasError())
+	assert.Equal(t, "fr-par/my-bucket", d.Id())
+	require.NotEmpty(t, credentials)
+	assert.Equal(t, "PUT SCWS3XXXXXXXXXXXXXXX@33333333-3333-3333-3333-333333333333", credentials[0])
+	for _, credential := range credentials {
+		assert.Contains(t, credential, " SCWS3XXXXXXXXXXXXXXX@33333333-3333-3333-3333-333333333333")
+	}
+
+	// Without project_id, the buckets of the default project of the key are managed.
+	credentials = nil
+	d = f.resourceData(resourceScalewayObjectBucket(), "fr-par/my-bucket", map[string]interface{}{"name": "my-bucket"})
+	require.False(t, resourceScalewayObjectBucketDelete(ctx, d, meta).HasError())
+	assert.Equal(t, []string{"DELETE SCWS3XXXXXXXXXXXXXXX"}, credentials)
+
+	// Buckets of another project are imported with their project_id.
+	credentials = nil
+	d = f.resourceData(resourceScalewayObjectBucket(), "fr-par/my-bucket@33333333-3333-3333-3333-333333333333", map[string]interface{}{})
+	imported, err := importObjectBucket(ctx, d, meta)
+	require.NoError(t, err)
+	require.Len(t, imported, 1)
+	d = imported[0]
+	assert.Equal(t, "fr-par/my-bucket", d.Id())
+	require.False(t, resourceScalewayObjectBucketRead(ctx, d, meta).HasError())
+	assert.Equal(t, "fr-par/my-bucket", d.Id())
+	assert.Equal(t, "33333333-3333-3333-3333-333333333333", d.Get("project_id"))
+	require.NotEmpty(t, credentials)
+	for _, credential := range credentials {
+		assert.Contains(t, credential, " SCWS3XXXXXXXXXXXXXXX@33333333-3333-3333-3333-333333333333")
+	}
+
+	d = f.resourceData(resourceScalewayObjectBucket(), "fr-par/my-bucket@my-project", map[string]interface{}{})
+	_, err = importObjectBucket(ctx, d, meta)
+	assert.Error(t, err)
+}
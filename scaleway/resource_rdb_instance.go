@@ -4,9 +4,11 @@ import (
 	"context"
 	"fmt"
 	"io/ioutil"
+	"strings"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/scaleway/scaleway-sdk-go/api/rdb/v1"
@@ -14,17 +16,22 @@ import (
 )
 
 func resourceScalewayRdbInstance() *schema.Resource {
-	return &schema.Resource{
+	return withLocalizedIDsStateUpgrade(&schema.Resource{
 		CreateContext: resourceScalewayRdbInstanceCreate,
 		ReadContext:   resourceScalewayRdbInstanceRead,
 		UpdateContext: resourceScalewayRdbInstanceUpdate,
 		DeleteContext: resourceScalewayRdbInstanceDelete,
 		Timeouts: &schema.ResourceTimeout{
 			Default: schema.DefaultTimeout(defaultRdbInstanceTimeout),
+			Update:  schema.DefaultTimeout(defaultRdbInstanceUpdateTimeout),
 		},
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importStateByName("database instance", rdbInstanceIDsByName),
 		},
+		CustomizeDiff: customdiff.All(
+			customizeDiffCatalogType("node_type", "node type", strings.ToLower, rdbNodeTypeNames),
+			customizeDiffTagsAll,
+		),
 		SchemaVersion: 0,
 		Schema: map[string]*schema.Schema{
 			"name": {
@@ -85,6 +92,7 @@ func resourceScalewayRdbInstance() *schema.Resource {
 				Optional:    true,
 				Description: "List of tags [\"tag1\", \"tag2\", ...] attached to a database instance",
 			},
+			"tags_all": tagsAllSchema(),
 			"volume_type": {
 				Type:     schema.TypeString,
 				Default:  rdb.VolumeTypeLssd,
@@ -147,13 +155,15 @@ func resourceScalewayRdbInstance() *schema.Resource {
 			"organization_id": organizationIDSchema(),
 			"project_id":      projectIDSchema(),
 		},
-	}
+	}, map[string]string{
+		"id": "region",
+	})
 }
 
 func resourceScalewayRdbInstanceCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	rdbAPI, region, err := rdbAPIWithRegion(d, meta)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	createReq := &rdb.CreateInstanceRequest{
@@ -166,20 +176,20 @@ func resourceScalewayRdbInstanceCreate(ctx context.Context, d *schema.ResourceDa
 		DisableBackup: d.Get("disable_backup").(bool),
 		UserName:      d.Get("user_name").(string),
 		Password:      d.Get("password").(string),
-		Tags:          expandStrings(d.Get("tags")),
+		Tags:          expandTags(meta, d.Get("tags")),
 		VolumeType:    rdb.VolumeType(d.Get("volume_type").(string)),
 	}
 
 	if size, ok := d.GetOk("volume_size_in_gb"); ok {
 		if createReq.VolumeType != rdb.VolumeTypeBssd {
-			return diag.FromErr(fmt.Errorf("volume_size_in_gb should be used with volume_type %s only", rdb.VolumeTypeBssd.String()))
+			return diagFromErr(fmt.Errorf("volume_size_in_gb should be used with volume_type %s only", rdb.VolumeTypeBssd.String()))
 		}
 		createReq.VolumeSize = scw.Size(uint64(size.(int)) * uint64(scw.GB))
 	}
 
 	res, err := rdbAPI.CreateInstance(createReq, scw.WithContext(ctx))
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	d.SetId(newRegionalIDString(region, res.ID))
@@ -187,10 +197,10 @@ func resourceScalewayRdbInstanceCreate(ctx context.Context, d *schema.ResourceDa
 	_, err = rdbAPI.WaitForInstance(&rdb.WaitForInstanceRequest{
 		Region:     region,
 		InstanceID: res.ID,
-		Timeout:    scw.TimeDurationPtr(defaultInstanceServerWaitTimeout),
+		Timeout:    scw.TimeDurationPtr(d.Timeout(schema.TimeoutCreate)),
 	}, scw.WithContext(ctx))
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	if settings, ok := d.GetOk("settings"); ok {
@@ -200,7 +210,7 @@ func resourceScalewayRdbInstanceCreate(ctx context.Context, d *schema.ResourceDa
 			Settings:   expandInstanceSettings(settings),
 		})
 		if err != nil {
-			return diag.FromErr(err)
+			return diagFromErr(err)
 		}
 	}
 
@@ -210,7 +220,7 @@ func resourceScalewayRdbInstanceCreate(ctx context.Context, d *schema.ResourceDa
 func resourceScalewayRdbInstanceRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	rdbAPI, region, ID, err := rdbAPIWithRegionAndID(meta, d.Id())
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	res, err := rdbAPI.GetInstance(&rdb.GetInstanceRequest{
@@ -222,7 +232,7 @@ func resourceScalewayRdbInstanceRead(ctx context.Context, d *schema.ResourceData
 			d.SetId("")
 			return nil
 		}
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	_ = d.Set("name", res.Name)
@@ -232,7 +242,8 @@ func resourceScalewayRdbInstanceRead(ctx context.Context, d *schema.ResourceData
 	_ = d.Set("disable_backup", res.BackupSchedule.Disabled)
 	_ = d.Set("user_name", d.Get("user_name").(string)) // user name and
 	_ = d.Set("password", d.Get("password").(string))   // password are immutable
-	_ = d.Set("tags", res.Tags)
+	_ = d.Set("tags", flattenTags(meta, res.Tags, d.Get("tags")))
+	_ = d.Set("tags_all", res.Tags)
 	if res.Endpoint != nil {
 		_ = d.Set("endpoint_ip", flattenIPPtr(res.Endpoint.IP))
 		_ = d.Set("endpoint_port", int(res.Endpoint.Port))
@@ -255,11 +266,11 @@ func resourceScalewayRdbInstanceRead(ctx context.Context, d *schema.ResourceData
 		InstanceID: ID,
 	})
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 	certContent, err := ioutil.ReadAll(cert.Content)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 	_ = d.Set("certificate", string(certContent))
 
@@ -272,7 +283,7 @@ func resourceScalewayRdbInstanceRead(ctx context.Context, d *schema.ResourceData
 func resourceScalewayRdbInstanceUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	rdbAPI, region, ID, err := rdbAPIWithRegionAndID(meta, d.Id())
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	req := &rdb.UpdateInstanceRequest{
@@ -287,13 +298,13 @@ func resourceScalewayRdbInstanceUpdate(ctx context.Context, d *schema.ResourceDa
 		req.IsBackupScheduleDisabled = scw.BoolPtr(d.Get("disable_backup").(bool))
 	}
 
-	if d.HasChange("tags") {
-		req.Tags = scw.StringsPtr(expandStrings(d.Get("tags")))
+	if d.HasChanges("tags", "tags_all") {
+		req.Tags = scw.StringsPtr(expandTags(meta, d.Get("tags")))
 	}
 
 	_, err = rdbAPI.UpdateInstance(req, scw.WithContext(ctx))
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	// Change settings
@@ -304,7 +315,7 @@ func resourceScalewayRdbInstanceUpdate(ctx context.Context, d *schema.ResourceDa
 			Settings:   expandInstanceSettings(d.Get("settings")),
 		}, scw.WithContext(ctx))
 		if err != nil {
-			return diag.FromErr(err)
+			return diagFromErr(err)
 		}
 	}
 
@@ -327,11 +338,11 @@ func resourceScalewayRdbInstanceUpdate(ctx context.Context, d *schema.ResourceDa
 				oldSize := uint64(oldSizeInterface.(int))
 				newSize := uint64(newSizeInterface.(int))
 				if newSize < oldSize {
-					return diag.FromErr(fmt.Errorf("volume_size_in_gb cannot be decreased"))
+					return diagFromErr(fmt.Errorf("volume_size_in_gb cannot be decreased"))
 				}
 
 				if newSize%5 != 0 {
-					return diag.FromErr(fmt.Errorf("volume_size_in_gb must be a multiple of 5"))
+					return diagFromErr(fmt.Errorf("volume_size_in_gb must be a multiple of 5"))
 				}
 
 				upgradeInstanceRequests = append(upgradeInstanceRequests,
@@ -344,7 +355,7 @@ func resourceScalewayRdbInstanceUpdate(ctx context.Context, d *schema.ResourceDa
 		case rdb.VolumeTypeLssd:
 			_, ok := d.GetOk("volume_size_in_gb")
 			if d.HasChange("volume_size_in_gb") && ok {
-				return diag.FromErr(fmt.Errorf("volume_size_in_gb should be used with volume_type %s only", rdb.VolumeTypeBssd.String()))
+				return diagFromErr(fmt.Errorf("volume_size_in_gb should be used with volume_type %s only", rdb.VolumeTypeBssd.String()))
 			}
 			if d.HasChange("volume_type") {
 				upgradeInstanceRequests = append(upgradeInstanceRequests,
@@ -355,7 +366,7 @@ func resourceScalewayRdbInstanceUpdate(ctx context.Context, d *schema.ResourceDa
 					})
 			}
 		default:
-			return diag.FromErr(fmt.Errorf("unknown volume_type %s", volType.String()))
+			return diagFromErr(fmt.Errorf("unknown volume_type %s", volType.String()))
 		}
 	}
 
@@ -379,16 +390,16 @@ func resourceScalewayRdbInstanceUpdate(ctx context.Context, d *schema.ResourceDa
 	for _, request := range upgradeInstanceRequests {
 		_, err = rdbAPI.UpgradeInstance(&request, scw.WithContext(ctx))
 		if err != nil {
-			return diag.FromErr(err)
+			return diagFromErr(err)
 		}
 
 		_, err = rdbAPI.WaitForInstance(&rdb.WaitForInstanceRequest{
 			Region:     region,
 			InstanceID: ID,
-			Timeout:    scw.TimeDurationPtr(defaultInstanceServerWaitTimeout * 3), // upgrade takes some time
+			Timeout:    scw.TimeDurationPtr(d.Timeout(schema.TimeoutUpdate)),
 		}, scw.WithContext(ctx))
 		if err != nil {
-			return diag.FromErr(err)
+			return diagFromErr(err)
 		}
 
 		// Wait for the instance to settle after upgrading
@@ -405,7 +416,7 @@ func resourceScalewayRdbInstanceUpdate(ctx context.Context, d *schema.ResourceDa
 
 		_, err = rdbAPI.UpdateUser(req, scw.WithContext(ctx))
 		if err != nil {
-			return diag.FromErr(err)
+			return diagFromErr(err)
 		}
 	}
 
@@ -415,18 +426,18 @@ func resourceScalewayRdbInstanceUpdate(ctx context.Context, d *schema.ResourceDa
 func resourceScalewayRdbInstanceDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	rdbAPI, region, ID, err := rdbAPIWithRegionAndID(meta, d.Id())
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	// We first wait in case the instance is in a transient state
 	_, err = rdbAPI.WaitForInstance(&rdb.WaitForInstanceRequest{
 		InstanceID: ID,
 		Region:     region,
-		Timeout:    scw.TimeDurationPtr(LbWaitForTimeout),
+		Timeout:    scw.TimeDurationPtr(d.Timeout(schema.TimeoutDelete)),
 	}, scw.WithContext(ctx))
 
 	if err != nil && !is404Error(err) {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	_, err = rdbAPI.DeleteInstance(&rdb.DeleteInstanceRequest{
@@ -435,17 +446,17 @@ func resourceScalewayRdbInstanceDelete(ctx context.Context, d *schema.ResourceDa
 	}, scw.WithContext(ctx))
 
 	if err != nil && !is404Error(err) {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	_, err = rdbAPI.WaitForInstance(&rdb.WaitForInstanceRequest{
 		InstanceID: ID,
 		Region:     region,
-		Timeout:    scw.TimeDurationPtr(LbWaitForTimeout),
+		Timeout:    scw.TimeDurationPtr(d.Timeout(schema.TimeoutDelete)),
 	}, scw.WithContext(ctx))
 
 	if err != nil && !is404Error(err) {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	return nil
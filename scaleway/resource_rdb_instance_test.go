@@ -1,13 +1,17 @@
 package scaleway
 
 import (
+	"context"
 	"fmt"
+	"net/http"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	"github.com/scaleway/scaleway-sdk-go/api/rdb/v1"
 	"github.com/scaleway/scaleway-sdk-go/scw"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func init() {
@@ -18,17 +22,23 @@ func init() {
 }
 
 func testSweepRDBInstance(_ string) error {
-	return sweepRegions(scw.AllRegions, func(scwClient *scw.Client, region scw.Region) error {
+	return sweepAllRegions(func(scwClient *scw.Client, region scw.Region) error {
 		rdbAPI := rdb.NewAPI(scwClient)
 		l.Debugf("sweeper: destroying the rdb instance in (%s)", region)
-		listInstances, err := rdbAPI.ListInstances(&rdb.ListInstancesRequest{}, scw.WithAllPages())
+		listInstances, err := rdbAPI.ListInstances(&rdb.ListInstancesRequest{
+			Region: region,
+		}, scw.WithAllPages())
 		if err != nil {
 			return fmt.Errorf("error listing rdb instances in (%s) in sweeper: %s", region, err)
 		}
 
 		for _, instance := range listInstances.Instances {
-			_, err := rdbAPI.DeleteInstance(&rdb.DeleteInstanceRequest{
-				InstanceID: instance.ID,
+			err := sweep(&sweptResource{kind: "rdb instance", id: instance.ID, name: instance.Name, tags: instance.Tags, createdAt: instance.CreatedAt}, func() error {
+				_, err := rdbAPI.DeleteInstance(&rdb.DeleteInstanceRequest{
+					Region:     region,
+					InstanceID: instance.ID,
+				})
+				return err
 			})
 			if err != nil {
 				return fmt.Errorf("error deleting rdb instance in sweeper: %s", err)
@@ -257,3 +267,52 @@ func testAccCheckScalewayRdbInstanceDestroy(tt *TestTools) resource.TestCheckFun
 		return nil
 	}
 }
+
+func TestScalewayRdbInstance_FakeAPITransientStates(t *testing.T) {
+	f := newFakeAPI(t)
+	defer f.close()
+	meta := f.meta()
+	ctx := context.Background()
+
+	instance := f.seed("rdb", "fr-par", "instances", map[string]interface{}{
+		"name":      "rdb",
+		"engine":    "PostgreSQL-12",
+		"node_type": "db-dev-s",
+	})
+	instanceID := instance["id"].(string)
+	d := f.resourceData(resourceScalewayRdbInstance(), newRegionalIDString("fr-par", instanceID), map[string]interface{}{})
+
+	require.False(t, resourceScalewayRdbInstanceRead(ctx, d, meta).HasError())
+	assert.Equal(t, "rdb", d.Get("name"))
+	assert.Equal(t, "51.159.0.2", d.Get("endpoint_ip"))
+
+	// Delete must wait for the backup to end before deleting the instance, then wait for the deletion.
+	f.update("rdb", "fr-par", "instances", instanceID, map[string]interface{}{"status": "backuping"})
+	require.False(t, resourceScalewayRdbInstanceDelete(ctx, d, meta).HasError())
+	assert.Nil(t, f.lookup("rdb", "fr-par", "instances", instanceID))
+	assert.Equal(t, []string{
+		"GET /rdb/v1/regions/fr-par/instances/" + instanceID,
+		"GET /rdb/v1/regions/fr-par/instances/" + instanceID + "/certificate",
+		"GET /rdb/v1/regions/fr-par/instances/" + instanceID,
+		"DELETE /rdb/v1/regions/fr-par/instances/" + instanceID,
+		"GET /rdb/v1/regions/fr-par/instances/" + instanceID,
+	}, f.receivedRequests())
+}
+
+func TestScalewayRdbInstance_FakeAPIPlanNodeType(t *testing.T) {
+	f := newFakeAPI(t)
+	defer f.close()
+	meta := f.meta()
+
+	f.respond(http.MethodGet, "/rdb/v1/regions/fr-par/node-types", http.StatusOK, map[string]interface{}{
+		"node_types":  []interface{}{map[string]interface{}{"name": "db-dev-s"}, map[string]interface{}{"name": "db-dev-m", "disabled": true}},
+		"total_count": 2,
+	}, false)
+
+	// Disabled node types are still valid.
+	require.NoError(t, f.plan(meta, resourceScalewayRdbInstance(), map[string]interface{}{"node_type": "DB-DEV-M", "engine": "PostgreSQL-12"}))
+
+	err := f.plan(meta, resourceScalewayRdbInstance(), map[string]interface{}{"node_type": "db-dev-xs", "engine": "PostgreSQL-12"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `unknown node type "db-dev-xs", did you mean db-dev-s, db-dev-m?`)
+}
@@ -12,7 +12,7 @@ import (
 )
 
 func resourceScalewayRdbUser() *schema.Resource {
-	return &schema.Resource{
+	return withLocalizedIDsStateUpgrade(&schema.Resource{
 		CreateContext: resourceScalewayRdbUserCreate,
 		ReadContext:   resourceScalewayRdbUserRead,
 		UpdateContext: resourceScalewayRdbUserUpdate,
@@ -23,6 +23,7 @@ func resourceScalewayRdbUser() *schema.Resource {
 		Timeouts: &schema.ResourceTimeout{
 			Default: schema.DefaultTimeout(defaultRdbInstanceTimeout),
 		},
+		CustomizeDiff: customizeDiffLocalityCheck("region", "instance_id"),
 		SchemaVersion: 0,
 		Schema: map[string]*schema.Schema{
 			"instance_id": {
@@ -52,13 +53,15 @@ func resourceScalewayRdbUser() *schema.Resource {
 			// Common
 			"region": regionSchema(),
 		},
-	}
+	}, map[string]string{
+		"instance_id": "region",
+	})
 }
 
 func resourceScalewayRdbUserCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	rdbAPI, region, err := rdbAPIWithRegion(d, meta)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 	instanceID := d.Get("instance_id").(string)
 	createReq := &rdb.CreateUserRequest{
@@ -71,7 +74,7 @@ func resourceScalewayRdbUserCreate(ctx context.Context, d *schema.ResourceData,
 
 	res, err := rdbAPI.CreateUser(createReq, scw.WithContext(ctx))
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	d.SetId(resourceScalewayRdbUserID(region, expandID(instanceID), res.Name))
@@ -82,13 +85,13 @@ func resourceScalewayRdbUserCreate(ctx context.Context, d *schema.ResourceData,
 func resourceScalewayRdbUserRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	rdbAPI, region, err := rdbAPIWithRegion(d, meta)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	instanceID, userName, err := resourceScalewayRdbUserParseID(d.Id())
 
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	res, err := rdbAPI.ListUsers(&rdb.ListUsersRequest{
@@ -102,7 +105,7 @@ func resourceScalewayRdbUserRead(ctx context.Context, d *schema.ResourceData, me
 			d.SetId("")
 			return nil
 		}
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	var user = res.Users[0]
@@ -118,13 +121,13 @@ func resourceScalewayRdbUserRead(ctx context.Context, d *schema.ResourceData, me
 func resourceScalewayRdbUserUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	rdbAPI, region, err := rdbAPIWithRegion(d, meta)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	instanceID, userName, err := resourceScalewayRdbUserParseID(d.Id())
 
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	req := &rdb.UpdateUserRequest{
@@ -142,7 +145,7 @@ func resourceScalewayRdbUserUpdate(ctx context.Context, d *schema.ResourceData,
 
 	_, err = rdbAPI.UpdateUser(req, scw.WithContext(ctx))
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	return resourceScalewayRdbUserRead(ctx, d, meta)
@@ -151,13 +154,13 @@ func resourceScalewayRdbUserUpdate(ctx context.Context, d *schema.ResourceData,
 func resourceScalewayRdbUserDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	rdbAPI, region, err := rdbAPIWithRegion(d, meta)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	instanceID, userName, err := resourceScalewayRdbUserParseID(d.Id())
 
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	err = rdbAPI.DeleteUser(&rdb.DeleteUserRequest{
@@ -167,7 +170,7 @@ func resourceScalewayRdbUserDelete(ctx context.Context, d *schema.ResourceData,
 	}, scw.WithContext(ctx))
 
 	if err != nil && !is404Error(err) {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	return nil
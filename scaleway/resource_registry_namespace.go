@@ -10,13 +10,13 @@ import (
 )
 
 func resourceScalewayRegistryNamespace() *schema.Resource {
-	return &schema.Resource{
+	return withLocalizedIDsStateUpgrade(&schema.Resource{
 		CreateContext: resourceScalewayRegistryNamespaceCreate,
 		ReadContext:   resourceScalewayRegistryNamespaceRead,
 		UpdateContext: resourceScalewayRegistryNamespaceUpdate,
 		DeleteContext: resourceScalewayRegistryNamespaceDelete,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importStateByName("registry namespace", registryNamespaceIDsByName),
 		},
 		Timeouts: &schema.ResourceTimeout{
 			Default: schema.DefaultTimeout(defaultRegistryNamespaceTimeout),
@@ -48,13 +48,15 @@ func resourceScalewayRegistryNamespace() *schema.Resource {
 			"organization_id": organizationIDSchema(),
 			"project_id":      projectIDSchema(),
 		},
-	}
+	}, map[string]string{
+		"id": "region",
+	})
 }
 
 func resourceScalewayRegistryNamespaceCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	api, region, err := registryAPIWithRegion(d, meta)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	ns, err := api.CreateNamespace(&registry.CreateNamespaceRequest{
@@ -65,7 +67,7 @@ func resourceScalewayRegistryNamespaceCreate(ctx context.Context, d *schema.Reso
 		IsPublic:    d.Get("is_public").(bool),
 	}, scw.WithContext(ctx))
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	d.SetId(newRegionalIDString(region, ns.ID))
@@ -76,7 +78,7 @@ func resourceScalewayRegistryNamespaceCreate(ctx context.Context, d *schema.Reso
 func resourceScalewayRegistryNamespaceRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	api, region, id, err := registryAPIWithRegionAndID(meta, d.Id())
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	ns, err := api.GetNamespace(&registry.GetNamespaceRequest{
@@ -89,7 +91,7 @@ func resourceScalewayRegistryNamespaceRead(ctx context.Context, d *schema.Resour
 			d.SetId("")
 			return nil
 		}
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	_ = d.Set("name", ns.Name)
@@ -106,7 +108,7 @@ func resourceScalewayRegistryNamespaceRead(ctx context.Context, d *schema.Resour
 func resourceScalewayRegistryNamespaceUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	api, region, id, err := registryAPIWithRegionAndID(meta, d.Id())
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	if d.HasChanges("description", "is_public") {
@@ -116,7 +118,7 @@ func resourceScalewayRegistryNamespaceUpdate(ctx context.Context, d *schema.Reso
 			Description: expandStringPtr(d.Get("description")),
 			IsPublic:    scw.BoolPtr(d.Get("is_public").(bool)),
 		}, scw.WithContext(ctx)); err != nil {
-			return diag.FromErr(err)
+			return diagFromErr(err)
 		}
 	}
 
@@ -126,7 +128,7 @@ func resourceScalewayRegistryNamespaceUpdate(ctx context.Context, d *schema.Reso
 func resourceScalewayRegistryNamespaceDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	api, region, id, err := registryAPIWithRegionAndID(meta, d.Id())
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	_, err = api.DeleteNamespace(&registry.DeleteNamespaceRequest{
@@ -135,7 +137,7 @@ func resourceScalewayRegistryNamespaceDelete(ctx context.Context, d *schema.Reso
 	}, scw.WithContext(ctx))
 
 	if err != nil && !is404Error(err) {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	return nil
@@ -21,14 +21,20 @@ func testSweepRegistryNamespace(_ string) error {
 	return sweepRegions([]scw.Region{scw.RegionFrPar, scw.RegionNlAms}, func(scwClient *scw.Client, region scw.Region) error {
 		registryAPI := registry.NewAPI(scwClient)
 		l.Debugf("sweeper: destroying the registry namespaces in (%s)", region)
-		listNamespaces, err := registryAPI.ListNamespaces(&registry.ListNamespacesRequest{}, scw.WithAllPages())
+		listNamespaces, err := registryAPI.ListNamespaces(&registry.ListNamespacesRequest{
+			Region: region,
+		}, scw.WithAllPages())
 		if err != nil {
 			return fmt.Errorf("error listing namespaces in (%s) in sweeper: %s", region, err)
 		}
 
 		for _, ns := range listNamespaces.Namespaces {
-			_, err := registryAPI.DeleteNamespace(&registry.DeleteNamespaceRequest{
-				NamespaceID: ns.ID,
+			err := sweep(&sweptResource{kind: "registry namespace", id: ns.ID, name: ns.Name, createdAt: ns.CreatedAt}, func() error {
+				_, err := registryAPI.DeleteNamespace(&registry.DeleteNamespaceRequest{
+					Region:      region,
+					NamespaceID: ns.ID,
+				})
+				return err
 			})
 			if err != nil {
 				return fmt.Errorf("error deleting namespace in sweeper: %s", err)
@@ -11,14 +11,18 @@ import (
 )
 
 func resourceScalewayVPCPrivateNetwork() *schema.Resource {
-	return &schema.Resource{
+	return withLocalizedIDsStateUpgrade(&schema.Resource{
 		CreateContext: resourceScalewayVPCPrivateNetworkCreate,
 		ReadContext:   resourceScalewayVPCPrivateNetworkRead,
 		UpdateContext: resourceScalewayVPCPrivateNetworkUpdate,
 		DeleteContext: resourceScalewayVPCPrivateNetworkDelete,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importStateByName("private network", vpcPrivateNetworkIDsByName),
 		},
+		Timeouts: &schema.ResourceTimeout{
+			Default: schema.DefaultTimeout(defaultVPCPrivateNetworkTimeout),
+		},
+		CustomizeDiff: customizeDiffTagsAll,
 		SchemaVersion: 0,
 		Schema: map[string]*schema.Schema{
 			"name": {
@@ -34,6 +38,7 @@ func resourceScalewayVPCPrivateNetwork() *schema.Resource {
 					Type: schema.TypeString,
 				},
 			},
+			"tags_all":   tagsAllSchema(),
 			"project_id": projectIDSchema(),
 			"zone":       zoneSchema(),
 			// Computed elements
@@ -49,23 +54,25 @@ func resourceScalewayVPCPrivateNetwork() *schema.Resource {
 				Description: "The date and time of the last update of the private network",
 			},
 		},
-	}
+	}, map[string]string{
+		"id": "zone",
+	})
 }
 
 func resourceScalewayVPCPrivateNetworkCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	vpcAPI, zone, err := vpcAPIWithZone(d, meta)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	res, err := vpcAPI.CreatePrivateNetwork(&vpc.CreatePrivateNetworkRequest{
 		Name:      expandOrGenerateString(d.Get("name"), "pn"),
-		Tags:      expandStrings(d.Get("tags")),
+		Tags:      expandTags(meta, d.Get("tags")),
 		ProjectID: d.Get("project_id").(string),
 		Zone:      zone,
 	}, scw.WithContext(ctx))
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	d.SetId(newZonedIDString(zone, res.ID))
@@ -76,7 +83,7 @@ func resourceScalewayVPCPrivateNetworkCreate(ctx context.Context, d *schema.Reso
 func resourceScalewayVPCPrivateNetworkRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	vpcAPI, zone, ID, err := vpcAPIWithZoneAndID(meta, d.Id())
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	pn, err := vpcAPI.GetPrivateNetwork(&vpc.GetPrivateNetworkRequest{
@@ -88,7 +95,7 @@ func resourceScalewayVPCPrivateNetworkRead(ctx context.Context, d *schema.Resour
 			d.SetId("")
 			return nil
 		}
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	_ = d.Set("name", pn.Name)
@@ -97,7 +104,8 @@ func resourceScalewayVPCPrivateNetworkRead(ctx context.Context, d *schema.Resour
 	_ = d.Set("created_at", pn.CreatedAt.Format(time.RFC3339))
 	_ = d.Set("updated_at", pn.UpdatedAt.Format(time.RFC3339))
 	_ = d.Set("zone", zone)
-	_ = d.Set("tags", pn.Tags)
+	_ = d.Set("tags", flattenTags(meta, pn.Tags, d.Get("tags")))
+	_ = d.Set("tags_all", pn.Tags)
 
 	return nil
 }
@@ -105,20 +113,20 @@ func resourceScalewayVPCPrivateNetworkRead(ctx context.Context, d *schema.Resour
 func resourceScalewayVPCPrivateNetworkUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	vpcAPI, zone, ID, err := vpcAPIWithZoneAndID(meta, d.Id())
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
-	if d.HasChanges("name", "tags") {
+	if d.HasChanges("name", "tags", "tags_all") {
 		updateRequest := &vpc.UpdatePrivateNetworkRequest{
 			PrivateNetworkID: ID,
 			Zone:             zone,
 			Name:             scw.StringPtr(d.Get("name").(string)),
-			Tags:             scw.StringsPtr(expandStrings(d.Get("tags"))),
+			Tags:             scw.StringsPtr(expandTags(meta, d.Get("tags"))),
 		}
 
 		_, err = vpcAPI.UpdatePrivateNetwork(updateRequest, scw.WithContext(ctx))
 		if err != nil {
-			return diag.FromErr(err)
+			return diagFromErr(err)
 		}
 	}
 
@@ -128,7 +136,7 @@ func resourceScalewayVPCPrivateNetworkUpdate(ctx context.Context, d *schema.Reso
 func resourceScalewayVPCPrivateNetworkDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	vpcAPI, zone, ID, err := vpcAPIWithZoneAndID(meta, d.Id())
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	err = vpcAPI.DeletePrivateNetwork(&vpc.DeletePrivateNetworkRequest{
@@ -137,7 +145,7 @@ func resourceScalewayVPCPrivateNetworkDelete(ctx context.Context, d *schema.Reso
 	}, scw.WithContext(ctx))
 
 	if err != nil && !is404Error(err) {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	return nil
@@ -1,24 +1,31 @@
 package scaleway
 
 import (
+	"context"
 	"fmt"
+	"net/http"
+	"strings"
 	"testing"
 
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	"github.com/scaleway/scaleway-sdk-go/api/vpc/v1"
 	"github.com/scaleway/scaleway-sdk-go/scw"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func init() {
 	resource.AddTestSweepers("scaleway_vpc", &resource.Sweeper{
-		Name: "scaleway_vpc",
-		F:    testSweepVPCPrivateNetwork,
+		Name:         "scaleway_vpc",
+		F:            testSweepVPCPrivateNetwork,
+		Dependencies: []string{"scaleway_instance_server"},
 	})
 }
 
 func testSweepVPCPrivateNetwork(_ string) error {
-	return sweepZones(scw.AllZones, func(scwClient *scw.Client, zone scw.Zone) error {
+	return sweepAllZones(func(scwClient *scw.Client, zone scw.Zone) error {
 		vpcAPI := vpc.NewAPI(scwClient)
 		l.Debugf("sweeper: destroying the private network in (%s)", zone)
 
@@ -30,9 +37,11 @@ func testSweepVPCPrivateNetwork(_ string) error {
 		}
 
 		for _, pn := range listPNResponse.PrivateNetworks {
-			err := vpcAPI.DeletePrivateNetwork(&vpc.DeletePrivateNetworkRequest{
-				Zone:             zone,
-				PrivateNetworkID: pn.ID,
+			err := sweep(&sweptResource{kind: "private network", id: pn.ID, name: pn.Name, tags: pn.Tags, createdAt: pn.CreatedAt}, func() error {
+				return vpcAPI.DeletePrivateNetwork(&vpc.DeletePrivateNetworkRequest{
+					Zone:             zone,
+					PrivateNetworkID: pn.ID,
+				})
 			})
 			if err != nil {
 				return fmt.Errorf("error deleting private network in sweeper: %s", err)
@@ -155,3 +164,212 @@ func testAccCheckScalewayVPCPrivateNetworkDestroy(tt *TestTools) resource.TestCh
 		return nil
 	}
 }
+
+func TestScalewayVPCPrivateNetwork_FakeAPI(t *testing.T) {
+	f := newFakeAPI(t)
+	defer f.close()
+	meta := f.meta()
+	ctx := context.Background()
+
+	d := f.resourceData(resourceScalewayVPCPrivateNetwork(), "", map[string]interface{}{
+		"name": "pn",
+		"tags": []interface{}{"foo"},
+	})
+	require.False(t, resourceScalewayVPCPrivateNetworkCreate(ctx, d, meta).HasError())
+	assert.Equal(t, "pn", d.Get("name"))
+	assert.Equal(t, fakeAPIProjectID, d.Get("project_id"))
+	assert.Equal(t, "fr-par-1", d.Get("zone"))
+
+	zonedID := expandZonedID(d.Id())
+	assert.Equal(t, "pn", f.lookup("vpc", "fr-par-1", "private-networks", zonedID.ID)["name"])
+
+	require.NoError(t, d.Set("name", "pn-renamed"))
+	require.False(t, resourceScalewayVPCPrivateNetworkUpdate(ctx, d, meta).HasError())
+	assert.Equal(t, "pn-renamed", f.lookup("vpc", "fr-par-1", "private-networks", zonedID.ID)["name"])
+
+	require.False(t, resourceScalewayVPCPrivateNetworkDelete(ctx, d, meta).HasError())
+	assert.Nil(t, f.lookup("vpc", "fr-par-1", "private-networks", zonedID.ID))
+}
+
+func TestScalewayVPCPrivateNetwork_FakeAPIDefaultTags(t *testing.T) {
+	f := newFakeAPI(t)
+	defer f.close()
+	ctx := context.Background()
+	metaWithDefaultTags := func(tags ...interface{}) *Meta {
+		return f.metaWithConfig(map[string]interface{}{
+			"default_tags": []interface{}{map[string]interface{}{"tags": tags}},
+		})
+	}
+	config := map[string]interface{}{
+		"name": "pn",
+		"tags": []interface{}{"web"},
+	}
+
+	meta := metaWithDefaultTags("env:prod")
+	pn := f.seed("vpc", "fr-par-1", "private-networks", map[string]interface{}{"name": "pn", "tags": []interface{}{"web", "env:prod"}})
+	d := f.resourceData(resourceScalewayVPCPrivateNetwork(), newZonedIDString("fr-par-1", pn["id"].(string)), map[string]interface{}{})
+	require.False(t, resourceScalewayVPCPrivateNetworkRead(ctx, d, meta).HasError())
+	assert.Equal(t, []interface{}{"web"}, d.Get("tags"))
+	assert.Equal(t, []interface{}{"web", "env:prod"}, d.Get("tags_all"))
+	diff, err := f.planUpdate(meta, resourceScalewayVPCPrivateNetwork(), d, config)
+	require.NoError(t, err)
+	assert.True(t, diff.Empty())
+
+	// A new default tag is planned and applied to the existing resource.
+	meta = metaWithDefaultTags("env:prod", "team:x")
+	require.False(t, resourceScalewayVPCPrivateNetworkRead(ctx, d, meta).HasError())
+	diff, err = f.planUpdate(meta, resourceScalewayVPCPrivateNetwork(), d, config)
+	require.NoError(t, err)
+	assert.False(t, diff.Empty())
+	assert.False(t, diff.RequiresNew())
+
+	state, err := f.applyUpdate(meta, resourceScalewayVPCPrivateNetwork(), d, config)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []interface{}{"web", "env:prod", "team:x"}, f.lookup("vpc", "fr-par-1", "private-networks", pn["id"].(string))["tags"])
+	d = resourceScalewayVPCPrivateNetwork().Data(state)
+	assert.Equal(t, []interface{}{"web"}, d.Get("tags"))
+
+	diff, err = f.planUpdate(meta, resourceScalewayVPCPrivateNetwork(), d, config)
+	require.NoError(t, err)
+	assert.True(t, diff.Empty())
+}
+
+func TestScalewayVPCPrivateNetwork_FakeAPIDeletedOutside(t *testing.T) {
+	f := newFakeAPI(t)
+	defer f.close()
+	meta := f.meta()
+	ctx := context.Background()
+
+	pn := f.seed("vpc", "fr-par-1", "private-networks", map[string]interface{}{"name": "pn"})
+	d := f.resourceData(resourceScalewayVPCPrivateNetwork(), newZonedIDString("fr-par-1", pn["id"].(string)), map[string]interface{}{})
+	require.False(t, resourceScalewayVPCPrivateNetworkRead(ctx, d, meta).HasError())
+	assert.Equal(t, "pn", d.Get("name"))
+
+	f.remove("vpc", "fr-par-1", "private-networks", pn["id"].(string))
+	require.False(t, resourceScalewayVPCPrivateNetworkRead(ctx, d, meta).HasError())
+	assert.Empty(t, d.Id())
+
+	// Deleting a resource already deleted is not an error.
+	d.SetId(newZonedIDString("fr-par-1", pn["id"].(string)))
+	require.False(t, resourceScalewayVPCPrivateNetworkDelete(ctx, d, meta).HasError())
+}
+
+func TestScalewayVPCPrivateNetwork_FakeAPIImportByName(t *testing.T) {
+	f := newFakeAPI(t)
+	defer f.close()
+	meta := f.meta()
+	ctx := context.Background()
+
+	pn := f.seed("vpc", "fr-par-1", "private-networks", map[string]interface{}{"name": "pn"})
+	f.seed("vpc", "fr-par-1", "private-networks", map[string]interface{}{"name": "pn-other"})
+	f.seed("vpc", "fr-par-1", "private-networks", map[string]interface{}{"name": "duplicated"})
+	f.seed("vpc", "fr-par-1", "private-networks", map[string]interface{}{"name": "duplicated"})
+	importer := resourceScalewayVPCPrivateNetwork().Importer.StateContext
+
+	testCases := []struct {
+		name  string
+		id    string
+		want  string
+		error string
+	}{
+		{name: "by name", id: "fr-par-1/pn", want: newZonedIDString("fr-par-1", pn["id"].(string))},
+		{name: "by ID", id: "fr-par-1/11111111-1111-1111-1111-111111111111", want: "fr-par-1/11111111-1111-1111-1111-111111111111"},
+		{name: "not found", id: "fr-par-1/unknown", error: `no private network named "unknown" found in fr-par-1`},
+		{name: "ambiguous", id: "fr-par-1/duplicated", error: `2 private networks named "duplicated" found in fr-par-1`},
+		{name: "without locality", id: "pn", error: "expected {locality}/{id} or {locality}/{name}"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			d := f.resourceData(resourceScalewayVPCPrivateNetwork(), tc.id, map[string]interface{}{})
+			res, err := importer(ctx, d, meta)
+			if tc.error != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.error)
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, res, 1)
+			assert.Equal(t, tc.want, res[0].Id())
+		})
+	}
+}
+
+func TestScalewayVPCPrivateNetwork_FakeAPIReadOnly(t *testing.T) {
+	f := newFakeAPI(t)
+	defer f.close()
+	meta := f.metaWithConfig(map[string]interface{}{"read_only": true})
+	ctx := context.Background()
+
+	pn := f.seed("vpc", "fr-par-1", "private-networks", map[string]interface{}{"name": "pn"})
+	d := f.resourceData(resourceScalewayVPCPrivateNetwork(), newZonedIDString("fr-par-1", pn["id"].(string)), nil)
+
+	// Reads go through.
+	require.False(t, resourceScalewayVPCPrivateNetworkRead(ctx, d, meta).HasError())
+	assert.Equal(t, "pn", d.Get("name"))
+
+	// Changes are refused before reaching the API.
+	diags := resourceScalewayVPCPrivateNetworkDelete(ctx, d, meta)
+	require.True(t, diags.HasError())
+	assert.Contains(t, diags[0].Summary, "read-only mode: refusing to DELETE vpc resource private-networks/"+pn["id"].(string))
+	assert.NotNil(t, f.lookup("vpc", "fr-par-1", "private-networks", pn["id"].(string)))
+	for _, request := range f.receivedRequests() {
+		assert.True(t, strings.HasPrefix(request, http.MethodGet+" "), request)
+	}
+}
+
+func TestScalewayVPCPrivateNetwork_FakeAPICustomZone(t *testing.T) {
+	f := newFakeAPI(t)
+	defer f.close()
+	ctx := context.Background()
+	raw := map[string]interface{}{"name": "pn", "zone": "fr-tst-1"}
+
+	// The zone is refused until the provider knows it.
+	d := f.resourceData(resourceScalewayVPCPrivateNetwork(), "", raw)
+	diags := resourceScalewayVPCPrivateNetworkCreate(ctx, d, f.meta())
+	require.True(t, diags.HasError())
+	assert.Contains(t, diags[0].Summary, "zone fr-tst-1 is not known by the provider")
+
+	meta := f.metaWithConfig(map[string]interface{}{
+		"custom_region": []interface{}{
+			map[string]interface{}{"name": "fr-tst", "zones": []interface{}{"fr-tst-1"}},
+		},
+	})
+	d = f.resourceData(resourceScalewayVPCPrivateNetwork(), "", raw)
+	require.False(t, resourceScalewayVPCPrivateNetworkCreate(ctx, d, meta).HasError())
+	assert.Equal(t, "fr-tst-1", d.Get("zone"))
+	assert.NotNil(t, f.lookup("vpc", "fr-tst-1", "private-networks", expandZonedID(d.Id()).ID))
+
+	meta = f.metaWithConfig(map[string]interface{}{"skip_locality_validation": true})
+	d = f.resourceData(resourceScalewayVPCPrivateNetwork(), "", raw)
+	require.False(t, resourceScalewayVPCPrivateNetworkCreate(ctx, d, meta).HasError())
+}
+
+func TestScalewayVPCPrivateNetwork_FakeAPIInvalidArguments(t *testing.T) {
+	f := newFakeAPI(t)
+	defer f.close()
+	meta := f.meta()
+	ctx := context.Background()
+
+	f.respond(http.MethodPost, "/vpc/v1/zones/fr-par-1/private-networks", http.StatusBadRequest, map[string]interface{}{
+		"type":    "invalid_arguments",
+		"message": "invalid argument(s)",
+		"details": []interface{}{
+			map[string]interface{}{"argument_name": "name", "reason": "constraint", "help_message": "must match ^[a-z0-9-]+$"},
+			map[string]interface{}{"argument_name": "subnets", "reason": "required"},
+		},
+	}, true)
+
+	privateNetwork := Provider(DefaultProviderConfig())().ResourcesMap["scaleway_vpc_private_network"]
+	d := f.resourceData(privateNetwork, "", map[string]interface{}{"name": "Private Network"})
+
+	diags := privateNetwork.CreateContext(ctx, d, meta)
+	require.Len(t, diags, 2)
+	assert.Equal(t, "invalid argument name: does not respect constraint", diags[0].Summary)
+	assert.Equal(t, "must match ^[a-z0-9-]+$", diags[0].Detail)
+	assert.Equal(t, cty.GetAttrPath("name"), diags[0].AttributePath)
+
+	// subnets is not an attribute of the resource, the diagnostic does not point to any attribute.
+	assert.Equal(t, "invalid argument subnets: is required", diags[1].Summary)
+	assert.Nil(t, diags[1].AttributePath)
+}
@@ -5,28 +5,138 @@ import (
 	"context"
 	"io"
 	"io/ioutil"
+	"math"
+	"math/rand"
 	"net/http"
+	"strconv"
 	"time"
 
 	"github.com/hashicorp/go-retryablehttp"
 )
 
+const (
+	defaultRetryMaxAttempts = 4
+	defaultRetryMinWait     = 2 * time.Second
+	defaultRetryMaxWait     = 2 * time.Minute
+)
+
+// retryConfig is the retry policy applied to the requests made by the provider.
+type retryConfig struct {
+	// maxAttempts is the total number of attempts for a request, including the first one.
+	maxAttempts int
+	// minWait and maxWait bound the time to wait between two attempts.
+	minWait time.Duration
+	maxWait time.Duration
+	// jitter randomizes the exponential backoff between minWait and the computed wait.
+	jitter bool
+	// retryableStatusCodes are the HTTP status codes triggering a retry.
+	// If empty, 429 and 5xx (except 501) responses are retried.
+	retryableStatusCodes []int
+	// respectRetryAfter makes the backoff honor the Retry-After header sent by the API.
+	respectRetryAfter bool
+}
+
+// defaultRetryConfig returns the retry policy used when none is configured.
+func defaultRetryConfig() *retryConfig {
+	return &retryConfig{
+		maxAttempts:       defaultRetryMaxAttempts,
+		minWait:           defaultRetryMinWait,
+		maxWait:           defaultRetryMaxWait,
+		respectRetryAfter: true,
+	}
+}
+
+// checkRetry returns true if the request should be retried given the response or error.
+func (c *retryConfig) checkRetry(ctx context.Context, resp *http.Response, err error) (bool, error) {
+	// do not retry on context.Canceled or context.DeadlineExceeded
+	if ctx.Err() != nil {
+		return false, ctx.Err()
+	}
+
+	if resp == nil {
+		return true, err
+	}
+
+	if len(c.retryableStatusCodes) > 0 {
+		for _, code := range c.retryableStatusCodes {
+			if resp.StatusCode == code {
+				return true, nil
+			}
+		}
+		return false, nil
+	}
+
+	if resp.StatusCode == http.StatusTooManyRequests {
+		return true, err
+	}
+	return retryablehttp.DefaultRetryPolicy(ctx, resp, err)
+}
+
+// backoff returns the time to wait before the next attempt.
+func (c *retryConfig) backoff(min, max time.Duration, attemptNum int, resp *http.Response) time.Duration {
+	if c.respectRetryAfter {
+		if wait, ok := parseRetryAfter(resp); ok {
+			if wait > max {
+				return max
+			}
+			return wait
+		}
+	}
+
+	mult := math.Pow(2, float64(attemptNum)) * float64(min)
+	wait := time.Duration(mult)
+	if float64(wait) != mult || wait > max {
+		wait = max
+	}
+
+	if c.jitter && wait > min {
+		wait = min + time.Duration(rand.Int63n(int64(wait-min)))
+	}
+
+	return wait
+}
+
+// parseRetryAfter parses the Retry-After header of a response. It can be either a number of seconds or an HTTP date.
+func parseRetryAfter(resp *http.Response) (time.Duration, bool) {
+	if resp == nil {
+		return 0, false
+	}
+	if resp.StatusCode != http.StatusTooManyRequests && resp.StatusCode != http.StatusServiceUnavailable {
+		return 0, false
+	}
+
+	header := resp.Header.Get("Retry-After")
+	if header == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.ParseInt(header, 10, 64); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if date, err := http.ParseTime(header); err == nil {
+		wait := time.Until(date)
+		if wait < 0 {
+			wait = 0
+		}
+		return wait, true
+	}
+
+	return 0, false
+}
+
 // TODO Retry logic should be moved in the SDK
 // newRetryableTransport creates a http transport with retry capability.
-func newRetryableTransport(defaultTransport http.RoundTripper) http.RoundTripper {
+func newRetryableTransport(defaultTransport http.RoundTripper, config *retryConfig) http.RoundTripper {
 	c := retryablehttp.NewClient()
 	c.HTTPClient = &http.Client{Transport: defaultTransport}
 
-	c.RetryMax = 3
-	c.RetryWaitMax = 2 * time.Minute
+	c.RetryMax = config.maxAttempts - 1
+	c.RetryWaitMax = config.maxWait
 	c.Logger = l
-	c.RetryWaitMin = time.Second * 2
-	c.CheckRetry = func(_ context.Context, resp *http.Response, err error) (bool, error) {
-		if resp == nil || resp.StatusCode == http.StatusTooManyRequests {
-			return true, err
-		}
-		return retryablehttp.DefaultRetryPolicy(context.TODO(), resp, err)
-	}
+	c.RetryWaitMin = config.minWait
+	c.CheckRetry = config.checkRetry
+	c.Backoff = config.backoff
 
 	return &retryableTransport{c}
 }
@@ -50,6 +160,8 @@ func (c *retryableTransport) RoundTrip(r *http.Request) (*http.Response, error)
 	if err != nil {
 		return nil, err
 	}
+	// The context carries the cancellation of the request and its product to the next transports.
+	req = req.WithContext(r.Context())
 	for key, val := range r.Header {
 		req.Header.Set(key, val[0])
 	}
@@ -0,0 +1,115 @@
+package scaleway
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRetryConfigCheckRetry(t *testing.T) {
+	testCases := []struct {
+		name        string
+		codes       []int
+		statusCode  int
+		shouldRetry bool
+	}{
+		{name: "default too many requests", statusCode: http.StatusTooManyRequests, shouldRetry: true},
+		{name: "default service unavailable", statusCode: http.StatusServiceUnavailable, shouldRetry: true},
+		{name: "default not implemented", statusCode: http.StatusNotImplemented, shouldRetry: false},
+		{name: "default not found", statusCode: http.StatusNotFound, shouldRetry: false},
+		{name: "custom listed", codes: []int{http.StatusConflict}, statusCode: http.StatusConflict, shouldRetry: true},
+		{name: "custom not listed", codes: []int{http.StatusConflict}, statusCode: http.StatusServiceUnavailable, shouldRetry: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			config := defaultRetryConfig()
+			config.retryableStatusCodes = tc.codes
+			shouldRetry, _ := config.checkRetry(context.Background(), &http.Response{StatusCode: tc.statusCode}, nil)
+			assert.Equal(t, tc.shouldRetry, shouldRetry)
+		})
+	}
+}
+
+func TestLoadRetryConfig(t *testing.T) {
+	testCases := []struct {
+		name    string
+		minWait string
+		maxWait string
+		err     string
+	}{
+		{name: "valid", minWait: "1s", maxWait: "30s"},
+		{name: "equal", minWait: "5s", maxWait: "5s"},
+		{name: "inverted", minWait: "30s", maxWait: "1s", err: "retry min_wait 30s must not be greater than max_wait 1s"},
+		{name: "zero", minWait: "0s", maxWait: "1s", err: "retry min_wait and max_wait must be positive"},
+		{name: "negative", minWait: "1s", maxWait: "-1s", err: "retry min_wait and max_wait must be positive"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			d := schema.TestResourceDataRaw(t, Provider(DefaultProviderConfig())().Schema, map[string]interface{}{
+				"retry": []interface{}{map[string]interface{}{"min_wait": tc.minWait, "max_wait": tc.maxWait}},
+			})
+			config, err := loadRetryConfig(d)
+			if tc.err != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, *expandDuration(tc.minWait), config.minWait)
+			assert.Equal(t, *expandDuration(tc.maxWait), config.maxWait)
+		})
+	}
+}
+
+func TestRetryConfigBackoff(t *testing.T) {
+	config := defaultRetryConfig()
+	min, max := time.Second, 10*time.Second
+
+	assert.Equal(t, time.Second, config.backoff(min, max, 0, nil))
+	assert.Equal(t, 4*time.Second, config.backoff(min, max, 2, nil))
+	assert.Equal(t, max, config.backoff(min, max, 10, nil))
+
+	retryAfter := &http.Response{StatusCode: http.StatusTooManyRequests, Header: http.Header{"Retry-After": []string{"3"}}}
+	assert.Equal(t, 3*time.Second, config.backoff(min, max, 0, retryAfter))
+
+	config.respectRetryAfter = false
+	assert.Equal(t, time.Second, config.backoff(min, max, 0, retryAfter))
+
+	config.jitter = true
+	for i := 0; i < 10; i++ {
+		wait := config.backoff(min, max, 3, nil)
+		assert.True(t, wait >= min && wait <= 8*time.Second, "unexpected wait %s", wait)
+	}
+}
+
+func TestRetryableTransport(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	config := defaultRetryConfig()
+	config.minWait = time.Millisecond
+	config.maxWait = time.Millisecond
+
+	client := &http.Client{Transport: newRetryableTransport(http.DefaultTransport, config)}
+	resp, err := client.Get(server.URL)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, 3, attempts)
+}
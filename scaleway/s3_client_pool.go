@@ -0,0 +1,47 @@
+package scaleway
+
+import (
+	"sync"
+
+	"github.com/aws/aws-sdk-go/service/s3"
+)
+
+// s3ClientPool lazily creates the object storage clients of each region and project and shares them between buckets,
+// as creating an AWS session for every call is measurable during the refresh of large states.
+//
+// It is safe for concurrent use. A nil pool creates a new client on every call.
+type s3ClientPool struct {
+	mu      sync.Mutex
+	clients map[string]*s3.S3
+}
+
+func newS3ClientPool() *s3ClientPool {
+	return &s3ClientPool{clients: map[string]*s3.S3{}}
+}
+
+// get returns the client of a region, for the buckets of projectID or of the default project of the key when empty.
+// Clients share the http client of meta.
+func (p *s3ClientPool) get(meta *Meta, region, projectID string) (*s3.S3, error) {
+	newClient := func() (*s3.S3, error) {
+		accessKey := s3AccessKeyWithProjectID(meta.s3Credentials.accessKey, projectID)
+		return newS3Client(meta.httpClient, meta.endpoints, region, accessKey, meta.s3Credentials.secretKey)
+	}
+	if p == nil {
+		return newClient()
+	}
+
+	key := region + "/" + projectID
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if client, exist := p.clients[key]; exist {
+		return client, nil
+	}
+
+	client, err := newClient()
+	if err != nil {
+		return nil, err
+	}
+	l.Debugf("created object storage client for %s", key)
+	p.clients[key] = client
+	return client, nil
+}
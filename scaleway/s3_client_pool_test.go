@@ -0,0 +1,55 @@
+package scaleway
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestS3ClientPool(t *testing.T) {
+	defer unsetAWSCABundle(t)()
+	f := newFakeAPI(t)
+	defer f.close()
+	meta := f.meta()
+
+	client, err := meta.s3Clients.get(meta, "fr-par", "")
+	require.NoError(t, err)
+	sameClient, err := meta.s3Clients.get(meta, "fr-par", "")
+	require.NoError(t, err)
+	assert.Same(t, client, sameClient)
+
+	otherRegion, err := meta.s3Clients.get(meta, "nl-ams", "")
+	require.NoError(t, err)
+	assert.NotSame(t, client, otherRegion)
+	assert.Equal(t, "https://s3.nl-ams.scw.cloud", otherRegion.Endpoint)
+
+	otherProject, err := meta.s3Clients.get(meta, "fr-par", "11111111-1111-1111-1111-111111111111")
+	require.NoError(t, err)
+	assert.NotSame(t, client, otherProject)
+
+	// Concurrent lookups share a single client.
+	clients := make([]*s3.S3, 10)
+	wg := sync.WaitGroup{}
+	for i := range clients {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			clients[i], _ = meta.s3Clients.get(meta, "pl-waw", "")
+		}(i)
+	}
+	wg.Wait()
+	for _, c := range clients {
+		assert.Same(t, clients[0], c)
+	}
+
+	// A nil pool creates a new client on every call.
+	var pool *s3ClientPool
+	first, err := pool.get(meta, "fr-par", "")
+	require.NoError(t, err)
+	second, err := pool.get(meta, "fr-par", "")
+	require.NoError(t, err)
+	assert.NotSame(t, first, second)
+}
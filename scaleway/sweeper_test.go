@@ -1,18 +1,136 @@
 package scaleway
 
 import (
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/aws/aws-sdk-go/service/s3"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/scaleway/scaleway-sdk-go/scw"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	// SweepDryRun only lists the resources the sweepers would delete.
+	SweepDryRun = flag.Bool("sweep-dry-run", os.Getenv("TF_SWEEP_DRY_RUN") == "true", "List the resources matching the sweeper filters without deleting them")
+	// SweepPrefix only sweeps the resources whose name starts with the given prefix, eg tf- for the names generated by the provider.
+	SweepPrefix = flag.String("sweep-prefix", os.Getenv("TF_SWEEP_PREFIX"), "Only sweep the resources whose name starts with this prefix")
+	// SweepTags only sweeps the resources having all the given comma separated tags.
+	SweepTags = flag.String("sweep-tags", os.Getenv("TF_SWEEP_TAGS"), "Only sweep the resources having all these comma separated tags")
+	// SweepMinAge only sweeps the resources created more than the given duration ago.
+	SweepMinAge = flag.String("sweep-min-age", os.Getenv("TF_SWEEP_MIN_AGE"), "Only sweep the resources created more than this duration ago, eg 2h")
 )
 
 func TestMain(m *testing.M) {
 	resource.TestMain(m)
 }
 
+// sweptResource is a resource found by a sweeper.
+type sweptResource struct {
+	// kind is the kind of the resource used in logs, eg instance server.
+	kind string
+	id   string
+	// name is empty for resources without name, which are only swept with a prefix when selected by tags or age.
+	name string
+	tags []string
+	// createdAt is nil for resources without creation date, which are not swept when a minimum age is set.
+	createdAt *time.Time
+}
+
+// sweepFilter selects the resources deleted by the sweepers.
+type sweepFilter struct {
+	prefix string
+	tags   []string
+	minAge time.Duration
+	now    time.Time
+}
+
+// sweepFilterFromFlags returns the filter configured by the sweeper flags.
+func sweepFilterFromFlags() (*sweepFilter, error) {
+	filter := &sweepFilter{
+		prefix: *SweepPrefix,
+		now:    time.Now(),
+	}
+	for _, tag := range strings.Split(*SweepTags, ",") {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			filter.tags = append(filter.tags, tag)
+		}
+	}
+	if *SweepMinAge != "" {
+		minAge, err := time.ParseDuration(*SweepMinAge)
+		if err != nil {
+			return nil, fmt.Errorf("invalid sweeper minimum age %q: %s", *SweepMinAge, err)
+		}
+		filter.minAge = minAge
+	}
+	return filter, nil
+}
+
+// match returns true if the resource must be swept.
+func (f *sweepFilter) match(r *sweptResource) bool {
+	if f.prefix != "" {
+		// A resource without name cannot match the prefix, it must be explicitly selected by another filter.
+		if r.name == "" && len(f.tags) == 0 && f.minAge == 0 {
+			return false
+		}
+		if r.name != "" && !strings.HasPrefix(r.name, f.prefix) {
+			return false
+		}
+	}
+
+	for _, tag := range f.tags {
+		if !stringInSlice(tag, r.tags) {
+			return false
+		}
+	}
+
+	if f.minAge > 0 && (r.createdAt == nil || f.now.Sub(*r.createdAt) < f.minAge) {
+		return false
+	}
+
+	return true
+}
+
+// sweep deletes a resource with deleteFunc if it matches the sweeper filters.
+// In dry-run mode the resource is only logged.
+func sweep(r *sweptResource, deleteFunc func() error) error {
+	filter, err := sweepFilterFromFlags()
+	if err != nil {
+		return err
+	}
+
+	if !filter.match(r) {
+		l.Debugf("sweeper: skipping %s %s (%s)", r.kind, r.name, r.id)
+		return nil
+	}
+
+	if *SweepDryRun {
+		l.Infof("sweeper: dry-run, would delete %s %s (%s)", r.kind, r.name, r.id)
+		return nil
+	}
+
+	l.Debugf("sweeper: deleting %s %s (%s)", r.kind, r.name, r.id)
+	return deleteFunc()
+}
+
+func stringInSlice(value string, slice []string) bool {
+	for _, item := range slice {
+		if item == value {
+			return true
+		}
+	}
+	return false
+}
+
+// sweepZones runs the sweeper in every zone and returns the errors of all zones.
 func sweepZones(zones []scw.Zone, f func(scwClient *scw.Client, zone scw.Zone) error) error {
+	errs := []string(nil)
 	for _, zone := range zones {
 		client, err := sharedClientForZone(zone)
 		if err != nil {
@@ -20,18 +138,65 @@ func sweepZones(zones []scw.Zone, f func(scwClient *scw.Client, zone scw.Zone) e
 		}
 		err = f(client, zone)
 		if err != nil {
-			l.Warningf("error running sweepZones, ignoring: %s", err)
+			errs = append(errs, fmt.Sprintf("%s: %s", zone, err))
 		}
 	}
+	if len(errs) > 0 {
+		return fmt.Errorf("error running sweepZones: %s", strings.Join(errs, "; "))
+	}
 	return nil
 }
 
+// sweepAllZones runs the sweeper in every zone known by the SDK or declared in SCW_CUSTOM_REGIONS.
+func sweepAllZones(f func(scwClient *scw.Client, zone scw.Zone) error) error {
+	zones, _, err := sweeperLocalities()
+	if err != nil {
+		return err
+	}
+	return sweepZones(zones, f)
+}
+
+// sweepAllRegions runs the sweeper in every region known by the SDK or declared in SCW_CUSTOM_REGIONS.
+func sweepAllRegions(f func(scwClient *scw.Client, region scw.Region) error) error {
+	_, regions, err := sweeperLocalities()
+	if err != nil {
+		return err
+	}
+	return sweepRegions(regions, f)
+}
+
+// sweeperLocalities returns the zones and the regions known by the SDK or declared in SCW_CUSTOM_REGIONS, sorted by name.
+func sweeperLocalities() ([]scw.Zone, []scw.Region, error) {
+	localities, err := loadLocalities(nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	zones := []scw.Zone(nil)
+	regions := []scw.Region(nil)
+	for region := range localities.regions {
+		regions = append(regions, region)
+		zones = append(zones, localities.regionZones(region)...)
+	}
+	sort.Slice(zones, func(i, j int) bool { return zones[i] < zones[j] })
+	sort.Slice(regions, func(i, j int) bool { return regions[i] < regions[j] })
+	return zones, regions, nil
+}
+
+// sweepRegions runs the sweeper in every region and returns the errors of all regions.
 func sweepRegions(regions []scw.Region, f func(scwClient *scw.Client, region scw.Region) error) error {
+	errs := []string(nil)
 	for _, region := range regions {
-		return sweepZones(region.GetZones(), func(scwClient *scw.Client, zone scw.Zone) error {
-			r, _ := zone.Region()
-			return f(scwClient, r)
-		})
+		client, err := sharedClientForRegion(region)
+		if err != nil {
+			return err
+		}
+		err = f(client, region)
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("%s: %s", region, err))
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("error running sweepRegions: %s", strings.Join(errs, "; "))
 	}
 	return nil
 }
@@ -49,14 +214,88 @@ func sharedClientForZone(zone scw.Zone) (*scw.Client, error) {
 	return meta.scwClient, nil
 }
 
+// sharedClientForRegion returns a Scaleway client needed for the sweeper
+// functions for a given region
+func sharedClientForRegion(region scw.Region) (*scw.Client, error) {
+	zone, err := sweeperRegionZone(region)
+	if err != nil {
+		return nil, err
+	}
+	return sharedClientForZone(zone)
+}
+
 // sharedS3ClientForRegion returns a common S3 client needed for the sweeper
 func sharedS3ClientForRegion(region scw.Region) (*s3.S3, error) {
+	zone, err := sweeperRegionZone(region)
+	if err != nil {
+		return nil, err
+	}
 	meta, err := buildMeta(&MetaConfig{
 		terraformVersion: "terraform-tests",
-		forceZone:        region.GetZones()[0],
+		forceZone:        zone,
 	})
 	if err != nil {
 		return nil, err
 	}
 	return newS3ClientFromMeta(meta)
 }
+
+// sweeperRegionZone returns the first zone of a region, custom regions included.
+func sweeperRegionZone(region scw.Region) (scw.Zone, error) {
+	localities, err := loadLocalities(nil)
+	if err != nil {
+		return "", err
+	}
+	zones := localities.regionZones(region)
+	if len(zones) == 0 {
+		return "", fmt.Errorf("no zone found in region %s, declare its zones in %s", region, customRegionsEnv)
+	}
+	return zones[0], nil
+}
+
+func TestSweepFilter(t *testing.T) {
+	now := time.Date(2020, 1, 1, 12, 0, 0, 0, time.UTC)
+	hourAgo := now.Add(-time.Hour)
+	testCases := []struct {
+		name     string
+		filter   *sweepFilter
+		resource *sweptResource
+		match    bool
+	}{
+		{name: "no filter", filter: &sweepFilter{}, resource: &sweptResource{name: "prod"}, match: true},
+		{name: "prefix match", filter: &sweepFilter{prefix: "tf-"}, resource: &sweptResource{name: "tf-srv-sharp-bhabha"}, match: true},
+		{name: "prefix mismatch", filter: &sweepFilter{prefix: "tf-"}, resource: &sweptResource{name: "prod"}, match: false},
+		{name: "prefix without name", filter: &sweepFilter{prefix: "tf-"}, resource: &sweptResource{}, match: false},
+		{name: "prefix without name selected by tags", filter: &sweepFilter{prefix: "tf-", tags: []string{"terraform-test"}}, resource: &sweptResource{tags: []string{"terraform-test"}}, match: true},
+		{name: "prefix without name not selected by tags", filter: &sweepFilter{prefix: "tf-", tags: []string{"terraform-test"}}, resource: &sweptResource{}, match: false},
+		{name: "prefix without name selected by age", filter: &sweepFilter{prefix: "tf-", minAge: 30 * time.Minute, now: now}, resource: &sweptResource{createdAt: &hourAgo}, match: true},
+		{name: "tags match", filter: &sweepFilter{tags: []string{"terraform-test"}}, resource: &sweptResource{tags: []string{"foo", "terraform-test"}}, match: true},
+		{name: "tags mismatch", filter: &sweepFilter{tags: []string{"terraform-test", "foo"}}, resource: &sweptResource{tags: []string{"terraform-test"}}, match: false},
+		{name: "old enough", filter: &sweepFilter{minAge: 30 * time.Minute, now: now}, resource: &sweptResource{createdAt: &hourAgo}, match: true},
+		{name: "too recent", filter: &sweepFilter{minAge: 2 * time.Hour, now: now}, resource: &sweptResource{createdAt: &hourAgo}, match: false},
+		{name: "age without creation date", filter: &sweepFilter{minAge: time.Minute, now: now}, resource: &sweptResource{}, match: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.match, tc.filter.match(tc.resource))
+		})
+	}
+}
+
+func TestSweeperLocalities(t *testing.T) {
+	previous, exist := os.LookupEnv(customRegionsEnv)
+	defer func() {
+		if exist {
+			_ = os.Setenv(customRegionsEnv, previous)
+		} else {
+			_ = os.Unsetenv(customRegionsEnv)
+		}
+	}()
+
+	require.NoError(t, os.Setenv(customRegionsEnv, "fr-tst:fr-tst-1,fr-tst-2;nl-ams:nl-ams-9"))
+	zones, regions, err := sweeperLocalities()
+	require.NoError(t, err)
+	assert.Equal(t, []scw.Zone{scw.ZoneFrPar1, scw.ZoneFrPar2, "fr-tst-1", "fr-tst-2", scw.ZoneNlAms1, "nl-ams-9", scw.ZonePlWaw1}, zones)
+	assert.Equal(t, []scw.Region{scw.RegionFrPar, "fr-tst", scw.RegionNlAms, scw.RegionPlWaw}, regions)
+}
@@ -2,6 +2,7 @@ package scaleway
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/scaleway/scaleway-sdk-go/validation"
 )
@@ -30,3 +31,37 @@ func validationUUID() func(interface{}, string) ([]string, []error) {
 		return
 	}
 }
+
+// validationZone validates the schema is a well-formed zone, e.g. "fr-par-1".
+// Whether the zone is known by the provider is only checked once it is configured, see localities.
+func validationZone() func(interface{}, string) ([]string, []error) {
+	return func(v interface{}, key string) (warnings []string, errors []error) {
+		zone, isString := v.(string)
+		if !isString {
+			return nil, []error{fmt.Errorf("invalid zone for key '%s': not a string", key)}
+		}
+
+		if !validation.IsZone(strings.ToLower(zone)) {
+			return nil, []error{fmt.Errorf("invalid zone for key '%s': '%s': format should be 'xx-xxx-1', e.g. 'fr-par-1'", key, zone)}
+		}
+
+		return
+	}
+}
+
+// validationRegion validates the schema is a well-formed region, e.g. "fr-par".
+// Whether the region is known by the provider is only checked once it is configured, see localities.
+func validationRegion() func(interface{}, string) ([]string, []error) {
+	return func(v interface{}, key string) (warnings []string, errors []error) {
+		region, isString := v.(string)
+		if !isString {
+			return nil, []error{fmt.Errorf("invalid region for key '%s': not a string", key)}
+		}
+
+		if !validation.IsRegion(strings.ToLower(region)) {
+			return nil, []error{fmt.Errorf("invalid region for key '%s': '%s': format should be 'xx-xxx', e.g. 'fr-par'", key, region)}
+		}
+
+		return
+	}
+}